@@ -19,6 +19,22 @@ func (il *IntegerLiteral) String() string {
 	return il.Token.Literal
 }
 
+// FloatLiteral represents a float literal like `3.14`
+type FloatLiteral struct {
+	*BaseNode
+	Value float64
+}
+
+func (fl *FloatLiteral) expressionNode() {}
+
+// TokenLiteral returns float literal's token literal
+func (fl *FloatLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+func (fl *FloatLiteral) String() string {
+	return fl.Token.Literal
+}
+
 type StringLiteral struct {
 	*BaseNode
 	Value string
@@ -37,6 +53,54 @@ func (sl *StringLiteral) String() string {
 	return out.String()
 }
 
+// SymbolLiteral represents a symbol like `:foo`, its Value doesn't include the leading colon
+type SymbolLiteral struct {
+	*BaseNode
+	Value string
+}
+
+func (sl *SymbolLiteral) expressionNode() {}
+
+// TokenLiteral returns symbol literal's token literal
+func (sl *SymbolLiteral) TokenLiteral() string {
+	return sl.Token.Literal
+}
+func (sl *SymbolLiteral) String() string {
+	return ":" + sl.Value
+}
+
+// InterpolatedString represents a double-quoted string with embedded expressions like "Hello #{name}"
+type InterpolatedString struct {
+	*BaseNode
+	Elements []Expression
+}
+
+func (is *InterpolatedString) expressionNode() {}
+
+// TokenLiteral returns interpolated string's token literal
+func (is *InterpolatedString) TokenLiteral() string {
+	return is.Token.Literal
+}
+func (is *InterpolatedString) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("\"")
+
+	for _, elem := range is.Elements {
+		if sl, ok := elem.(*StringLiteral); ok {
+			out.WriteString(sl.Value)
+			continue
+		}
+
+		out.WriteString("#{")
+		out.WriteString(elem.String())
+		out.WriteString("}")
+	}
+
+	out.WriteString("\"")
+	return out.String()
+}
+
 type ArrayExpression struct {
 	*BaseNode
 	Elements []Expression
@@ -169,6 +233,32 @@ func (ae *AssignExpression) String() string {
 	return out.String()
 }
 
+// ArgumentPairExpression represents a keyword parameter like `host:` or `port: 80` in method definition.
+// Value is nil when the keyword is required.
+type ArgumentPairExpression struct {
+	*BaseNode
+	Key   *Identifier
+	Value Expression
+}
+
+func (ape *ArgumentPairExpression) expressionNode() {}
+func (ape *ArgumentPairExpression) TokenLiteral() string {
+	return ape.Token.Literal
+}
+func (ape *ArgumentPairExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ape.Key.String())
+	out.WriteString(":")
+
+	if ape.Value != nil {
+		out.WriteString(" ")
+		out.WriteString(ape.Value.String())
+	}
+
+	return out.String()
+}
+
 type BooleanExpression struct {
 	*BaseNode
 	Value bool
@@ -199,6 +289,33 @@ func (n *NilExpression) String() string {
 	return "nil"
 }
 
+// ForExpression represents `for variable in collection ... end`.
+// The variable is bound in the enclosing scope, and the expression returns the collection.
+type ForExpression struct {
+	*BaseNode
+	Variable   *Identifier
+	Collection Expression
+	Body       *BlockStatement
+}
+
+func (fe *ForExpression) expressionNode() {}
+func (fe *ForExpression) TokenLiteral() string {
+	return fe.Token.Literal
+}
+func (fe *ForExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("for ")
+	out.WriteString(fe.Variable.String())
+	out.WriteString(" in ")
+	out.WriteString(fe.Collection.String())
+	out.WriteString(" do\n")
+	out.WriteString(fe.Body.String())
+	out.WriteString("\nend")
+
+	return out.String()
+}
+
 type IfExpression struct {
 	*BaseNode
 	Conditionals []*ConditionalExpression
@@ -346,10 +463,149 @@ func (ye *YieldExpression) String() string {
 	return out.String()
 }
 
+// BeginExpression represents a `begin ... rescue => e ... end` expression.
+// If an error is raised in Body, the error is assigned to RescueVariable and Rescue block will be evaluated.
+type BeginExpression struct {
+	*BaseNode
+	Body           *BlockStatement
+	RescueVariable *Identifier
+	Rescue         *BlockStatement
+}
+
+func (be *BeginExpression) expressionNode() {}
+
+// TokenLiteral returns begin expression's token literal
+func (be *BeginExpression) TokenLiteral() string {
+	return be.Token.Literal
+}
+func (be *BeginExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("begin\n")
+	out.WriteString(be.Body.String())
+
+	if be.Rescue != nil {
+		out.WriteString("\nrescue")
+
+		if be.RescueVariable != nil {
+			out.WriteString(" => ")
+			out.WriteString(be.RescueVariable.String())
+		}
+
+		out.WriteString("\n")
+		out.WriteString(be.Rescue.String())
+	}
+
+	out.WriteString("\nend")
+
+	return out.String()
+}
+
+// CaseExpression represents a `case ... when ... else ... end` expression.
+// Subject is compared with each WhenExpression's values with `==`, and only the first matched branch is evaluated.
+type CaseExpression struct {
+	*BaseNode
+	Subject     Expression
+	Whens       []*WhenExpression
+	Alternative *BlockStatement
+}
+
+func (ce *CaseExpression) expressionNode() {}
+
+// TokenLiteral returns `case`
+func (ce *CaseExpression) TokenLiteral() string {
+	return ce.Token.Literal
+}
+func (ce *CaseExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("case ")
+	out.WriteString(ce.Subject.String())
+
+	for _, w := range ce.Whens {
+		out.WriteString("\n")
+		out.WriteString(w.String())
+	}
+
+	if ce.Alternative != nil {
+		out.WriteString("\nelse\n")
+		out.WriteString(ce.Alternative.String())
+	}
+
+	out.WriteString("\nend")
+
+	return out.String()
+}
+
+// WhenExpression represents a `when` branch of case expression, which can have multiple values like `when 1, 2`
+type WhenExpression struct {
+	*BaseNode
+	Values []Expression
+	Body   *BlockStatement
+}
+
+func (we *WhenExpression) expressionNode() {}
+
+// TokenLiteral returns `when`
+func (we *WhenExpression) TokenLiteral() string {
+	return we.Token.Literal
+}
+func (we *WhenExpression) String() string {
+	var out bytes.Buffer
+	values := []string{}
+
+	for _, v := range we.Values {
+		values = append(values, v.String())
+	}
+
+	out.WriteString("when ")
+	out.WriteString(strings.Join(values, ", "))
+	out.WriteString("\n")
+	out.WriteString(we.Body.String())
+
+	return out.String()
+}
+
+// SuperExpression represents calling the method with the same name in superclass.
+// Bare `super` has ImplicitArgs set, it means the current method's arguments will be passed.
+type SuperExpression struct {
+	*BaseNode
+	Arguments    []Expression
+	ImplicitArgs bool
+}
+
+func (se *SuperExpression) expressionNode() {}
+
+// TokenLiteral returns super expression's token literal
+func (se *SuperExpression) TokenLiteral() string {
+	return se.Token.Literal
+}
+func (se *SuperExpression) String() string {
+	var out bytes.Buffer
+	var args []string
+
+	if se.ImplicitArgs {
+		return se.TokenLiteral()
+	}
+
+	for _, arg := range se.Arguments {
+		args = append(args, arg.String())
+	}
+
+	out.WriteString(se.TokenLiteral())
+	out.WriteString("(")
+	out.WriteString(strings.Join(args, ", "))
+	out.WriteString(")")
+
+	return out.String()
+}
+
+// RangeExpression represents `start..end`, or `start...end` which excludes the end value
 type RangeExpression struct {
 	*BaseNode
-	Start Expression
-	End   Expression
+	Start     Expression
+	End       Expression
+	Exclusive bool
 }
 
 func (re *RangeExpression) expressionNode() {}
@@ -361,7 +617,11 @@ func (re *RangeExpression) String() string {
 
 	out.WriteString("(")
 	out.WriteString(re.Start.String())
-	out.WriteString("..")
+	if re.Exclusive {
+		out.WriteString("...")
+	} else {
+		out.WriteString("..")
+	}
 	out.WriteString(re.End.String())
 	out.WriteString(")")
 
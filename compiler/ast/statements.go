@@ -128,9 +128,10 @@ func (ds *DefStatement) String() string {
 	return out.String()
 }
 
-// NextStatement represents "next" keyword
+// NextStatement represents "next" keyword, Value is nil when it doesn't carry a value
 type NextStatement struct {
 	*BaseNode
+	Value Expression
 }
 
 func (ns *NextStatement) statementNode() {}
@@ -140,12 +141,17 @@ func (ns *NextStatement) TokenLiteral() string {
 	return ns.Token.Literal
 }
 func (ns *NextStatement) String() string {
+	if ns.Value != nil {
+		return "next " + ns.Value.String()
+	}
+
 	return "next"
 }
 
-// BreakStatement represents "break" keyword
+// BreakStatement represents "break" keyword, Value is nil when it doesn't carry a value
 type BreakStatement struct {
 	*BaseNode
+	Value Expression
 }
 
 func (bs *BreakStatement) statementNode() {}
@@ -155,6 +161,10 @@ func (bs *BreakStatement) TokenLiteral() string {
 	return bs.Token.Literal
 }
 func (bs *BreakStatement) String() string {
+	if bs.Value != nil {
+		return bs.TokenLiteral() + " " + bs.Value.String()
+	}
+
 	return bs.TokenLiteral()
 }
 
@@ -5,7 +5,7 @@ import (
 	"strings"
 )
 
-// Variable interface represents assignable nodes in Goby, currently are Identifier, InstanceVariable and Constant
+// Variable interface represents assignable nodes in Goby, currently are Identifier, InstanceVariable, ClassVariable, GlobalVariable and Constant
 type Variable interface {
 	variableNode()
 	ReturnValue() string
@@ -69,6 +69,42 @@ func (iv *InstanceVariable) String() string {
 	return iv.Value
 }
 
+// ClassVariable represents a variable like `@@count`, which is shared by the class, its subclasses and their instances
+type ClassVariable struct {
+	*BaseNode
+	Value string
+}
+
+func (cv *ClassVariable) variableNode() {}
+func (cv *ClassVariable) ReturnValue() string {
+	return cv.Value
+}
+func (cv *ClassVariable) expressionNode() {}
+func (cv *ClassVariable) TokenLiteral() string {
+	return cv.Token.Literal
+}
+func (cv *ClassVariable) String() string {
+	return cv.Value
+}
+
+// GlobalVariable represents a variable like `$config`, which can be accessed from anywhere
+type GlobalVariable struct {
+	*BaseNode
+	Value string
+}
+
+func (gv *GlobalVariable) variableNode() {}
+func (gv *GlobalVariable) ReturnValue() string {
+	return gv.Value
+}
+func (gv *GlobalVariable) expressionNode() {}
+func (gv *GlobalVariable) TokenLiteral() string {
+	return gv.Token.Literal
+}
+func (gv *GlobalVariable) String() string {
+	return gv.Value
+}
+
 type Constant struct {
 	*BaseNode
 	Value       string
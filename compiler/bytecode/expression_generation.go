@@ -12,10 +12,20 @@ func (g *Generator) compileExpression(is *InstructionSet, exp ast.Expression, sc
 		is.define(GetConstant, sourceLine, exp.Value, fmt.Sprint(exp.IsNamespace))
 	case *ast.InstanceVariable:
 		is.define(GetInstanceVariable, sourceLine, exp.Value)
+	case *ast.ClassVariable:
+		is.define(GetClassVariable, sourceLine, exp.Value)
+	case *ast.GlobalVariable:
+		is.define(GetGlobalVariable, sourceLine, exp.Value)
 	case *ast.IntegerLiteral:
 		is.define(PutObject, sourceLine, fmt.Sprint(exp.Value))
+	case *ast.FloatLiteral:
+		is.define(PutFloat, sourceLine, fmt.Sprint(exp.Value))
 	case *ast.StringLiteral:
 		is.define(PutString, sourceLine, exp.Value)
+	case *ast.SymbolLiteral:
+		is.define(PutSymbol, sourceLine, exp.Value)
+	case *ast.InterpolatedString:
+		g.compileInterpolatedString(is, exp, scope, table)
 	case *ast.BooleanExpression:
 		is.define(PutObject, sourceLine, fmt.Sprint(exp.Value))
 	case *ast.NilExpression:
@@ -23,7 +33,12 @@ func (g *Generator) compileExpression(is *InstructionSet, exp ast.Expression, sc
 	case *ast.RangeExpression:
 		g.compileExpression(is, exp.Start, scope, table)
 		g.compileExpression(is, exp.End, scope, table)
-		is.define(NewRange, sourceLine, 0)
+
+		if exp.Exclusive {
+			is.define(NewRange, sourceLine, 1)
+		} else {
+			is.define(NewRange, sourceLine, 0)
+		}
 	case *ast.ArrayExpression:
 		for _, elem := range exp.Elements {
 			g.compileExpression(is, elem, scope, table)
@@ -45,12 +60,23 @@ func (g *Generator) compileExpression(is *InstructionSet, exp ast.Expression, sc
 		g.compileIdentifier(is, exp, scope, table)
 	case *ast.AssignExpression:
 		g.compileAssignExpression(is, exp, scope, table)
+	case *ast.BeginExpression:
+		g.compileBeginExpression(is, exp, scope, table)
 	case *ast.IfExpression:
 		g.compileIfExpression(is, exp, scope, table)
+	case *ast.ForExpression:
+		g.compileForExpression(is, exp, scope, table)
+	case *ast.CaseExpression:
+		g.compileCaseExpression(is, exp, scope, table)
 	case *ast.YieldExpression:
 		g.compileYieldExpression(is, exp, scope, table)
+	case *ast.SuperExpression:
+		g.compileSuperExpression(is, exp, scope, table)
 	case *ast.CallExpression:
 		g.compileCallExpression(is, exp, scope, table)
+	default:
+		// Silently skipping a node would leave the stack unbalanced and break the vm in unexpected places
+		g.unsupportedNodeError(exp, sourceLine)
 	}
 }
 
@@ -67,6 +93,21 @@ func (g *Generator) compileIdentifier(is *InstructionSet, exp *ast.Identifier, s
 	is.define(Send, exp.Line(), exp.Value, 0)
 }
 
+func (g *Generator) compileSuperExpression(is *InstructionSet, exp *ast.SuperExpression, scope *scope, table *localTable) {
+	is.define(PutSelf, exp.Line())
+
+	if exp.ImplicitArgs {
+		is.define(InvokeSuper, exp.Line(), 0, "implicit")
+		return
+	}
+
+	for _, arg := range exp.Arguments {
+		g.compileExpression(is, arg, scope, table)
+	}
+
+	is.define(InvokeSuper, exp.Line(), len(exp.Arguments))
+}
+
 func (g *Generator) compileYieldExpression(is *InstructionSet, exp *ast.YieldExpression, scope *scope, table *localTable) {
 	is.define(PutSelf, exp.Line())
 
@@ -117,6 +158,10 @@ func (g *Generator) compileAssignExpression(is *InstructionSet, exp *ast.AssignE
 			is.define(SetLocal, exp.Line(), depth, index)
 		case *ast.InstanceVariable:
 			is.define(SetInstanceVariable, exp.Line(), name.Value)
+		case *ast.ClassVariable:
+			is.define(SetClassVariable, exp.Line(), name.Value)
+		case *ast.GlobalVariable:
+			is.define(SetGlobalVariable, exp.Line(), name.Value)
 		case *ast.Constant:
 			is.define(SetConstant, exp.Line(), name.Value)
 		}
@@ -143,13 +188,83 @@ func (g *Generator) compileBlockArgExpression(index int, exp *ast.CallExpression
 
 	for i := 0; i < len(exp.BlockArguments); i++ {
 		table.set(exp.BlockArguments[i].Value)
+		// Block arguments are recorded so a block can be used as a method's body, like `define_method`
+		is.argTypes = append(is.argTypes, NormalArg)
+		is.argNames = append(is.argNames, exp.BlockArguments[i].Value)
 	}
 
+	// Loops outside of the block can't be stopped by the block's `break` or `next`
+	outerNext, outerBreak := scope.anchors["next"], scope.anchors["break"]
+	scope.anchors["next"], scope.anchors["break"] = nil, nil
+
 	g.compileCodeBlock(is, exp.Block, scope, table)
+
+	scope.anchors["next"], scope.anchors["break"] = outerNext, outerBreak
+
 	g.endInstructions(is, exp.Line())
 	g.instructionSets = append(g.instructionSets, is)
 }
 
+// compileForExpression compiles a for loop into a while loop which walks the collection's `to_a` by index,
+// so `break` and `next` work like in `while` and the loop variable stays in the enclosing scope.
+// The collection itself is pushed as the loop's value, unless the loop is stopped by `break` with its value.
+func (g *Generator) compileForExpression(is *InstructionSet, exp *ast.ForExpression, scope *scope, table *localTable) {
+	line := exp.Line()
+	// Hidden locals can't clash with user's variables because they aren't valid identifiers
+	collectionIndex := table.set(fmt.Sprintf("%d:for_collection", table.count))
+	elemsIndex := table.set(fmt.Sprintf("%d:for_elements", table.count))
+	counterIndex := table.set(fmt.Sprintf("%d:for_counter", table.count))
+	condAnchor := &anchor{}
+	nextAnchor := &anchor{}
+	breakAnchor := &anchor{}
+
+	g.compileExpression(is, exp.Collection, scope, table)
+	is.define(SetLocal, line, 0, collectionIndex)
+	is.define(Send, line, "to_a", "0")
+	is.define(SetLocal, line, 0, elemsIndex)
+	is.define(Pop, line)
+	is.define(PutObject, line, 0)
+	is.define(SetLocal, line, 0, counterIndex)
+	is.define(Pop, line)
+	is.define(Jump, line, condAnchor)
+
+	bodyAnchor := &anchor{is.count}
+
+	is.define(GetLocal, line, 0, elemsIndex)
+	is.define(GetLocal, line, 0, counterIndex)
+	is.define(Send, line, "[]", "1")
+	index, depth := table.setLCL(exp.Variable.Value, table.depth)
+	is.define(SetLocal, line, depth, index)
+	is.define(Pop, line)
+
+	outerNext, outerBreak := scope.anchors["next"], scope.anchors["break"]
+	scope.anchors["next"] = nextAnchor
+	scope.anchors["break"] = breakAnchor
+
+	g.compileCodeBlock(is, exp.Body, scope, table)
+
+	scope.anchors["next"], scope.anchors["break"] = outerNext, outerBreak
+
+	nextAnchor.line = is.count
+
+	is.define(GetLocal, line, 0, counterIndex)
+	is.define(PutObject, line, 1)
+	is.define(Send, line, "+", "1")
+	is.define(SetLocal, line, 0, counterIndex)
+	is.define(Pop, line)
+
+	condAnchor.line = is.count
+
+	is.define(GetLocal, line, 0, counterIndex)
+	is.define(GetLocal, line, 0, elemsIndex)
+	is.define(Send, line, "length", "0")
+	is.define(Send, line, "<", "1")
+	is.define(BranchIf, line, bodyAnchor)
+	is.define(GetLocal, line, 0, collectionIndex)
+
+	breakAnchor.line = is.count
+}
+
 func (g *Generator) compileIfExpression(is *InstructionSet, exp *ast.IfExpression, scope *scope, table *localTable) {
 	anchorLast := &anchor{}
 
@@ -159,7 +274,7 @@ func (g *Generator) compileIfExpression(is *InstructionSet, exp *ast.IfExpressio
 		g.compileExpression(is, c.Condition, scope, table)
 		is.define(BranchUnless, exp.Line(), anchorConditional)
 
-		g.compileCodeBlock(is, c.Consequence, scope, table)
+		g.compileBlockValue(is, c.Consequence, exp.Line(), scope, table)
 		anchorConditional.line = is.count + 1
 		is.define(Jump, exp.Line(), anchorLast)
 	}
@@ -172,24 +287,109 @@ func (g *Generator) compileIfExpression(is *InstructionSet, exp *ast.IfExpressio
 		return
 	}
 
-	g.compileCodeBlock(is, exp.Alternative, scope, table)
+	g.compileBlockValue(is, exp.Alternative, exp.Line(), scope, table)
+
+	anchorLast.line = is.count
+}
+
+// compileCaseExpression keeps the subject on the stack and compares it with each `when` value with `==`.
+// The subject is popped before the matched branch (or the else branch) is evaluated.
+func (g *Generator) compileCaseExpression(is *InstructionSet, exp *ast.CaseExpression, scope *scope, table *localTable) {
+	anchorLast := &anchor{}
+
+	g.compileExpression(is, exp.Subject, scope, table)
+
+	for _, w := range exp.Whens {
+		anchorBody := &anchor{}
+		anchorNext := &anchor{}
+
+		for _, v := range w.Values {
+			is.define(Dup, w.Line())
+			g.compileExpression(is, v, scope, table)
+			is.define(Send, w.Line(), "==", 1)
+			is.define(BranchIf, w.Line(), anchorBody)
+		}
+
+		is.define(Jump, w.Line(), anchorNext)
+		anchorBody.line = is.count
+		is.define(Pop, w.Line())
+		g.compileBlockValue(is, w.Body, w.Line(), scope, table)
+		anchorNext.line = is.count + 1
+		is.define(Jump, w.Line(), anchorLast)
+	}
+
+	is.define(Pop, exp.Line())
+
+	if exp.Alternative == nil {
+		is.define(PutNull, exp.Line())
+	} else {
+		g.compileBlockValue(is, exp.Alternative, exp.Line(), scope, table)
+	}
+
+	anchorLast.line = is.count
+}
+
+// compileBeginExpression registers a rescue handler before the body, and removes it after the body is evaluated.
+// When an error is raised in the body, vm jumps to the instruction after `jump` with the error on the stack.
+func (g *Generator) compileBeginExpression(is *InstructionSet, exp *ast.BeginExpression, scope *scope, table *localTable) {
+	anchorRescue := &anchor{}
+	anchorLast := &anchor{}
+
+	is.define(PushRescue, exp.Line(), anchorRescue)
+	g.compileBlockValue(is, exp.Body, exp.Line(), scope, table)
+	is.define(PopRescue, exp.Line())
+	anchorRescue.line = is.count + 1
+	is.define(Jump, exp.Line(), anchorLast)
+
+	// The raised error is pushed on the stack
+	if exp.RescueVariable != nil {
+		index, depth := table.setLCL(exp.RescueVariable.Value, table.depth)
+		is.define(SetLocal, exp.Line(), depth, index)
+	}
+
+	is.define(Pop, exp.Line())
+
+	if exp.Rescue == nil {
+		is.define(PutNull, exp.Line())
+	} else {
+		g.compileBlockValue(is, exp.Rescue, exp.Line(), scope, table)
+	}
 
 	anchorLast.line = is.count
 }
 
+// compileBlockValue compiles the block and leaves its value on the stack, it's nil if the block is empty
+func (g *Generator) compileBlockValue(is *InstructionSet, block *ast.BlockStatement, line int, scope *scope, table *localTable) {
+	if len(block.Statements) == 0 {
+		is.define(PutNull, line)
+		return
+	}
+
+	g.compileCodeBlock(is, block, scope, table)
+}
+
 func (g *Generator) compilePrefixExpression(is *InstructionSet, exp *ast.PrefixExpression, scope *scope, table *localTable) {
 	switch exp.Operator {
 	case "!":
 		g.compileExpression(is, exp.Right, scope, table)
 		is.define(Send, exp.Line(), exp.Operator, 0)
 	case "-":
-		is.define(PutObject, exp.Line(), 0)
+		// Unary minus calls receiver's `-@` method, so `-"foo"` raises an UndefinedMethodError
 		g.compileExpression(is, exp.Right, scope, table)
-		is.define(Send, exp.Line(), exp.Operator, 1)
+		is.define(Send, exp.Line(), "-@", 0)
 	}
 }
 
 func (g *Generator) compileInfixExpression(is *InstructionSet, node *ast.InfixExpression, scope *scope, table *localTable) {
+	switch node.Operator {
+	case "&&":
+		g.compileAndExpression(is, node, scope, table)
+		return
+	case "||":
+		g.compileOrExpression(is, node, scope, table)
+		return
+	}
+
 	g.compileExpression(is, node.Left, scope, table)
 	g.compileExpression(is, node.Right, scope, table)
 
@@ -197,3 +397,55 @@ func (g *Generator) compileInfixExpression(is *InstructionSet, node *ast.InfixEx
 		is.define(Send, node.Line(), node.Operator, "1")
 	}
 }
+
+// compileAndExpression only evaluates the right operand when the left one is truthy,
+// otherwise the left operand is left on the stack as the result.
+func (g *Generator) compileAndExpression(is *InstructionSet, node *ast.InfixExpression, scope *scope, table *localTable) {
+	anchorLast := &anchor{}
+
+	g.compileExpression(is, node.Left, scope, table)
+	is.define(Dup, node.Line())
+	is.define(BranchUnless, node.Line(), anchorLast)
+	is.define(Pop, node.Line())
+	g.compileExpression(is, node.Right, scope, table)
+
+	anchorLast.line = is.count
+}
+
+// compileOrExpression only evaluates the right operand when the left one is falsey,
+// otherwise the left operand is left on the stack as the result.
+func (g *Generator) compileOrExpression(is *InstructionSet, node *ast.InfixExpression, scope *scope, table *localTable) {
+	anchorRight := &anchor{}
+	anchorLast := &anchor{}
+
+	g.compileExpression(is, node.Left, scope, table)
+	is.define(Dup, node.Line())
+	is.define(BranchUnless, node.Line(), anchorRight)
+	is.define(Jump, node.Line(), anchorLast)
+
+	anchorRight.line = is.count
+	is.define(Pop, node.Line())
+	g.compileExpression(is, node.Right, scope, table)
+
+	anchorLast.line = is.count
+}
+
+// compileInterpolatedString converts each embedded expression with `to_s` and concatenates all elements with `+`
+func (g *Generator) compileInterpolatedString(is *InstructionSet, exp *ast.InterpolatedString, scope *scope, table *localTable) {
+	if len(exp.Elements) == 0 {
+		is.define(PutString, exp.Line(), "")
+		return
+	}
+
+	for i, elem := range exp.Elements {
+		g.compileExpression(is, elem, scope, table)
+
+		if _, ok := elem.(*ast.StringLiteral); !ok {
+			is.define(Send, exp.Line(), "to_s", "0")
+		}
+
+		if i > 0 {
+			is.define(Send, exp.Line(), "+", "1")
+		}
+	}
+}
@@ -303,6 +303,74 @@ func TestBooleanCompilation(t *testing.T) {
 	compareBytecode(t, bytecode, expected)
 }
 
+func TestAndExpressionCompilation(t *testing.T) {
+	input := `
+	a = 1
+	a && 2
+`
+	expected := `
+<ProgramStart>
+0 putobject 1
+1 setlocal 0 0
+2 pop
+3 getlocal 0 0
+4 dup
+5 branchunless 8
+6 pop
+7 putobject 2
+8 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestOrExpressionCompilation(t *testing.T) {
+	input := `
+	a = 1
+	a || 2
+`
+	expected := `
+<ProgramStart>
+0 putobject 1
+1 setlocal 0 0
+2 pop
+3 getlocal 0 0
+4 dup
+5 branchunless 7
+6 jump 9
+7 pop
+8 putobject 2
+9 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestInterpolatedStringCompilation(t *testing.T) {
+	input := `
+	a = 1
+	"a=#{a}!"
+`
+	expected := `
+<ProgramStart>
+0 putobject 1
+1 setlocal 0 0
+2 pop
+3 putstring a=
+4 getlocal 0 0
+5 send to_s 0
+6 send + 1
+7 putstring !
+8 send + 1
+9 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
 func TestArrayCompilation(t *testing.T) {
 	input := `
 	a = [1, 2, "bar"]
@@ -457,6 +525,24 @@ func TestRangeCompilation(t *testing.T) {
 	compareBytecode(t, bytecode, expected)
 }
 
+func TestExclusiveRangeCompilation(t *testing.T) {
+	input := `
+	(1...5).to_a
+	`
+
+	expected := `
+<ProgramStart>
+0 putobject 1
+1 putobject 5
+2 newrange 1
+3 send to_a 0
+4 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
 func TestUnusedExpressionRemoval(t *testing.T) {
 	input := `
 	i = 0
@@ -496,3 +582,228 @@ func TestUnusedExpressionRemoval(t *testing.T) {
 	bytecode := compileToBytecode(input)
 	compareBytecode(t, bytecode, expected)
 }
+
+func TestBeginExpressionCompilation(t *testing.T) {
+	input := `
+	a = begin
+	  foo
+	rescue => e
+	  e
+	end
+	`
+
+	expected := `
+<ProgramStart>
+0 push_rescue 5
+1 putself
+2 send foo 0
+3 pop_rescue
+4 jump 8
+5 setlocal 0 0
+6 pop
+7 getlocal 0 0
+8 setlocal 0 1
+9 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestEmptyBeginExpressionCompilation(t *testing.T) {
+	input := `
+	begin
+	rescue
+	end
+	`
+
+	expected := `
+<ProgramStart>
+0 push_rescue 4
+1 putnil
+2 pop_rescue
+3 jump 6
+4 pop
+5 putnil
+6 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestCaseExpressionCompilation(t *testing.T) {
+	input := `
+	a = case x
+	when 1, 2
+	  10
+	else
+	  20
+	end
+	`
+
+	expected := `
+<ProgramStart>
+0 putself
+1 send x 0
+2 dup
+3 putobject 1
+4 send == 1
+5 branchif 11
+6 dup
+7 putobject 2
+8 send == 1
+9 branchif 11
+10 jump 14
+11 pop
+12 putobject 10
+13 jump 16
+14 pop
+15 putobject 20
+16 setlocal 0 0
+17 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestMinusPrefixCompilation(t *testing.T) {
+	input := `
+	a = 3 - -2
+	`
+
+	expected := `
+<ProgramStart>
+0 putobject 3
+1 putobject 2
+2 send -@ 0
+3 send - 1
+4 setlocal 0 0
+5 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestClassVariableCompilation(t *testing.T) {
+	input := `
+	@@foo = 1
+	@@foo + 2
+	`
+
+	expected := `
+<ProgramStart>
+0 putobject 1
+1 setclassvariable @@foo
+2 pop
+3 getclassvariable @@foo
+4 putobject 2
+5 send + 1
+6 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestGlobalVariableCompilation(t *testing.T) {
+	input := `
+	$foo = 1
+	$foo + 2
+	`
+
+	expected := `
+<ProgramStart>
+0 putobject 1
+1 setglobalvariable $foo
+2 pop
+3 getglobalvariable $foo
+4 putobject 2
+5 send + 1
+6 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestMultipleVariableAssignmentWithMultipleValuesCompilation(t *testing.T) {
+	input := `
+	a = 1
+	b = 2
+	a, b = b, a
+	`
+
+	expected := `
+<ProgramStart>
+0 putobject 1
+1 setlocal 0 0
+2 pop
+3 putobject 2
+4 setlocal 0 1
+5 pop
+6 getlocal 0 1
+7 getlocal 0 0
+8 newarray 2
+9 expand_array 2
+10 setlocal 0 0
+11 pop
+12 setlocal 0 1
+13 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestForExpressionCompilation(t *testing.T) {
+	input := `
+	for x in [1, 2] do
+	  puts(x)
+	end
+	x
+	`
+
+	expected := `
+<ProgramStart>
+0 putobject 1
+1 putobject 2
+2 newarray 2
+3 setlocal 0 0
+4 send to_a 0
+5 setlocal 0 1
+6 pop
+7 putobject 0
+8 setlocal 0 2
+9 pop
+10 jump 25
+11 getlocal 0 1
+12 getlocal 0 2
+13 send [] 1
+14 setlocal 0 3
+15 pop
+16 putself
+17 getlocal 0 3
+18 send puts 1
+19 pop
+20 getlocal 0 2
+21 putobject 1
+22 send + 1
+23 setlocal 0 2
+24 pop
+25 getlocal 0 2
+26 getlocal 0 1
+27 send length 0
+28 send < 1
+29 branchif 11
+30 getlocal 0 0
+31 pop
+32 getlocal 0 3
+33 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
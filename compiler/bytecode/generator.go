@@ -2,6 +2,7 @@ package bytecode
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 
 	"github.com/goby-lang/goby/compiler/ast"
@@ -25,6 +26,8 @@ type Generator struct {
 	instructionSets []*InstructionSet
 	blockCounter    int
 	scope           *scope
+	// err is the first error found during the compilation, like an unsupported node
+	err error
 }
 
 // NewGenerator initializes new Generator with complete AST tree.
@@ -43,19 +46,35 @@ func (g *Generator) InitTopLevelScope(program *ast.Program) {
 }
 
 // GenerateByteCode returns compiled instructions in string format
-func (g *Generator) GenerateByteCode(stmts []ast.Statement) string {
-	g.compileStatements(stmts, g.scope, g.scope.localTable)
+func (g *Generator) GenerateByteCode(stmts []ast.Statement) (string, error) {
+	if _, err := g.GenerateInstructions(stmts); err != nil {
+		return "", err
+	}
 
-	return strings.TrimSpace(strings.Replace(g.instructionsToString(), "\n\n", "\n", -1))
+	return strings.TrimSpace(strings.Replace(g.instructionsToString(), "\n\n", "\n", -1)), nil
 }
 
-// GenerateInstructions returns compiled instructions
-func (g *Generator) GenerateInstructions(stmts []ast.Statement) []*InstructionSet {
+// GenerateInstructions returns compiled instructions.
+// It returns an error if the statements contain nodes that can't be compiled, the instructions generated by
+// this call are discarded in that case.
+func (g *Generator) GenerateInstructions(stmts []ast.Statement) ([]*InstructionSet, error) {
+	generated := len(g.instructionSets)
+	g.err = nil
 	g.compileStatements(stmts, g.scope, g.scope.localTable)
 
-	//fmt.Println(g.instructionsToString())
-	//fmt.Print()
-	return g.instructionSets
+	if g.err != nil {
+		g.instructionSets = g.instructionSets[:generated]
+		return nil, g.err
+	}
+
+	return g.instructionSets, nil
+}
+
+// unsupportedNodeError records the error of a node the generator can't compile. Only the first error is kept.
+func (g *Generator) unsupportedNodeError(node interface{}, sourceLine int) {
+	if g.err == nil {
+		g.err = fmt.Errorf("Unsupported node type %T. line: %d", node, sourceLine)
+	}
 }
 
 func (g *Generator) instructionsToString() string {
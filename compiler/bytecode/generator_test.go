@@ -1,6 +1,7 @@
 package bytecode
 
 import (
+	"github.com/goby-lang/goby/compiler/ast"
 	"github.com/goby-lang/goby/compiler/lexer"
 	"github.com/goby-lang/goby/compiler/parser"
 	"strings"
@@ -305,6 +306,26 @@ func TestMethodDefWithDefaultValueArgument(t *testing.T) {
 	compareBytecode(t, bytecode, expected)
 }
 
+func TestUnsupportedNodeCompilation(t *testing.T) {
+	// A `when` expression can only be compiled as a part of `case` expression
+	exp := &ast.WhenExpression{BaseNode: &ast.BaseNode{}}
+	stmt := &ast.ExpressionStatement{BaseNode: &ast.BaseNode{}, Expression: exp}
+	program := &ast.Program{Statements: []ast.Statement{stmt}}
+
+	g := NewGenerator()
+	g.InitTopLevelScope(program)
+	_, err := g.GenerateByteCode(program.Statements)
+	expected := "Unsupported node type *ast.WhenExpression. line: 0"
+
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expect compilation to return error %q. got: %v", expected, err)
+	}
+
+	if _, err := g.GenerateInstructions(program.Statements); err == nil {
+		t.Fatalf("Expect generating instructions to return an error")
+	}
+}
+
 func compileToBytecode(input string) string {
 	l := lexer.New(input)
 	p := parser.New(l)
@@ -315,7 +336,11 @@ func compileToBytecode(input string) string {
 	}
 	g := NewGenerator()
 	g.InitTopLevelScope(program)
-	return g.GenerateByteCode(program.Statements)
+	out, genErr := g.GenerateByteCode(program.Statements)
+	if genErr != nil {
+		panic(genErr.Error())
+	}
+	return out
 }
 
 func compareBytecode(t *testing.T, value, expected string) {
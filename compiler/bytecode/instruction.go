@@ -19,12 +19,18 @@ const (
 	GetLocal            = "getlocal"
 	GetConstant         = "getconstant"
 	GetInstanceVariable = "getinstancevariable"
+	GetClassVariable    = "getclassvariable"
+	GetGlobalVariable   = "getglobalvariable"
 	SetLocal            = "setlocal"
 	SetConstant         = "setconstant"
 	SetInstanceVariable = "setinstancevariable"
+	SetClassVariable    = "setclassvariable"
+	SetGlobalVariable   = "setglobalvariable"
 	PutString           = "putstring"
+	PutSymbol           = "putsymbol"
 	PutSelf             = "putself"
 	PutObject           = "putobject"
+	PutFloat            = "putfloat"
 	PutNull             = "putnil"
 	NewArray            = "newarray"
 	ExpandArray         = "expand_array"
@@ -38,7 +44,12 @@ const (
 	DefClass            = "def_class"
 	Send                = "send"
 	InvokeBlock         = "invokeblock"
+	InvokeSuper         = "invokesuper"
 	Pop                 = "pop"
+	Dup                 = "dup"
+	PushRescue          = "push_rescue"
+	PopRescue           = "pop_rescue"
+	Break               = "break"
 	Leave               = "leave"
 )
 
@@ -92,6 +103,7 @@ type InstructionSet struct {
 	Instructions []*Instruction
 	count        int
 	argTypes     []int
+	argNames     []string
 }
 
 // ArgTypes returns enums that represents each argument's type
@@ -99,6 +111,11 @@ func (is *InstructionSet) ArgTypes() []int {
 	return is.argTypes
 }
 
+// ArgNames returns each argument's name, it's used for assigning keyword arguments
+func (is *InstructionSet) ArgNames() []string {
+	return is.argNames
+}
+
 // Name returns instruction set's name
 func (is *InstructionSet) Name() string {
 	return is.name
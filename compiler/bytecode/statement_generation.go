@@ -10,6 +10,9 @@ import (
 const (
 	NormalArg int = iota
 	OptionedArg
+	SplatArg
+	RequiredKeywordArg
+	OptionalKeywordArg
 )
 
 func (g *Generator) compileStatements(stmts []ast.Statement, scope *scope, table *localTable) {
@@ -28,10 +31,16 @@ func (g *Generator) compileStatement(is *InstructionSet, statement ast.Statement
 	switch stmt := statement.(type) {
 	case *ast.ExpressionStatement:
 		if !g.REPL && stmt.Expression.IsStmt() {
-			switch stmt.Expression.(type) {
-			case *ast.AssignExpression, *ast.IfExpression, *ast.Identifier, *ast.CallExpression, *ast.YieldExpression:
+			switch exp := stmt.Expression.(type) {
+			case *ast.AssignExpression, *ast.IfExpression, *ast.ForExpression, *ast.CaseExpression, *ast.BeginExpression, *ast.Identifier, *ast.CallExpression, *ast.YieldExpression, *ast.SuperExpression:
 				g.compileExpression(is, stmt.Expression, scope, table)
 				is.define(Pop, statement.Line())
+			case *ast.InfixExpression:
+				// Statements like `a && foo(a)` or `a << b` are used for their side effects
+				if exp.Operator == "&&" || exp.Operator == "||" || exp.Operator == "<<" {
+					g.compileExpression(is, exp, scope, table)
+					is.define(Pop, statement.Line())
+				}
 			}
 
 			return
@@ -62,9 +71,11 @@ func (g *Generator) compileStatement(is *InstructionSet, statement ast.Statement
 	case *ast.WhileStatement:
 		g.compileWhileStmt(is, stmt, scope, table)
 	case *ast.NextStatement:
-		g.compileNextStatement(is, stmt, scope)
+		g.compileNextStatement(is, stmt, scope, table)
 	case *ast.BreakStatement:
-		g.compileBreakStatement(is, stmt, scope)
+		g.compileBreakStatement(is, stmt, scope, table)
+	default:
+		g.unsupportedNodeError(stmt, statement.Line())
 	}
 }
 
@@ -80,28 +91,68 @@ func (g *Generator) compileWhileStmt(is *InstructionSet, stmt *ast.WhileStatemen
 
 	anchor2 := &anchor{is.count}
 
+	outerNext, outerBreak := scope.anchors["next"], scope.anchors["break"]
 	scope.anchors["next"] = anchor1
 	scope.anchors["break"] = breakAnchor
 
 	g.compileCodeBlock(is, stmt.Body, scope, table)
 
+	scope.anchors["next"], scope.anchors["break"] = outerNext, outerBreak
+
 	anchor1.line = is.count
 
 	g.compileExpression(is, stmt.Condition, scope, table)
 
 	is.define(BranchIf, stmt.Line(), anchor2)
 	is.define(PutNull, stmt.Line())
-	is.define(Pop, stmt.Line())
 
+	// `break` jumps here with its value, which is dropped because while is a statement
 	breakAnchor.line = is.count
+
+	is.define(Pop, stmt.Line())
 }
 
-func (g *Generator) compileNextStatement(is *InstructionSet, stmt ast.Statement, scope *scope) {
-	is.define(Jump, stmt.Line(), scope.anchors["next"])
+// compileNextStatement jumps to the loop's next iteration. Outside of loops, `next` in a block leaves the block
+// with given value as the result of current yield.
+func (g *Generator) compileNextStatement(is *InstructionSet, stmt *ast.NextStatement, scope *scope, table *localTable) {
+	if anchor := scope.anchors["next"]; anchor != nil {
+		if stmt.Value != nil {
+			g.compileExpression(is, stmt.Value, scope, table)
+			is.define(Pop, stmt.Line())
+		}
+
+		is.define(Jump, stmt.Line(), anchor)
+		return
+	}
+
+	if is.isType == Block {
+		g.compileJumpValue(is, stmt.Value, stmt.Line(), scope, table)
+		is.define(Leave, stmt.Line())
+	}
 }
 
-func (g *Generator) compileBreakStatement(is *InstructionSet, stmt ast.Statement, scope *scope) {
-	is.define(Jump, stmt.Line(), scope.anchors["break"])
+// compileBreakStatement jumps out of the loop with given value. Outside of loops, `break` in a block stops
+// the method the block is given to and makes the value the method call's result.
+func (g *Generator) compileBreakStatement(is *InstructionSet, stmt *ast.BreakStatement, scope *scope, table *localTable) {
+	if anchor := scope.anchors["break"]; anchor != nil {
+		g.compileJumpValue(is, stmt.Value, stmt.Line(), scope, table)
+		is.define(Jump, stmt.Line(), anchor)
+		return
+	}
+
+	if is.isType == Block {
+		g.compileJumpValue(is, stmt.Value, stmt.Line(), scope, table)
+		is.define(Break, stmt.Line())
+	}
+}
+
+func (g *Generator) compileJumpValue(is *InstructionSet, value ast.Expression, line int, scope *scope, table *localTable) {
+	if value == nil {
+		is.define(PutNull, line)
+		return
+	}
+
+	g.compileExpression(is, value, scope, table)
 }
 
 func (g *Generator) compileClassStmt(is *InstructionSet, stmt *ast.ClassStatement, scope *scope, table *localTable) {
@@ -164,17 +215,37 @@ func (g *Generator) compileDefStmt(is *InstructionSet, stmt *ast.DefStatement, s
 
 	for i := 0; i < len(stmt.Parameters); i++ {
 		var argType int
+		var argName string
 		switch exp := stmt.Parameters[i].(type) {
 		case *ast.Identifier:
 			argType = NormalArg
+			argName = exp.Value
 			scope.localTable.setLCL(exp.Value, scope.localTable.depth)
 		case *ast.AssignExpression:
 			argType = OptionedArg
+			argName = exp.Variables[0].(*ast.Identifier).Value
 			exp.Optioned = 1
 			g.compileAssignExpression(newIS, exp, scope, scope.localTable)
+		case *ast.PrefixExpression:
+			argType = SplatArg
+			argName = exp.Right.(*ast.Identifier).Value
+			scope.localTable.setLCL(argName, scope.localTable.depth)
+		case *ast.ArgumentPairExpression:
+			argName = exp.Key.Value
+
+			if exp.Value == nil {
+				argType = RequiredKeywordArg
+				scope.localTable.setLCL(argName, scope.localTable.depth)
+				break
+			}
+
+			argType = OptionalKeywordArg
+			assignment := &ast.AssignExpression{BaseNode: exp.BaseNode, Variables: []ast.Expression{exp.Key}, Value: exp.Value, Optioned: 1}
+			g.compileAssignExpression(newIS, assignment, scope, scope.localTable)
 		}
 
 		newIS.argTypes = append(newIS.argTypes, argType)
+		newIS.argNames = append(newIS.argNames, argName)
 	}
 
 	if len(stmt.BlockStatement.Statements) == 0 {
@@ -143,6 +143,34 @@ Foo.bar
 	compareBytecode(t, bytecode, expected)
 }
 
+func TestSuperCompilation(t *testing.T) {
+	input := `
+	def foo(a)
+	  super(a, 1)
+	  super
+	end
+	`
+	expected := `
+<Def:foo>
+0 putself
+1 getlocal 0 0
+2 putobject 1
+3 invokesuper 2
+4 pop
+5 putself
+6 invokesuper 0 implicit
+7 leave
+<ProgramStart>
+0 putself
+1 putstring foo
+2 def_method 1
+3 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
 func TestClassCompilation(t *testing.T) {
 	input := `
 class Bar
@@ -457,19 +485,19 @@ a + 100
 6 putobject 0
 7 setlocal 0 2
 8 pop
-9 jump 48
+9 jump 49
 10 putnil
 11 pop
-12 jump 48
+12 jump 49
 13 getlocal 0 0
 14 putobject 1
 15 send + 1
 16 setlocal 0 0
 17 pop
-18 jump 42
+18 jump 43
 19 putnil
 20 pop
-21 jump 42
+21 jump 43
 22 getlocal 0 1
 23 putobject 1
 24 send + 1
@@ -478,39 +506,66 @@ a + 100
 27 getlocal 0 1
 28 putobject 3
 29 send == 1
-30 branchunless 33
-31 jump 48
-32 jump 34
-33 putnil
-34 pop
-35 getlocal 0 2
-36 getlocal 0 0
-37 getlocal 0 1
-38 send * 1
-39 send + 1
-40 setlocal 0 2
-41 pop
-42 getlocal 0 1
-43 putobject 5
-44 send < 1
-45 branchif 22
-46 putnil
-47 pop
-48 getlocal 0 0
-49 putobject 10
-50 send < 1
-51 branchif 13
-52 putnil
-53 pop
-54 getlocal 0 2
-55 putobject 10
-56 send * 1
-57 setlocal 0 3
-58 pop
-59 getlocal 0 3
-60 putobject 100
-61 send + 1
-62 leave
+30 branchunless 34
+31 putnil
+32 jump 48
+33 jump 35
+34 putnil
+35 pop
+36 getlocal 0 2
+37 getlocal 0 0
+38 getlocal 0 1
+39 send * 1
+40 send + 1
+41 setlocal 0 2
+42 pop
+43 getlocal 0 1
+44 putobject 5
+45 send < 1
+46 branchif 22
+47 putnil
+48 pop
+49 getlocal 0 0
+50 putobject 10
+51 send < 1
+52 branchif 13
+53 putnil
+54 pop
+55 getlocal 0 2
+56 putobject 10
+57 send * 1
+58 setlocal 0 3
+59 pop
+60 getlocal 0 3
+61 putobject 100
+62 send + 1
+63 leave
+`
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestBreakAndNextInBlockCompilation(t *testing.T) {
+	input := `
+	[1, 2].each do |x|
+	  next x
+	  break
+	end
+	`
+
+	expected := `
+<Block:0>
+0 getlocal 0 0
+1 leave
+2 putnil
+3 break
+4 leave
+<ProgramStart>
+0 putobject 1
+1 putobject 2
+2 newarray 2
+3 send each 0 block:0
+4 leave
 `
 	bytecode := compileToBytecode(input)
 	compareBytecode(t, bytecode, expected)
@@ -2,8 +2,11 @@ package compiler
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/goby-lang/goby/compiler/bytecode"
 	"github.com/goby-lang/goby/compiler/lexer"
+	"github.com/goby-lang/goby/compiler/optimizer"
 	"github.com/goby-lang/goby/compiler/parser"
 )
 
@@ -13,23 +16,47 @@ func CompileToBytecode(input string) (string, error) {
 	p := parser.New(l)
 	program, err := p.ParseProgram()
 	if err != nil {
-		return "", fmt.Errorf(err.Message)
+		return "", parserError(p)
 	}
 	g := bytecode.NewGenerator()
 	g.InitTopLevelScope(program)
-	return g.GenerateByteCode(program.Statements), nil
+	return g.GenerateByteCode(program.Statements)
 }
 
 // CompileToInstructions compiles input source code into instruction set data structures
 func CompileToInstructions(input string, parserMode int) ([]*bytecode.InstructionSet, error) {
+	return compileToInstructions(input, parserMode, false)
+}
+
+// CompileToOptimizedInstructions is like CompileToInstructions, but folds constant expressions like `2 + 3 * 4` before
+// generating instructions. See the optimizer package for what's folded.
+func CompileToOptimizedInstructions(input string, parserMode int) ([]*bytecode.InstructionSet, error) {
+	return compileToInstructions(input, parserMode, true)
+}
+
+func compileToInstructions(input string, parserMode int, optimize bool) ([]*bytecode.InstructionSet, error) {
 	l := lexer.New(input)
 	p := parser.New(l)
 	p.Mode = parserMode
 	program, err := p.ParseProgram()
 	if err != nil {
-		return nil, fmt.Errorf(err.Message)
+		return nil, parserError(p)
+	}
+	if optimize {
+		optimizer.FoldConstants(program)
 	}
 	g := bytecode.NewGenerator()
 	g.InitTopLevelScope(program)
-	return g.GenerateInstructions(program.Statements), nil
+	return g.GenerateInstructions(program.Statements)
+}
+
+// parserError combines all syntax errors found by the parser into one error, one message per line
+func parserError(p *parser.Parser) error {
+	messages := []string{}
+
+	for _, err := range p.Errors() {
+		messages = append(messages, err.Message)
+	}
+
+	return fmt.Errorf("%s", strings.Join(messages, "\n"))
 }
@@ -3,6 +3,7 @@ package lexer
 import (
 	"github.com/goby-lang/goby/compiler/token"
 	"github.com/looplab/fsm"
+	"strings"
 )
 
 // Lexer is used for tokenizing programs
@@ -13,6 +14,12 @@ type Lexer struct {
 	ch           rune
 	line         int
 	FSM          *fsm.FSM
+	// pendingTokens holds tokens which are already lexed but not returned yet, e.g. tokens inside string interpolation
+	pendingTokens []token.Token
+	// heredocLineEnd and heredocResume record the line of the last heredoc and where its body ends,
+	// so the next heredoc at the same line starts after it, like `<<~A + <<~B`
+	heredocLineEnd int
+	heredocResume  int
 }
 
 // New initializes a new lexer with input string
@@ -41,11 +48,22 @@ func New(input string) *Lexer {
 func (l *Lexer) NextToken() token.Token {
 
 	var tok token.Token
+
+	if len(l.pendingTokens) > 0 {
+		tok = l.pendingTokens[0]
+		l.pendingTokens = l.pendingTokens[1:]
+		return tok
+	}
+
 	l.resetNosymbol()
 
 	l.skipWhitespace()
 	switch l.ch {
 	case '"', '\'':
+		if l.ch == '"' && l.hasInterpolation() {
+			return l.readInterpolatedString()
+		}
+
 		tok.Literal = l.readString(l.ch)
 		tok.Type = token.String
 		tok.Line = l.line
@@ -55,6 +73,10 @@ func (l *Lexer) NextToken() token.Token {
 			currentByte := l.ch
 			l.readChar()
 			tok = token.Token{Type: token.Eq, Literal: string(currentByte) + string(l.ch), Line: l.line}
+		} else if l.peekChar() == '>' {
+			currentByte := l.ch
+			l.readChar()
+			tok = token.Token{Type: token.HashRocket, Literal: string(currentByte) + string(l.ch), Line: l.line}
 		} else {
 			tok = newToken(token.Assign, l.ch, l.line)
 		}
@@ -84,11 +106,19 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.Bang, l.ch, l.line)
 		}
 	case '/':
-		tok = newToken(token.Slash, l.ch, l.line)
+		if l.peekChar() == '=' {
+			l.readChar()
+			tok = token.Token{Type: token.SlashEq, Literal: "/=", Line: l.line}
+		} else {
+			tok = newToken(token.Slash, l.ch, l.line)
+		}
 	case '*':
 		if l.peekChar() == '*' {
 			l.readChar()
 			tok = token.Token{Type: token.Pow, Literal: "**", Line: l.line}
+		} else if l.peekChar() == '=' {
+			l.readChar()
+			tok = token.Token{Type: token.AsteriskEq, Literal: "*=", Line: l.line}
 		} else {
 			tok = newToken(token.Asterisk, l.ch, l.line)
 		}
@@ -101,6 +131,13 @@ func (l *Lexer) NextToken() token.Token {
 			} else {
 				tok = token.Token{Type: token.LTE, Literal: "<=", Line: l.line}
 			}
+		} else if l.peekChar() == '<' {
+			if l.isHeredocStart() {
+				return l.readHeredoc()
+			}
+
+			l.readChar()
+			tok = token.Token{Type: token.LShift, Literal: "<<", Line: l.line}
 		} else {
 			tok = newToken(token.LT, l.ch, l.line)
 		}
@@ -113,6 +150,8 @@ func (l *Lexer) NextToken() token.Token {
 		}
 	case ';':
 		tok = newToken(token.Semicolon, l.ch, l.line)
+	case '?':
+		tok = newToken(token.Question, l.ch, l.line)
 	case '(':
 		tok = newToken(token.LParen, l.ch, l.line)
 	case ')':
@@ -146,8 +185,16 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.RBracket, l.ch, l.line)
 	case '.':
 		if l.peekChar() == '.' {
-			tok = token.Token{Type: token.Range, Literal: "..", Line: l.line}
 			l.readChar()
+
+			// Three dots make an exclusive range like `1...5`
+			if l.peekChar() == '.' {
+				l.readChar()
+				tok = token.Token{Type: token.Range, Literal: "...", Line: l.line}
+			} else {
+				tok = token.Token{Type: token.Range, Literal: "..", Line: l.line}
+			}
+
 			l.readChar()
 			return tok
 		}
@@ -166,7 +213,7 @@ func (l *Lexer) NextToken() token.Token {
 
 			} else if isLetter(l.peekChar()) {
 				tok.Literal = string(l.readSymbol())
-				tok.Type = token.String
+				tok.Type = token.Symbol
 				tok.Line = l.line
 				return tok
 
@@ -193,11 +240,12 @@ func (l *Lexer) NextToken() token.Token {
 		}
 	case '%':
 		tok = newToken(token.Modulo, l.ch, l.line)
+	case '$':
+		return l.readGlobalVariable()
 	case '#':
-		tok.Literal = string(l.absorbComment())
-		tok.Type = token.Comment
-		tok.Line = l.line
-		return tok
+		// Comments produce no tokens, so they can be placed between any tokens
+		l.absorbComment()
+		return l.NextToken()
 	case 0:
 		tok.Literal = ""
 		tok.Type = token.EOF
@@ -234,6 +282,10 @@ func (l *Lexer) NextToken() token.Token {
 			}
 			return tok
 		} else if isInstanceVariable(l.ch) {
+			if l.peekChar() == '@' {
+				return l.readClassVariable()
+			}
+
 			if isLetter(l.peekChar()) {
 				tok.Literal = string(l.readInstanceVariable())
 				tok.Type = token.InstanceVariable
@@ -246,6 +298,15 @@ func (l *Lexer) NextToken() token.Token {
 			tok.Literal = string(l.readNumber())
 			tok.Type = token.Int
 			tok.Line = l.line
+
+			// Only treat the dot as decimal point when a digit follows it,
+			// so `1.to_s` and `1..5` are still lexed as integers.
+			if l.ch == '.' && isDigit(l.peekChar()) {
+				l.readChar()
+				tok.Literal = tok.Literal + "." + string(l.readNumber())
+				tok.Type = token.Float
+			}
+
 			return tok
 		}
 
@@ -311,6 +372,45 @@ func (l *Lexer) readInstanceVariable() []rune {
 	return l.input[position:l.position]
 }
 
+// readClassVariable returns a class variable token like `@@foo`, or an illegal token if there's no name after `@@`
+func (l *Lexer) readClassVariable() token.Token {
+	line := l.line
+	position := l.position
+	l.readChar()
+
+	if !isLetter(l.peekChar()) {
+		l.readChar()
+		return token.Token{Type: token.Illegal, Literal: "@@", Line: line}
+	}
+
+	l.readChar()
+
+	for isLetter(l.ch) || isDigit(l.ch) {
+		l.readChar()
+	}
+
+	return token.Token{Type: token.ClassVariable, Literal: string(l.input[position:l.position]), Line: line}
+}
+
+// readGlobalVariable returns a global variable token like `$foo`, or an illegal token if there's no name after `$`
+func (l *Lexer) readGlobalVariable() token.Token {
+	line := l.line
+	position := l.position
+
+	if !isLetter(l.peekChar()) {
+		l.readChar()
+		return token.Token{Type: token.Illegal, Literal: "$", Line: line}
+	}
+
+	l.readChar()
+
+	for isLetter(l.ch) || isDigit(l.ch) {
+		l.readChar()
+	}
+
+	return token.Token{Type: token.GlobalVariable, Literal: string(l.input[position:l.position]), Line: line}
+}
+
 func (l *Lexer) readString(ch rune) string {
 	l.readChar()
 
@@ -342,6 +442,251 @@ func (l *Lexer) readString(ch rune) string {
 	return result
 }
 
+// hasInterpolation looks ahead the double-quoted string starting at current position and returns true if it contains `#{`
+func (l *Lexer) hasInterpolation() bool {
+	for i := l.readPosition; i < len(l.input); i++ {
+		switch l.input[i] {
+		case '\\':
+			i++
+		case '"':
+			return false
+		case '#':
+			if i+1 < len(l.input) && l.input[i+1] == '{' {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// readInterpolatedString returns the beginning token of an interpolated string like "Hello #{name}!",
+// the rest of tokens (string segments, tokens of embedded expressions and the ending token) are kept in pendingTokens.
+func (l *Lexer) readInterpolatedString() token.Token {
+	line := l.line
+	l.readChar() // skip the beginning quote
+
+	l.readStringSegments('"', line)
+
+	l.readChar() // skip the ending quote
+	l.pendingTokens = append(l.pendingTokens, token.Token{Type: token.InterpolatedStringEnd, Literal: "\"", Line: line})
+
+	return token.Token{Type: token.InterpolatedStringBegin, Literal: "\"", Line: line}
+}
+
+// readStringSegments reads a double-quoted string's content until given ending character and appends its string segments
+// and tokens of embedded expressions to pendingTokens. It stops at the ending character, or at the end of input if end is 0.
+func (l *Lexer) readStringSegments(end rune, line int) {
+	result := ""
+
+	for l.ch != end && l.ch != 0 {
+		switch {
+		case isEscapedChar(l.ch):
+			result += escapedCharResult('"', l.peekChar())
+			l.readChar()
+			l.readChar()
+		case l.ch == '#' && l.peekChar() == '{':
+			if result != "" {
+				l.pendingTokens = append(l.pendingTokens, token.Token{Type: token.String, Literal: result, Line: line})
+				result = ""
+			}
+
+			l.readChar()
+			l.readChar()
+
+			l.pendingTokens = append(l.pendingTokens, token.Token{Type: token.InterpolationBegin, Literal: token.InterpolationBegin, Line: line})
+
+			sub := New(l.readInterpolation())
+
+			for tok := sub.NextToken(); tok.Type != token.EOF; tok = sub.NextToken() {
+				tok.Line += line
+				l.pendingTokens = append(l.pendingTokens, tok)
+			}
+
+			l.pendingTokens = append(l.pendingTokens, token.Token{Type: token.InterpolationEnd, Literal: token.InterpolationEnd, Line: line})
+		default:
+			result += string(l.ch)
+			l.readChar()
+		}
+	}
+
+	if result != "" {
+		l.pendingTokens = append(l.pendingTokens, token.Token{Type: token.String, Literal: result, Line: line})
+	}
+}
+
+// readInterpolation returns the source of an embedded expression and moves to the character after its closing brace.
+func (l *Lexer) readInterpolation() string {
+	position := l.position
+	depth := 0
+
+	for l.ch != 0 {
+		switch l.ch {
+		case '"', '\'':
+			// skip strings inside the expression so their braces and quotes won't be counted
+			quote := l.ch
+			l.readChar()
+
+			for l.ch != quote && l.ch != 0 {
+				if isEscapedChar(l.ch) {
+					l.readChar()
+				}
+				l.readChar()
+			}
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				source := string(l.input[position:l.position])
+				l.readChar()
+				return source
+			}
+
+			depth--
+		}
+
+		l.readChar()
+	}
+
+	return string(l.input[position:l.position])
+}
+
+// isHeredocStart returns true if current `<<` begins a heredoc like `<<~EOS` or `<<-EOS`, whose delimiter can be quoted
+func (l *Lexer) isHeredocStart() bool {
+	i := l.readPosition + 1 // the character after `<<`
+
+	if i >= len(l.input) || (l.input[i] != '~' && l.input[i] != '-') {
+		return false
+	}
+
+	i++
+
+	if i < len(l.input) && (l.input[i] == '\'' || l.input[i] == '"') {
+		i++
+	}
+
+	return i < len(l.input) && isLetter(l.input[i])
+}
+
+// readHeredoc reads a heredoc like `<<~EOS`. Its body starts from the next line and ends before the line of the delimiter,
+// `<<~` also removes the body's common indentation while `<<-` keeps it.
+// The body is processed like a double-quoted string, unless the delimiter is single-quoted like `<<~'EOS'`.
+// Rest of the beginning line is lexed as usual.
+func (l *Lexer) readHeredoc() token.Token {
+	line := l.line
+	l.readChar()
+	l.readChar() // skip `<<`
+	squiggly := l.ch == '~'
+	l.readChar()
+
+	var quote rune
+
+	if l.ch == '\'' || l.ch == '"' {
+		quote = l.ch
+		l.readChar()
+	}
+
+	position := l.position
+
+	for isLetter(l.ch) || isDigit(l.ch) {
+		l.readChar()
+	}
+
+	delimiter := string(l.input[position:l.position])
+
+	if quote != 0 && l.ch == quote {
+		l.readChar()
+	}
+
+	lines := l.cutHeredocBody(delimiter)
+
+	if squiggly {
+		lines = removeIndentation(lines)
+	}
+
+	body := ""
+
+	for _, s := range lines {
+		body += s + "\n"
+	}
+
+	if quote == '\'' {
+		return token.Token{Type: token.String, Literal: body, Line: line}
+	}
+
+	sub := New(body)
+	sub.readStringSegments(0, line)
+
+	// Without interpolation, there's at most one segment
+	switch len(sub.pendingTokens) {
+	case 0:
+		return token.Token{Type: token.String, Literal: "", Line: line}
+	case 1:
+		return sub.pendingTokens[0]
+	}
+
+	l.pendingTokens = append(l.pendingTokens, sub.pendingTokens...)
+	l.pendingTokens = append(l.pendingTokens, token.Token{Type: token.InterpolatedStringEnd, Literal: "\"", Line: line})
+
+	return token.Token{Type: token.InterpolatedStringBegin, Literal: "\"", Line: line}
+}
+
+// cutHeredocBody returns lines after current line until the line of given delimiter, or until the end of input if there's no such line.
+// The lines are removed from input, but their newlines are kept so the following tokens still have correct line numbers.
+func (l *Lexer) cutHeredocBody(delimiter string) []string {
+	start := l.position
+
+	for start < len(l.input) && l.input[start] != '\n' {
+		start++
+	}
+
+	if start >= len(l.input) {
+		return []string{}
+	}
+
+	bodyStart := start + 1
+
+	if l.heredocResume > 0 && l.heredocLineEnd == start && l.heredocResume <= len(l.input) {
+		bodyStart = l.heredocResume
+	}
+
+	lines := []string{}
+	end := len(l.input)
+
+	for i := bodyStart; i < len(l.input); {
+		j := i
+
+		for j < len(l.input) && l.input[j] != '\n' {
+			j++
+		}
+
+		current := string(l.input[i:j])
+
+		if strings.TrimSpace(current) == delimiter {
+			end = j
+			break
+		}
+
+		lines = append(lines, current)
+		i = j + 1
+	}
+
+	input := append([]rune{}, l.input[:bodyStart]...)
+
+	for _, ch := range l.input[bodyStart:end] {
+		if ch == '\n' {
+			input = append(input, ch)
+		}
+	}
+
+	// Skip the delimiter's newline
+	l.heredocLineEnd = start
+	l.heredocResume = len(input) + 1
+	l.input = append(input, l.input[end:]...)
+
+	return lines
+}
+
 func (l *Lexer) readSymbol() []rune {
 	l.readChar()
 
@@ -351,18 +696,20 @@ func (l *Lexer) readSymbol() []rune {
 		l.readChar()
 	}
 
+	// Symbol can end with `?` like `:nil?`
+	if l.peekChar() == '?' {
+		l.readChar()
+	}
+
 	l.readChar()                           // currently at string's last letter
 	result := l.input[position:l.position] // get full string
 	return result
 }
 
-func (l *Lexer) absorbComment() []rune {
-	p := l.position
+func (l *Lexer) absorbComment() {
 	for l.ch != '\n' && l.ch != 0 {
 		l.readChar()
 	}
-	result := l.input[p:l.position]
-	return result
 }
 
 func (l *Lexer) readChar() {
@@ -420,20 +767,59 @@ func escapedCharResult(quotedChar rune, peeked rune) string {
 			return "\""
 		case '\'':
 			return "'"
+		case '#':
+			return "#"
+		case '0':
+			return "\x00"
 		default:
 			return "\\" + string(peeked)
 		}
 	}
+	// Single-quoted strings only unescape the quote and the backslash itself
 	switch peeked {
 	case '"':
 		return "\\\""
 	case '\'':
 		return "'"
+	case '\\':
+		return "\\"
 	default:
 		return "\\" + string(peeked)
 	}
 }
 
+// removeIndentation removes the smallest indentation of non-blank lines from each line
+func removeIndentation(lines []string) []string {
+	indent := -1
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if indent == -1 || n < indent {
+			indent = n
+		}
+	}
+
+	result := make([]string, len(lines))
+
+	for i, line := range lines {
+		// Only blank lines can be shorter than the indentation
+		if len(line) <= indent {
+			result[i] = ""
+		} else if indent > 0 {
+			result[i] = line[indent:]
+		} else {
+			result[i] = line
+		}
+	}
+
+	return result
+}
+
 func newToken(tokenType token.Type, ch rune, line int) token.Token {
 	return token.Token{Type: tokenType, Literal: string(ch), Line: line}
 }
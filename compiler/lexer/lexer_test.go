@@ -258,9 +258,6 @@ func TestNextToken(t *testing.T) {
 		{token.Int, "9", 37},
 		{token.Semicolon, ";", 37},
 
-		{token.Comment, "# This is comment.", 39},
-		{token.Comment, "# And I should be ignored.", 40},
-
 		{token.LBracket, "[", 42},
 		{token.Int, "1", 42},
 		{token.Comma, ",", 42},
@@ -381,7 +378,7 @@ func TestNextToken(t *testing.T) {
 		{token.String, "", 91},
 
 		{token.Next, "next", 93},
-		{token.String, "apple", 94},
+		{token.Symbol, "apple", 94},
 
 		{token.LBrace, "{", 95},
 		{token.Ident, "test", 95},
@@ -392,7 +389,7 @@ func TestNextToken(t *testing.T) {
 		{token.LBrace, "{", 96},
 		{token.Ident, "test", 96},
 		{token.Colon, ":", 96},
-		{token.String, "abc", 96},
+		{token.Symbol, "abc", 96},
 		{token.RBrace, "}", 96},
 
 		{token.LBrace, "{", 97},
@@ -474,3 +471,356 @@ func TestNextToken(t *testing.T) {
 		}
 	}
 }
+
+func TestHashRocketToken(t *testing.T) {
+	input := `{ "a" => 1 }`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.LBrace, "{"},
+		{token.String, "a"},
+		{token.HashRocket, "=>"},
+		{token.Int, "1"},
+		{token.RBrace, "}"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestAssignmentWithOperatorToken(t *testing.T) {
+	input := `a *= 2; a /= 2; a ** 2`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.Ident, "a"},
+		{token.AsteriskEq, "*="},
+		{token.Int, "2"},
+		{token.Semicolon, ";"},
+		{token.Ident, "a"},
+		{token.SlashEq, "/="},
+		{token.Int, "2"},
+		{token.Semicolon, ";"},
+		{token.Ident, "a"},
+		{token.Pow, "**"},
+		{token.Int, "2"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestInterpolatedStringToken(t *testing.T) {
+	input := `"Hi #{name}, #{"\#{x}"}" '#{y}' "\#{z}"`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.InterpolatedStringBegin, "\""},
+		{token.String, "Hi "},
+		{token.InterpolationBegin, "#{"},
+		{token.Ident, "name"},
+		{token.InterpolationEnd, "}"},
+		{token.String, ", "},
+		{token.InterpolationBegin, "#{"},
+		{token.String, "#{x}"},
+		{token.InterpolationEnd, "}"},
+		{token.InterpolatedStringEnd, "\""},
+		{token.String, "#{y}"},
+		{token.String, "#{z}"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestFloatToken(t *testing.T) {
+	input := `
+	3.14
+	1.to_s
+	1..5
+	1...5
+	`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+		expectedLine    int
+	}{
+		{token.Float, "3.14", 1},
+		{token.Int, "1", 2},
+		{token.Dot, ".", 2},
+		{token.Ident, "to_s", 2},
+		{token.Int, "1", 3},
+		{token.Range, "..", 3},
+		{token.Int, "5", 3},
+		{token.Int, "1", 4},
+		{token.Range, "...", 4},
+		{token.Int, "5", 4},
+		{token.EOF, "", 5},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+		if tok.Line != tt.expectedLine {
+			t.Fatalf("tests[%d] - line number wrong. expected=%d, got=%d", i, tt.expectedLine, tok.Line)
+		}
+	}
+}
+
+func TestClassVariableToken(t *testing.T) {
+	input := `
+	@@count = @@count + 1
+	@foo
+	@@
+	`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+		expectedLine    int
+	}{
+		{token.ClassVariable, "@@count", 1},
+		{token.Assign, "=", 1},
+		{token.ClassVariable, "@@count", 1},
+		{token.Plus, "+", 1},
+		{token.Int, "1", 1},
+		{token.InstanceVariable, "@foo", 2},
+		{token.Illegal, "@@", 3},
+		{token.EOF, "", 4},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+		if tok.Line != tt.expectedLine {
+			t.Fatalf("tests[%d] - line number wrong. expected=%d, got=%d", i, tt.expectedLine, tok.Line)
+		}
+	}
+}
+
+func TestGlobalVariableToken(t *testing.T) {
+	input := `
+	$count = $count + 1
+	$foo_2
+	$
+	`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+		expectedLine    int
+	}{
+		{token.GlobalVariable, "$count", 1},
+		{token.Assign, "=", 1},
+		{token.GlobalVariable, "$count", 1},
+		{token.Plus, "+", 1},
+		{token.Int, "1", 1},
+		{token.GlobalVariable, "$foo_2", 2},
+		{token.Illegal, "$", 3},
+		{token.EOF, "", 4},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+		if tok.Line != tt.expectedLine {
+			t.Fatalf("tests[%d] - line number wrong. expected=%d, got=%d", i, tt.expectedLine, tok.Line)
+		}
+	}
+}
+
+func TestSymbolWithQuestionMarkToken(t *testing.T) {
+	input := `a.respond_to?(:nil?)`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.Ident, "a"},
+		{token.Dot, "."},
+		{token.Ident, "respond_to?"},
+		{token.LParen, "("},
+		{token.Symbol, "nil?"},
+		{token.RParen, ")"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestLeftShiftToken(t *testing.T) {
+	input := `a << 1 <= 2`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.Ident, "a"},
+		{token.LShift, "<<"},
+		{token.Int, "1"},
+		{token.LTE, "<="},
+		{token.Int, "2"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestStringEscapeSequences(t *testing.T) {
+	input := `"a\tb\nc" "\\ \" \0" 'a\tb' 'it\'s \\ \"'`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.String, "a\tb\nc"},
+		{token.String, "\\ \" \x00"},
+		{token.String, "a\\tb"},
+		{token.String, "it's \\ \\\""},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestHeredoc(t *testing.T) {
+	input := `a = <<~EOS
+  Hello
+    World
+
+  #{name}
+EOS
+b = <<-'EOS'.size
+  raw #{name}\n
+  EOS
+c << d
+`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+		expectedLine    int
+	}{
+		{token.Ident, "a", 0},
+		{token.Assign, "=", 0},
+		{token.InterpolatedStringBegin, "\"", 0},
+		{token.String, "Hello\n  World\n\n", 0},
+		{token.InterpolationBegin, "#{", 0},
+		{token.Ident, "name", 0},
+		{token.InterpolationEnd, "}", 0},
+		{token.String, "\n", 0},
+		{token.InterpolatedStringEnd, "\"", 0},
+		{token.Ident, "b", 6},
+		{token.Assign, "=", 6},
+		{token.String, "  raw #{name}\\n\n", 6},
+		{token.Dot, ".", 6},
+		{token.Ident, "size", 6},
+		{token.Ident, "c", 9},
+		{token.LShift, "<<", 9},
+		{token.Ident, "d", 9},
+		{token.EOF, "", 10},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+		if tok.Line != tt.expectedLine {
+			t.Fatalf("tests[%d] - line number wrong. expected=%d, got=%d", i, tt.expectedLine, tok.Line)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+/*
+Package optimizer rewrites the AST before generating bytecode.
+
+FoldConstants replaces operations whose operands are all literals with the results, so `2 + 3 * 4` is compiled
+into a single `14`. It assumes the operators of Integer, Float, String and Boolean are not redefined, which is why
+it's optional. Operations that raise errors (like `1 / 0`) or need BigInteger (like overflowed results) are left
+for the vm, and anything involving variables or method calls is never folded.
+*/
+package optimizer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/goby-lang/goby/compiler/ast"
+	"github.com/goby-lang/goby/compiler/token"
+)
+
+// FoldConstants folds constant expressions of the program in place
+func FoldConstants(program *ast.Program) {
+	foldStatements(program.Statements)
+}
+
+func foldStatements(stmts []ast.Statement) {
+	for _, stmt := range stmts {
+		foldStatement(stmt)
+	}
+}
+
+func foldBlock(bs *ast.BlockStatement) {
+	if bs != nil {
+		foldStatements(bs.Statements)
+	}
+}
+
+func foldExpressions(exps []ast.Expression) {
+	for i, exp := range exps {
+		exps[i] = foldExpression(exp)
+	}
+}
+
+func foldStatement(stmt ast.Statement) {
+	switch stmt := stmt.(type) {
+	case *ast.ExpressionStatement:
+		stmt.Expression = foldExpression(stmt.Expression)
+	case *ast.ReturnStatement:
+		stmt.ReturnValue = foldExpression(stmt.ReturnValue)
+	case *ast.NextStatement:
+		stmt.Value = foldExpression(stmt.Value)
+	case *ast.BreakStatement:
+		stmt.Value = foldExpression(stmt.Value)
+	case *ast.WhileStatement:
+		stmt.Condition = foldExpression(stmt.Condition)
+		foldBlock(stmt.Body)
+	case *ast.DefStatement:
+		foldExpressions(stmt.Parameters)
+		foldBlock(stmt.BlockStatement)
+	case *ast.ClassStatement:
+		foldBlock(stmt.Body)
+	case *ast.ModuleStatement:
+		foldBlock(stmt.Body)
+	case *ast.BlockStatement:
+		foldBlock(stmt)
+	}
+}
+
+func foldExpression(exp ast.Expression) ast.Expression {
+	switch exp := exp.(type) {
+	case *ast.InfixExpression:
+		exp.Left = foldExpression(exp.Left)
+		exp.Right = foldExpression(exp.Right)
+
+		if folded := foldInfixExpression(exp); folded != nil {
+			return folded
+		}
+	case *ast.PrefixExpression:
+		exp.Right = foldExpression(exp.Right)
+
+		if folded := foldPrefixExpression(exp); folded != nil {
+			return folded
+		}
+	case *ast.AssignExpression:
+		exp.Value = foldExpression(exp.Value)
+	case *ast.ArgumentPairExpression:
+		exp.Value = foldExpression(exp.Value)
+	case *ast.ArrayExpression:
+		foldExpressions(exp.Elements)
+	case *ast.HashExpression:
+		for key, value := range exp.Data {
+			exp.Data[key] = foldExpression(value)
+		}
+	case *ast.InterpolatedString:
+		foldExpressions(exp.Elements)
+	case *ast.RangeExpression:
+		exp.Start = foldExpression(exp.Start)
+		exp.End = foldExpression(exp.End)
+	case *ast.CallExpression:
+		exp.Receiver = foldExpression(exp.Receiver)
+		foldExpressions(exp.Arguments)
+		foldBlock(exp.Block)
+	case *ast.YieldExpression:
+		foldExpressions(exp.Arguments)
+	case *ast.SuperExpression:
+		foldExpressions(exp.Arguments)
+	case *ast.IfExpression:
+		for _, c := range exp.Conditionals {
+			c.Condition = foldExpression(c.Condition)
+			foldBlock(c.Consequence)
+		}
+
+		foldBlock(exp.Alternative)
+	case *ast.CaseExpression:
+		exp.Subject = foldExpression(exp.Subject)
+
+		for _, w := range exp.Whens {
+			foldExpressions(w.Values)
+			foldBlock(w.Body)
+		}
+
+		foldBlock(exp.Alternative)
+	case *ast.ForExpression:
+		exp.Collection = foldExpression(exp.Collection)
+		foldBlock(exp.Body)
+	case *ast.BeginExpression:
+		foldBlock(exp.Body)
+		foldBlock(exp.Rescue)
+	case nil:
+		return nil
+	}
+
+	return exp
+}
+
+// foldInfixExpression returns the literal of the operation's result, or nil if it can't be folded
+func foldInfixExpression(exp *ast.InfixExpression) ast.Expression {
+	switch left := exp.Left.(type) {
+	case *ast.IntegerLiteral:
+		if right, ok := exp.Right.(*ast.IntegerLiteral); ok {
+			return foldIntegerOperation(exp, left.Value, right.Value)
+		}
+	case *ast.FloatLiteral:
+		if right, ok := exp.Right.(*ast.FloatLiteral); ok {
+			return foldFloatOperation(exp, left.Value, right.Value)
+		}
+	case *ast.StringLiteral:
+		if right, ok := exp.Right.(*ast.StringLiteral); ok {
+			switch exp.Operator {
+			case "+":
+				return stringLiteral(exp.BaseNode, left.Value+right.Value)
+			case "==":
+				return booleanLiteral(exp.BaseNode, left.Value == right.Value)
+			case "!=":
+				return booleanLiteral(exp.BaseNode, left.Value != right.Value)
+			}
+		}
+	case *ast.BooleanExpression:
+		if right, ok := exp.Right.(*ast.BooleanExpression); ok {
+			switch exp.Operator {
+			case "==":
+				return booleanLiteral(exp.BaseNode, left.Value == right.Value)
+			case "!=":
+				return booleanLiteral(exp.BaseNode, left.Value != right.Value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func foldIntegerOperation(exp *ast.InfixExpression, left, right int) ast.Expression {
+	var result int
+
+	switch exp.Operator {
+	case "+":
+		if (right > 0 && left > math.MaxInt64-right) || (right < 0 && left < math.MinInt64-right) {
+			return nil
+		}
+
+		result = left + right
+	case "-":
+		if (right < 0 && left > math.MaxInt64+right) || (right > 0 && left < math.MinInt64+right) {
+			return nil
+		}
+
+		result = left - right
+	case "*":
+		result = left * right
+
+		if left != 0 && (result/left != right || (left == -1 && right == math.MinInt64) || (right == -1 && left == math.MinInt64)) {
+			return nil
+		}
+	case "/":
+		if right == 0 || (left == math.MinInt64 && right == -1) {
+			return nil
+		}
+
+		result = left / right
+	case "%":
+		if right == 0 {
+			return nil
+		}
+
+		result = left % right
+	case "<":
+		return booleanLiteral(exp.BaseNode, left < right)
+	case "<=":
+		return booleanLiteral(exp.BaseNode, left <= right)
+	case ">":
+		return booleanLiteral(exp.BaseNode, left > right)
+	case ">=":
+		return booleanLiteral(exp.BaseNode, left >= right)
+	case "==":
+		return booleanLiteral(exp.BaseNode, left == right)
+	case "!=":
+		return booleanLiteral(exp.BaseNode, left != right)
+	default:
+		return nil
+	}
+
+	return integerLiteral(exp.BaseNode, result)
+}
+
+func foldFloatOperation(exp *ast.InfixExpression, left, right float64) ast.Expression {
+	var result float64
+
+	switch exp.Operator {
+	case "+":
+		result = left + right
+	case "-":
+		result = left - right
+	case "*":
+		result = left * right
+	case "<":
+		return booleanLiteral(exp.BaseNode, left < right)
+	case "<=":
+		return booleanLiteral(exp.BaseNode, left <= right)
+	case ">":
+		return booleanLiteral(exp.BaseNode, left > right)
+	case ">=":
+		return booleanLiteral(exp.BaseNode, left >= right)
+	default:
+		return nil
+	}
+
+	// Infinity and NaN can't be written as float literals
+	if math.IsInf(result, 0) || math.IsNaN(result) {
+		return nil
+	}
+
+	return floatLiteral(exp.BaseNode, result)
+}
+
+// foldPrefixExpression returns the literal of the operation's result, or nil if it can't be folded
+func foldPrefixExpression(exp *ast.PrefixExpression) ast.Expression {
+	switch right := exp.Right.(type) {
+	case *ast.IntegerLiteral:
+		if exp.Operator == "-" && right.Value != math.MinInt64 {
+			return integerLiteral(exp.BaseNode, -right.Value)
+		}
+	case *ast.FloatLiteral:
+		if exp.Operator == "-" {
+			return floatLiteral(exp.BaseNode, -right.Value)
+		}
+	case *ast.BooleanExpression:
+		if exp.Operator == "!" {
+			return booleanLiteral(exp.BaseNode, !right.Value)
+		}
+	}
+
+	return nil
+}
+
+func integerLiteral(node *ast.BaseNode, value int) ast.Expression {
+	return &ast.IntegerLiteral{BaseNode: baseNodeOf(node, token.Int, strconv.Itoa(value)), Value: value}
+}
+
+func floatLiteral(node *ast.BaseNode, value float64) ast.Expression {
+	return &ast.FloatLiteral{BaseNode: baseNodeOf(node, token.Float, fmt.Sprint(value)), Value: value}
+}
+
+func stringLiteral(node *ast.BaseNode, value string) ast.Expression {
+	return &ast.StringLiteral{BaseNode: baseNodeOf(node, token.String, value), Value: value}
+}
+
+func booleanLiteral(node *ast.BaseNode, value bool) ast.Expression {
+	if value {
+		return &ast.BooleanExpression{BaseNode: baseNodeOf(node, token.True, "true"), Value: true}
+	}
+
+	return &ast.BooleanExpression{BaseNode: baseNodeOf(node, token.False, "false"), Value: false}
+}
+
+// baseNodeOf returns the base node for a literal that replaces given node. The literal keeps the node's line,
+// and whether the node is a statement, so the generator compiles the literal the same way.
+func baseNodeOf(node *ast.BaseNode, tokenType token.Type, literal string) *ast.BaseNode {
+	bn := &ast.BaseNode{Token: token.Token{Type: tokenType, Literal: literal, Line: node.Line()}}
+
+	if node.IsStmt() {
+		bn.MarkAsStmt()
+	}
+
+	return bn
+}
@@ -0,0 +1,111 @@
+package optimizer
+
+import (
+	"testing"
+
+	"github.com/goby-lang/goby/compiler/ast"
+	"github.com/goby-lang/goby/compiler/lexer"
+	"github.com/goby-lang/goby/compiler/parser"
+)
+
+func TestFoldConstants(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`2 + 3 * 4`, `14`},
+		{`(2 + 3) * 4`, `20`},
+		{`-7 / 2`, `-3`},
+		{`-7 % 2`, `-1`},
+		{`-5`, `-5`},
+		{`2.5 * 2.0`, `5`},
+		{`-1.5`, `-1.5`},
+		{`"foo" + "bar"`, `"foobar"`},
+		{`"foo" == "bar"`, `false`},
+		{`1 < 2`, `true`},
+		{`1 + 1 != 2`, `false`},
+		{`!true`, `false`},
+		{`true == !false`, `true`},
+		// Only the literal parts are folded
+		{`x = 1 + 2`, `(x = 3)`},
+		{`x + 1 * 2`, `(x + 2)`},
+		{`[1 + 1, 2 * 2]`, `[2, 4]`},
+		{`foo(1 + 2)`, `self.foo(3)`},
+		// These are left for the vm
+		{`1 / 0`, `(1 / 0)`},
+		{`1 % 0`, `(1 % 0)`},
+		{`9223372036854775807 + 1`, `(9223372036854775807 + 1)`},
+		{`3037000500 * 3037000500`, `(3037000500 * 3037000500)`},
+		{`1 + 2.0`, `(1 + 2.0)`},
+		{`1.0 / 2.0`, `(1.0 / 2.0)`},
+		{`1 ** 2`, `(1 ** 2)`},
+		{`"a" * 3`, `("a" * 3)`},
+	}
+
+	for i, tt := range tests {
+		program := parseProgram(t, i, tt.input)
+		FoldConstants(program)
+
+		if program.String() != tt.expected {
+			t.Errorf("At case %d expect folded program to be %s. got: %s", i, tt.expected, program.String())
+		}
+	}
+}
+
+func TestFoldConstantsInNestedNodes(t *testing.T) {
+	input := `
+	def foo(a = 2 * 3)
+	  if a > 1 + 1
+	    return 10 - 1
+	  end
+
+	  [1, 2].map do |i|
+	    i * (1 + 1)
+	  end
+	end
+	`
+
+	program := parseProgram(t, 0, input)
+	FoldConstants(program)
+
+	def := program.Statements[0].(*ast.DefStatement)
+	param := def.Parameters[0].(*ast.AssignExpression)
+
+	if param.Value.String() != "6" {
+		t.Fatalf("Expect default value to be folded into 6. got: %s", param.Value.String())
+	}
+
+	ifExp := def.BlockStatement.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.IfExpression)
+	condition := ifExp.Conditionals[0].Condition
+
+	if condition.String() != "(a > 2)" {
+		t.Fatalf("Expect condition to be folded into (a > 2). got: %s", condition.String())
+	}
+
+	returnValue := ifExp.Conditionals[0].Consequence.Statements[0].(*ast.ReturnStatement).ReturnValue
+
+	if returnValue.String() != "9" {
+		t.Fatalf("Expect return value to be folded into 9. got: %s", returnValue.String())
+	}
+
+	call := def.BlockStatement.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	blockValue := call.Block.Statements[0].(*ast.ExpressionStatement).Expression
+
+	if blockValue.String() != "(i * 2)" {
+		t.Fatalf("Expect block's expression to be folded into (i * 2). got: %s", blockValue.String())
+	}
+}
+
+func parseProgram(t *testing.T, index int, input string) *ast.Program {
+	t.Helper()
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatalf("At case %d failed to parse %s: %s", index, input, err.Message)
+	}
+
+	return program
+}
@@ -9,14 +9,19 @@ import (
 )
 
 var arguments = map[token.Type]bool{
-	token.Int:              true,
-	token.String:           true,
-	token.True:             true,
-	token.False:            true,
-	token.Null:             true,
-	token.InstanceVariable: true,
-	token.Ident:            true,
-	token.Constant:         true,
+	token.Int:                     true,
+	token.Float:                   true,
+	token.InterpolatedStringBegin: true,
+	token.String:                  true,
+	token.Symbol:                  true,
+	token.True:                    true,
+	token.False:                   true,
+	token.Null:                    true,
+	token.InstanceVariable:        true,
+	token.ClassVariable:           true,
+	token.GlobalVariable:          true,
+	token.Ident:                   true,
+	token.Constant:                true,
 }
 
 var precedence = map[token.Type]int{
@@ -27,6 +32,8 @@ var precedence = map[token.Type]int{
 	token.GT:                 COMPARE,
 	token.GTE:                COMPARE,
 	token.COMP:               COMPARE,
+	token.LShift:             SHIFT,
+	token.Question:           TERNARY,
 	token.And:                LOGIC,
 	token.Or:                 LOGIC,
 	token.Range:              RANGE,
@@ -37,7 +44,7 @@ var precedence = map[token.Type]int{
 	token.Modulo:             SUM,
 	token.Slash:              PRODUCT,
 	token.Asterisk:           PRODUCT,
-	token.Pow:                PRODUCT,
+	token.Pow:                POWER,
 	token.LBracket:           INDEX,
 	token.Dot:                CALL,
 	token.LParen:             CALL,
@@ -45,6 +52,8 @@ var precedence = map[token.Type]int{
 	token.Assign:             ASSIGN,
 	token.PlusEq:             ASSIGN,
 	token.MinusEq:            ASSIGN,
+	token.AsteriskEq:         ASSIGN,
+	token.SlashEq:            ASSIGN,
 	token.OrEq:               ASSIGN,
 }
 
@@ -54,12 +63,15 @@ const (
 	LOWEST
 	NORMAL
 	ASSIGN
+	TERNARY
 	LOGIC
 	RANGE
 	EQUALS
 	COMPARE
+	SHIFT
 	SUM
 	PRODUCT
+	POWER
 	PREFIX
 	INDEX
 	CALL
@@ -91,7 +103,8 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 		```
 	*/
 	if p.curTokenIs(token.Ident) && (p.fsm.Is(normal) || p.fsm.Is(parsingAssignment)) {
-		if p.peekTokenIs(token.Do) {
+		// `foo do ... end` or `foo { ... }`, a brace after a method name at the same line starts a block instead of a hash
+		if p.peekTokenIs(token.Do) || (p.peekTokenIs(token.LBrace) && p.peekTokenAtSameLine() && p.acceptBlock) {
 			method := p.parseIdentifier()
 			return p.parseCallExpressionWithoutReceiver(method)
 		}
@@ -148,10 +161,6 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 		leftExp = infixFn(leftExp)
 	}
 
-	if p.peekTokenIs(token.Semicolon) {
-		p.nextToken()
-	}
-
 	return leftExp
 }
 
@@ -179,6 +188,14 @@ func (p *Parser) parseInstanceVariable() ast.Expression {
 	return &ast.InstanceVariable{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
 }
 
+func (p *Parser) parseClassVariable() ast.Expression {
+	return &ast.ClassVariable{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+}
+
+func (p *Parser) parseGlobalVariable() ast.Expression {
+	return &ast.GlobalVariable{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+}
+
 func (p *Parser) parseIntegerLiteral() ast.Expression {
 	lit := &ast.IntegerLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}}
 
@@ -193,6 +210,20 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	return lit
 }
 
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	value, err := strconv.ParseFloat(lit.TokenLiteral(), 64)
+	if err != nil {
+		msg := fmt.Sprintf("could not parse %q as float", lit.TokenLiteral())
+		panic(msg)
+	}
+
+	lit.Value = value
+
+	return lit
+}
+
 func (p *Parser) parseStringLiteral() ast.Expression {
 	lit := &ast.StringLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}}
 	lit.Value = p.curToken.Literal
@@ -200,6 +231,46 @@ func (p *Parser) parseStringLiteral() ast.Expression {
 	return lit
 }
 
+func (p *Parser) parseSymbolLiteral() ast.Expression {
+	lit := &ast.SymbolLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	lit.Value = p.curToken.Literal
+
+	return lit
+}
+
+func (p *Parser) parseInterpolatedString() ast.Expression {
+	is := &ast.InterpolatedString{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	for !p.peekTokenIs(token.InterpolatedStringEnd) {
+		p.nextToken()
+
+		switch p.curToken.Type {
+		case token.String:
+			is.Elements = append(is.Elements, p.parseStringLiteral())
+		case token.InterpolationBegin:
+			// Empty interpolation like "#{}"
+			if p.peekTokenIs(token.InterpolationEnd) {
+				p.nextToken()
+				continue
+			}
+
+			p.nextToken()
+			is.Elements = append(is.Elements, p.parseExpression(NORMAL))
+
+			if !p.expectPeek(token.InterpolationEnd) {
+				return nil
+			}
+		default:
+			p.error = &Error{Message: fmt.Sprintf("Unexpected %s in string interpolation. Line: %d", p.curToken.Literal, p.curToken.Line), errType: UnexpectedTokenError, Line: p.curToken.Line}
+			return nil
+		}
+	}
+
+	p.nextToken()
+
+	return is
+}
+
 func (p *Parser) parseBooleanLiteral() ast.Expression {
 	lit := &ast.BooleanExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
 
@@ -263,6 +334,18 @@ func (p *Parser) parseHashPair(pairs map[string]ast.Expression) {
 		key = p.parseIdentifier().(ast.Variable).ReturnValue()
 	case token.Constant:
 		key = p.parseIdentifier().(ast.Variable).ReturnValue()
+	case token.String:
+		// `{ "foo" => 1 }`
+		key = p.curToken.Literal
+
+		if !p.expectPeek(token.HashRocket) {
+			return
+		}
+
+		p.nextToken()
+		value = p.parseExpression(NORMAL)
+		pairs[key] = value
+		return
 	default:
 		return
 	}
@@ -356,12 +439,53 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	}
 
 	precedence := p.curPrecedence()
+
+	// `**` is right-associative: `2 ** 3 ** 2` is parsed as `2 ** (3 ** 2)`
+	if p.curTokenIs(token.Pow) {
+		precedence--
+	}
+
 	p.nextToken()
 	exp.Right = p.parseExpression(precedence)
 
 	return exp
 }
 
+// parseTernaryExpression parses `cond ? a : b` into an `if` expression.
+// It's right-associative: `a ? b : c ? d : e` is parsed as `a ? b : (c ? d : e)`
+func (p *Parser) parseTernaryExpression(condition ast.Expression) ast.Expression {
+	ie := &ast.IfExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	ce := &ast.ConditionalExpression{BaseNode: &ast.BaseNode{Token: p.curToken}, Condition: condition}
+
+	p.nextToken()
+	ce.Consequence = p.parseTernaryBranch()
+
+	if !p.expectPeek(token.Colon) {
+		return nil
+	}
+
+	p.nextToken()
+	ie.Alternative = p.parseTernaryBranch()
+	ie.Conditionals = []*ast.ConditionalExpression{ce}
+
+	return ie
+}
+
+func (p *Parser) parseTernaryBranch() *ast.BlockStatement {
+	// Use lower precedence so branches can contain another ternary expression
+	exp := p.parseExpression(TERNARY - 1)
+	bs := &ast.BlockStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	if exp == nil {
+		return bs
+	}
+
+	bs.Statements = []ast.Statement{&ast.ExpressionStatement{BaseNode: &ast.BaseNode{Token: p.curToken}, Expression: exp}}
+	bs.KeepLastValue()
+
+	return bs
+}
+
 func (p *Parser) parseAssignExpression(v ast.Expression) ast.Expression {
 	var value ast.Expression
 	var tok token.Token
@@ -401,9 +525,9 @@ func (p *Parser) parseAssignExpression(v ast.Expression) ast.Expression {
 			return callExp
 		}
 
-		p.error = &Error{Message: fmt.Sprintf("Can't assign value to %s. Line: %d", v.String(), p.curToken.Line), errType: InvalidAssignmentError}
+		p.error = &Error{Message: fmt.Sprintf("Can't assign value to %s. Line: %d", v.String(), p.curToken.Line), errType: InvalidAssignmentError, Line: p.curToken.Line}
 	default:
-		p.error = &Error{Message: fmt.Sprintf("Can't assign value to %s. Line: %d", v.String(), p.curToken.Line), errType: InvalidAssignmentError}
+		p.error = &Error{Message: fmt.Sprintf("Can't assign value to %s. Line: %d", v.String(), p.curToken.Line), errType: InvalidAssignmentError, Line: p.curToken.Line}
 	}
 
 	if len(exp.Variables) == 1 {
@@ -414,6 +538,19 @@ func (p *Parser) parseAssignExpression(v ast.Expression) ast.Expression {
 		precedence := p.curPrecedence()
 		p.nextToken()
 		value = p.parseExpression(precedence)
+
+		// `a, b = 1, 2` is the same as `a, b = [1, 2]`, so all values are evaluated before assigning any of them
+		if p.peekTokenIs(token.Comma) {
+			values := &ast.ArrayExpression{BaseNode: &ast.BaseNode{Token: p.curToken}, Elements: []ast.Expression{value}}
+
+			for p.peekTokenIs(token.Comma) {
+				p.nextToken()
+				p.nextToken()
+				values.Elements = append(values.Elements, p.parseExpression(precedence))
+			}
+
+			value = values
+		}
 	}
 
 	exp.Token = tok
@@ -431,7 +568,7 @@ func (p *Parser) expandAssignmentValue(value ast.Expression) ast.Expression {
 		precedence := p.curPrecedence()
 		p.nextToken()
 		return p.parseExpression(precedence)
-	case token.MinusEq, token.PlusEq, token.OrEq:
+	case token.MinusEq, token.PlusEq, token.AsteriskEq, token.SlashEq, token.OrEq:
 		// Syntax Surgar: Assignment with operator case
 		infixOperator := token.Token{Line: p.curToken.Line}
 		switch p.curToken.Type {
@@ -441,6 +578,12 @@ func (p *Parser) expandAssignmentValue(value ast.Expression) ast.Expression {
 		case token.MinusEq:
 			infixOperator.Type = token.Minus
 			infixOperator.Literal = "-"
+		case token.AsteriskEq:
+			infixOperator.Type = token.Asterisk
+			infixOperator.Literal = "*"
+		case token.SlashEq:
+			infixOperator.Type = token.Slash
+			infixOperator.Literal = "/"
 		case token.OrEq:
 			infixOperator.Type = token.Or
 			infixOperator.Literal = "||"
@@ -455,7 +598,7 @@ func (p *Parser) expandAssignmentValue(value ast.Expression) ast.Expression {
 			Right:    p.parseExpression(LOWEST),
 		}
 	default:
-		p.error = &Error{errType: UnexpectedTokenError, Message: fmt.Sprintf("Unexpect token '%s' for assgin expression", p.curToken.Literal)}
+		p.error = &Error{errType: UnexpectedTokenError, Message: fmt.Sprintf("Unexpect token '%s' for assgin expression", p.curToken.Literal), Line: p.curToken.Line}
 		return nil
 	}
 }
@@ -472,6 +615,35 @@ func (p *Parser) parseGroupedExpression() ast.Expression {
 	return exp
 }
 
+func (p *Parser) parseForExpression() ast.Expression {
+	fe := &ast.ForExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	if !p.expectPeek(token.Ident) {
+		return nil
+	}
+
+	fe.Variable = p.parseIdentifier().(*ast.Identifier)
+
+	if !p.expectPeek(token.In) {
+		return nil
+	}
+
+	p.nextToken()
+	// Prevent expression's method call to consume for's block as argument.
+	p.acceptBlock = false
+	fe.Collection = p.parseExpression(NORMAL)
+	p.acceptBlock = true
+
+	// `do` is optional, the block statement parsing skips current token anyway
+	if p.peekTokenIs(token.Do) {
+		p.nextToken()
+	}
+
+	fe.Body = p.parseBlockStatement()
+
+	return fe
+}
+
 func (p *Parser) parseIfExpression() ast.Expression {
 	ie := &ast.IfExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
 	// parse if and elsif expressions
@@ -486,6 +658,33 @@ func (p *Parser) parseIfExpression() ast.Expression {
 	return ie
 }
 
+// parseUnlessExpression turns `unless x; foo; else; bar; end` into `if x; bar; else; foo; end`
+func (p *Parser) parseUnlessExpression() ast.Expression {
+	ie := &ast.IfExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	ce := &ast.ConditionalExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	p.nextToken()
+	ce.Condition = p.parseExpression(NORMAL)
+
+	if p.peekTokenIs(token.Then) {
+		p.nextToken()
+	}
+
+	ie.Alternative = p.parseBlockStatement()
+	ie.Alternative.KeepLastValue()
+
+	// curToken is now ELSE or END
+	if p.curTokenIs(token.Else) {
+		ce.Consequence = p.parseBlockStatement()
+		ce.Consequence.KeepLastValue()
+	} else {
+		ce.Consequence = &ast.BlockStatement{BaseNode: &ast.BaseNode{Token: p.curToken}, Statements: []ast.Statement{}}
+	}
+
+	ie.Conditionals = []*ast.ConditionalExpression{ce}
+
+	return ie
+}
+
 func (p *Parser) parseConditionalExpressions() []*ast.ConditionalExpression {
 	// first conditional expression should start with if
 	cs := []*ast.ConditionalExpression{p.parseConditionalExpression()}
@@ -502,12 +701,88 @@ func (p *Parser) parseConditionalExpression() *ast.ConditionalExpression {
 	p.nextToken()
 	ce.Condition = p.parseExpression(NORMAL)
 
+	if p.peekTokenIs(token.Then) {
+		p.nextToken()
+	}
+
 	ce.Consequence = p.parseBlockStatement()
 	ce.Consequence.KeepLastValue()
 
 	return ce
 }
 
+func (p *Parser) parseCaseExpression() ast.Expression {
+	ce := &ast.CaseExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	p.nextToken()
+	ce.Subject = p.parseExpression(NORMAL)
+
+	if p.peekTokenIs(token.Semicolon) {
+		p.nextToken()
+	}
+
+	if !p.expectPeek(token.When) {
+		return nil
+	}
+
+	// curToken is now WHEN, ELSE or END
+	for p.curTokenIs(token.When) {
+		we := &ast.WhenExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+		p.nextToken()
+		we.Values = []ast.Expression{p.parseExpression(NORMAL)}
+
+		for p.peekTokenIs(token.Comma) {
+			p.nextToken()
+			p.nextToken()
+			we.Values = append(we.Values, p.parseExpression(NORMAL))
+		}
+
+		if p.peekTokenIs(token.Then) {
+			p.nextToken()
+		}
+
+		we.Body = p.parseBlockStatement()
+		we.Body.KeepLastValue()
+		ce.Whens = append(ce.Whens, we)
+	}
+
+	if p.curTokenIs(token.Else) {
+		ce.Alternative = p.parseBlockStatement()
+		ce.Alternative.KeepLastValue()
+	}
+
+	if !p.curTokenIs(token.End) {
+		p.error = &Error{Message: fmt.Sprintf("Unexpected %s in case expression. Line: %d", p.curToken.Literal, p.curToken.Line), errType: UnexpectedTokenError, Line: p.curToken.Line}
+		return nil
+	}
+
+	return ce
+}
+
+func (p *Parser) parseBeginExpression() ast.Expression {
+	be := &ast.BeginExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	be.Body = p.parseBlockStatement()
+	be.Body.KeepLastValue()
+
+	// curToken is now RESCUE or END
+	if p.curTokenIs(token.Rescue) {
+		if p.peekTokenIs(token.HashRocket) { // rescue => e
+			p.nextToken()
+
+			if !p.expectPeek(token.Ident) {
+				return nil
+			}
+
+			be.RescueVariable = &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+		}
+
+		be.Rescue = p.parseBlockStatement()
+		be.Rescue.KeepLastValue()
+	}
+
+	return be
+}
+
 func (p *Parser) parseYieldExpression() ast.Expression {
 	ye := &ast.YieldExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
 
@@ -524,10 +799,31 @@ func (p *Parser) parseYieldExpression() ast.Expression {
 	return ye
 }
 
+func (p *Parser) parseSuperExpression() ast.Expression {
+	se := &ast.SuperExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	if p.peekTokenIs(token.LParen) {
+		p.nextToken()
+		se.Arguments = p.parseCallArgumentsWithParens()
+		return se
+	}
+
+	if arguments[p.peekToken.Type] && p.peekTokenAtSameLine() { // super 123
+		p.nextToken()
+		se.Arguments = p.parseCallArguments()
+		return se
+	}
+
+	se.ImplicitArgs = true
+
+	return se
+}
+
 func (p *Parser) parseRangeExpression(left ast.Expression) ast.Expression {
 	exp := &ast.RangeExpression{
-		BaseNode: &ast.BaseNode{Token: p.curToken},
-		Start:    left,
+		BaseNode:  &ast.BaseNode{Token: p.curToken},
+		Start:     left,
+		Exclusive: p.curToken.Literal == "...",
 	}
 
 	precedence := p.curPrecedence()
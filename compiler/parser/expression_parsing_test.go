@@ -87,6 +87,13 @@ func TestHashExpression(t *testing.T) {
 				"another_string": 456,
 			},
 		},
+		{
+			`{ "foo" => 1, "bar baz" => 2 }`,
+			map[string]int{
+				"foo":     1,
+				"bar baz": 2,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -109,6 +116,10 @@ func TestHashExpression(t *testing.T) {
 
 		hash, ok := stmt.Expression.(*ast.HashExpression)
 
+		if len(hash.Data) != len(tt.expectedElements) {
+			t.Fatalf("Expect hash to have %d pairs. got=%d", len(tt.expectedElements), len(hash.Data))
+		}
+
 		for key := range hash.Data {
 			testIntegerLiteral(t, hash.Data[key], tt.expectedElements[key])
 		}
@@ -314,6 +325,38 @@ func TestIntegerLiteralExpression(t *testing.T) {
 	testIntegerLiteral(t, literal, 5)
 }
 
+func TestFloatLiteralExpression(t *testing.T) {
+	input := `3.14;`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program has wrong number of statements. got=%d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("first program statement is not ast.ExpressionStatement. got=%T", program.Statements[0])
+	}
+
+	literal, ok := stmt.Expression.(*ast.FloatLiteral)
+	if !ok {
+		t.Fatalf("expect exp to be FloatLiteral. got=%T", stmt.Expression)
+	}
+	if literal.Value != 3.14 {
+		t.Fatalf("literal.Value is not 3.14. got=%f", literal.Value)
+	}
+	if literal.TokenLiteral() != "3.14" {
+		t.Fatalf("literal.TokenLiteral not 3.14. got=%s", literal.TokenLiteral())
+	}
+}
+
 func TestStringLiteralExpression(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -347,6 +390,92 @@ func TestStringLiteralExpression(t *testing.T) {
 	}
 }
 
+func TestSymbolLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: `:foo;`, expected: "foo"},
+		{input: `:foo_bar1;`, expected: "foo_bar1"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatal(err.Message)
+		}
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program has wrong number of statements. got=%d", len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("first program statement is not ast.ExpressionStatement. got=%T", program.Statements[0])
+		}
+
+		literal, ok := stmt.Expression.(*ast.SymbolLiteral)
+		if !ok {
+			t.Fatalf("expect exp to be SymbolLiteral. got=%T", stmt.Expression)
+		}
+		if literal.Value != tt.expected {
+			t.Fatalf("literal.Value is not %q. got=%q", tt.expected, literal.Value)
+		}
+		if literal.String() != ":"+tt.expected {
+			t.Fatalf("literal.String() is not %q. got=%q", ":"+tt.expected, literal.String())
+		}
+	}
+}
+
+func TestInterpolatedStringExpression(t *testing.T) {
+	tests := []struct {
+		input            string
+		expectedElements int
+		expected         string
+	}{
+		{input: `"Hello #{name}!";`, expectedElements: 3, expected: `"Hello #{name}!"`},
+		{input: `"#{a + b}";`, expectedElements: 1, expected: `"#{(a + b)}"`},
+		{input: `"#{a}#{b.foo(1)}";`, expectedElements: 2, expected: `"#{a}#{b.foo(1)}"`},
+		{input: `"a #{h["b"]} c";`, expectedElements: 3, expected: `"a #{h.[]("b")} c"`},
+		{input: `"#{}";`, expectedElements: 0, expected: `""`},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatal(err.Message)
+		}
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program has wrong number of statements. got=%d", len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("first program statement is not ast.ExpressionStatement. got=%T", program.Statements[0])
+		}
+
+		is, ok := stmt.Expression.(*ast.InterpolatedString)
+		if !ok {
+			t.Fatalf("expect exp to be InterpolatedString. got=%T", stmt.Expression)
+		}
+
+		if len(is.Elements) != tt.expectedElements {
+			t.Fatalf("expect interpolated string to have %d elements. got=%d", tt.expectedElements, len(is.Elements))
+		}
+
+		if is.String() != tt.expected {
+			t.Fatalf("expect interpolated string to be %s. got=%s", tt.expected, is.String())
+		}
+	}
+}
+
 func TestParsingPrefixExpression(t *testing.T) {
 	prefixTests := []struct {
 		input    string
@@ -444,6 +573,7 @@ func TestParsingInfixExpression(t *testing.T) {
 	}{
 		{"4 + 1;", 4, "+", 1},
 		{"3 - 2;", 3, "-", 2},
+		{"5 << 6;", 5, "<<", 6},
 	}
 
 	for _, tt := range infixTests {
@@ -467,6 +597,60 @@ func TestParsingInfixExpression(t *testing.T) {
 	}
 }
 
+func TestForExpression(t *testing.T) {
+	input := `
+	for x in [1, 2] do
+	  puts(x)
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	exp, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.ForExpression)
+	if !ok {
+		t.Fatalf("expect expression to be *ast.ForExpression. got=%T", program.Statements[0].(*ast.ExpressionStatement).Expression)
+	}
+
+	testIdentifier(t, exp.Variable, "x")
+
+	if _, ok := exp.Collection.(*ast.ArrayExpression); !ok {
+		t.Fatalf("expect collection to be *ast.ArrayExpression. got=%T", exp.Collection)
+	}
+
+	call := exp.Body.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	testMethodName(t, call, "puts")
+	testIdentifier(t, call.Arguments[0], "x")
+}
+
+func TestForExpressionWithoutDo(t *testing.T) {
+	input := `
+	for x in foo
+	  puts(x)
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	exp := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.ForExpression)
+	testIdentifier(t, exp.Collection, "foo")
+
+	if len(exp.Body.Statements) != 1 {
+		t.Fatalf("expect for's body to have 1 statement. got=%d", len(exp.Body.Statements))
+	}
+}
+
 func TestIfExpression(t *testing.T) {
 	input := `
 	if x < y
@@ -581,6 +765,295 @@ func TestIfExpression(t *testing.T) {
 	}
 }
 
+func TestIfExpressionWithThen(t *testing.T) {
+	input := `
+	if x < y then x + 5 elsif x == y then y + 5 else y + 4 end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("expect program's statements to be 1. got=%d", len(program.Statements))
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+
+	if !ok {
+		t.Fatalf("expect statement to be an IfExpression. got=%T", stmt.Expression)
+	}
+
+	cs := exp.Conditionals
+
+	if len(cs) != 2 {
+		t.Fatalf("expect the length of conditionals to be 2. got=%d", len(cs))
+	}
+
+	if !testInfixExpression(t, cs[0].Condition, "x", "<", "y") {
+		return
+	}
+
+	consequence0 := cs[0].Consequence.Statements[0].(*ast.ExpressionStatement)
+
+	if !testInfixExpression(t, consequence0.Expression, "x", "+", 5) {
+		return
+	}
+
+	if !testInfixExpression(t, cs[1].Condition, "x", "==", "y") {
+		return
+	}
+
+	consequence1 := cs[1].Consequence.Statements[0].(*ast.ExpressionStatement)
+
+	if !testInfixExpression(t, consequence1.Expression, "y", "+", 5) {
+		return
+	}
+
+	alternative := exp.Alternative.Statements[0].(*ast.ExpressionStatement)
+
+	if !testInfixExpression(t, alternative.Expression, "y", "+", 4) {
+		return
+	}
+}
+
+func TestUnlessExpression(t *testing.T) {
+	input := `
+	unless x < y
+	  x + 5
+	else
+	  y + 4
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+
+	if !ok {
+		t.Fatalf("expect statement to be an IfExpression. got=%T", stmt.Expression)
+	}
+
+	if len(exp.Conditionals) != 1 {
+		t.Fatalf("expect the length of conditionals to be 1. got=%d", len(exp.Conditionals))
+	}
+
+	c := exp.Conditionals[0]
+
+	if !testInfixExpression(t, c.Condition, "x", "<", "y") {
+		return
+	}
+
+	// else branch becomes the consequence
+	consequence := c.Consequence.Statements[0].(*ast.ExpressionStatement)
+
+	if !testInfixExpression(t, consequence.Expression, "y", "+", 4) {
+		return
+	}
+
+	// unless' body becomes the alternative
+	alternative := exp.Alternative.Statements[0].(*ast.ExpressionStatement)
+
+	if !testInfixExpression(t, alternative.Expression, "x", "+", 5) {
+		return
+	}
+}
+
+func TestUnlessModifier(t *testing.T) {
+	input := `
+	x = 1 unless y
+	z
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("expect program's statements to be 2. got=%d", len(program.Statements))
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+
+	if !ok {
+		t.Fatalf("expect statement to be an IfExpression. got=%T", stmt.Expression)
+	}
+
+	c := exp.Conditionals[0]
+
+	if !testIdentifier(t, c.Condition, "y") {
+		return
+	}
+
+	if len(c.Consequence.Statements) != 0 {
+		t.Fatalf("expect consequence to be empty. got=%d", len(c.Consequence.Statements))
+	}
+
+	alternative := exp.Alternative.Statements[0].(*ast.ExpressionStatement)
+
+	if _, ok := alternative.Expression.(*ast.AssignExpression); !ok {
+		t.Fatalf("expect alternative to be an AssignExpression. got=%T", alternative.Expression)
+	}
+}
+
+func TestIfModifierWithReturnStatement(t *testing.T) {
+	input := `
+	def foo(x)
+	  return 0 if x
+	  x
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	def := program.Statements[0].(*ast.DefStatement)
+
+	if len(def.BlockStatement.Statements) != 2 {
+		t.Fatalf("expect method body to have 2 statements. got=%d", len(def.BlockStatement.Statements))
+	}
+
+	stmt := def.BlockStatement.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+
+	if !ok {
+		t.Fatalf("expect statement to be an IfExpression. got=%T", stmt.Expression)
+	}
+
+	if !testIdentifier(t, exp.Conditionals[0].Condition, "x") {
+		return
+	}
+
+	if _, ok := exp.Conditionals[0].Consequence.Statements[0].(*ast.ReturnStatement); !ok {
+		t.Fatalf("expect consequence to be a ReturnStatement. got=%T", exp.Conditionals[0].Consequence.Statements[0])
+	}
+
+	if exp.Alternative != nil {
+		t.Fatalf("expect if modifier to have no alternative")
+	}
+}
+
+func TestTernaryExpression(t *testing.T) {
+	input := `x = a > b ? 1 : c ? 2 : 3`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	assign, ok := stmt.Expression.(*ast.AssignExpression)
+
+	if !ok {
+		t.Fatalf("expect statement to be an AssignExpression. got=%T", stmt.Expression)
+	}
+
+	exp, ok := assign.Value.(*ast.IfExpression)
+
+	if !ok {
+		t.Fatalf("expect assigned value to be an IfExpression. got=%T", assign.Value)
+	}
+
+	if !testInfixExpression(t, exp.Conditionals[0].Condition, "a", ">", "b") {
+		return
+	}
+
+	consequence := exp.Conditionals[0].Consequence.Statements[0].(*ast.ExpressionStatement)
+	testIntegerLiteral(t, consequence.Expression, 1)
+
+	// `c ? 2 : 3` should be the alternative
+	alternative := exp.Alternative.Statements[0].(*ast.ExpressionStatement)
+	nested, ok := alternative.Expression.(*ast.IfExpression)
+
+	if !ok {
+		t.Fatalf("expect alternative to be an IfExpression. got=%T", alternative.Expression)
+	}
+
+	if !testIdentifier(t, nested.Conditionals[0].Condition, "c") {
+		return
+	}
+
+	testIntegerLiteral(t, nested.Conditionals[0].Consequence.Statements[0].(*ast.ExpressionStatement).Expression, 2)
+	testIntegerLiteral(t, nested.Alternative.Statements[0].(*ast.ExpressionStatement).Expression, 3)
+}
+
+func TestCaseExpression(t *testing.T) {
+	input := `
+	case x
+	when 1
+	  "one"
+	when 2, 3 then "two or three"
+	else
+	  "many"
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.CaseExpression)
+
+	if !ok {
+		t.Fatalf("expect statement to be a CaseExpression. got=%T", stmt.Expression)
+	}
+
+	if !testIdentifier(t, exp.Subject, "x") {
+		return
+	}
+
+	if len(exp.Whens) != 2 {
+		t.Fatalf("expect case expression to have 2 whens. got=%d", len(exp.Whens))
+	}
+
+	if len(exp.Whens[0].Values) != 1 {
+		t.Fatalf("expect first when to have 1 value. got=%d", len(exp.Whens[0].Values))
+	}
+
+	testIntegerLiteral(t, exp.Whens[0].Values[0], 1)
+	testStringLiteral(t, exp.Whens[0].Body.Statements[0].(*ast.ExpressionStatement).Expression, "one")
+
+	if len(exp.Whens[1].Values) != 2 {
+		t.Fatalf("expect second when to have 2 values. got=%d", len(exp.Whens[1].Values))
+	}
+
+	testIntegerLiteral(t, exp.Whens[1].Values[0], 2)
+	testIntegerLiteral(t, exp.Whens[1].Values[1], 3)
+	testStringLiteral(t, exp.Whens[1].Body.Statements[0].(*ast.ExpressionStatement).Expression, "two or three")
+
+	testStringLiteral(t, exp.Alternative.Statements[0].(*ast.ExpressionStatement).Expression, "many")
+}
+
 func TestMethodParameterParsing(t *testing.T) {
 	tests := []struct {
 		input          string
@@ -645,6 +1118,60 @@ func TestCallExpression(t *testing.T) {
 	testInfixExpression(t, callExpression.Arguments[2], 4, "+", 5)
 }
 
+func TestCallExpressionWithKeywordArguments(t *testing.T) {
+	input := `
+		p.connect(1, host: "localhost", port: 2 * 3)
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	callExpression := stmt.Expression.(*ast.CallExpression)
+
+	testMethodName(t, callExpression, "connect")
+
+	if len(callExpression.Arguments) != 2 {
+		t.Fatalf("expect %d arguments. got=%d", 2, len(callExpression.Arguments))
+	}
+
+	testIntegerLiteral(t, callExpression.Arguments[0], 1)
+
+	hash, ok := callExpression.Arguments[1].(*ast.HashExpression)
+
+	if !ok {
+		t.Fatalf("expect keyword arguments to be HashExpression. got=%T", callExpression.Arguments[1])
+	}
+
+	testStringLiteral(t, hash.Data["host"], "localhost")
+	testInfixExpression(t, hash.Data["port"], 2, "*", 3)
+}
+
+func TestCallExpressionFailWithPositionalArgumentAfterKeywordArgument(t *testing.T) {
+	input := `
+		p.connect(host: "localhost", 1)
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	_, err := p.ParseProgram()
+
+	if err == nil {
+		t.Fatal("expect not to allow positional argument after keyword arguments")
+	}
+
+	expected := "Positional argument can't be passed after keyword arguments. Line: 1"
+
+	if err.Message != expected {
+		t.Fatalf("expect error message to be:\n  %s. got: \n%s", expected, err.Message)
+	}
+}
+
 func TestSelfCallExpression(t *testing.T) {
 	input := `
 		self.add(1, 2 * 3, 4 + 5);
@@ -711,6 +1238,82 @@ func TestCallExpressionWithBlock(t *testing.T) {
 	testMethodName(t, exp, "puts")
 }
 
+func TestCallExpressionWithBraceBlock(t *testing.T) {
+	tests := []struct {
+		input    string
+		method   string
+		argCount int
+	}{
+		{`[1, 2].each { |i| puts(i) }`, "each", 0},
+		{`foo(1) { |i| puts(i) }`, "foo", 1},
+		{`foo { |i| puts(i) }`, "foo", 0},
+		{`[1, 2].each { |i|
+		  puts(i)
+		}`, "each", 0},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatal(err.Message)
+		}
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("At case %d: program has wrong number of statements. got=%d", i, len(program.Statements))
+		}
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		callExpression := stmt.Expression.(*ast.CallExpression)
+
+		testMethodName(t, callExpression, tt.method)
+		if len(callExpression.Arguments) != tt.argCount {
+			t.Fatalf("At case %d: expect %d arguments. got=%d", i, tt.argCount, len(callExpression.Arguments))
+		}
+		testIdentifier(t, callExpression.BlockArguments[0], "i")
+
+		block := callExpression.Block
+		exp := block.Statements[0].(*ast.ExpressionStatement).Expression
+		testMethodName(t, exp, "puts")
+	}
+}
+
+func TestRangeExpression(t *testing.T) {
+	tests := []struct {
+		input     string
+		start     int
+		end       int
+		exclusive bool
+	}{
+		{"1..5", 1, 5, false},
+		{"1...5", 1, 5, true},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatal(err.Message)
+		}
+
+		exp, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.RangeExpression)
+		if !ok {
+			t.Fatalf("expect expression to be *ast.RangeExpression. got=%T", program.Statements[0].(*ast.ExpressionStatement).Expression)
+		}
+
+		testIntegerLiteral(t, exp.Start, tt.start)
+		testIntegerLiteral(t, exp.End, tt.end)
+
+		if exp.Exclusive != tt.exclusive {
+			t.Fatalf("expect range's exclusive flag to be %t. got=%t", tt.exclusive, exp.Exclusive)
+		}
+	}
+}
+
 func TestAssignInfixExpressionWithLiteralValue(t *testing.T) {
 	tests := []struct {
 		input              string
@@ -756,6 +1359,10 @@ func TestAssignIndexExpressionWithVariableValue(t *testing.T) {
 		{"Foo = @bar", "Foo", "@bar", testConstant, testInstanceVariable},
 		{"@bar = Foo", "@bar", "Foo", testInstanceVariable, testConstant},
 		{"@bar = @foo", "@bar", "@foo", testInstanceVariable, testInstanceVariable},
+		{"@@bar = @foo", "@@bar", "@foo", testClassVariable, testInstanceVariable},
+		{"y = @@foo", "y", "@@foo", testIdentifier, testClassVariable},
+		{"$bar = @foo", "$bar", "@foo", testGlobalVariable, testInstanceVariable},
+		{"y = $foo", "y", "$foo", testIdentifier, testGlobalVariable},
 	}
 
 	for _, tt := range tests {
@@ -810,3 +1417,74 @@ func testAssignExpression(t *testing.T, exp ast.Expression, expectedIdentifier s
 		testBoolLiteral(t, assignExp.Value, expected)
 	}
 }
+
+func TestBeginExpression(t *testing.T) {
+	input := `
+	begin
+	  foo
+	  bar
+	rescue => e
+	  e
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.BeginExpression)
+
+	if !ok {
+		t.Fatalf("expect statement to be a BeginExpression. got=%T", stmt.Expression)
+	}
+
+	if len(exp.Body.Statements) != 2 {
+		t.Fatalf("expect begin body to have 2 statements. got=%d", len(exp.Body.Statements))
+	}
+
+	if exp.RescueVariable == nil || exp.RescueVariable.Value != "e" {
+		t.Fatalf("expect rescue variable to be 'e'. got=%v", exp.RescueVariable)
+	}
+
+	if len(exp.Rescue.Statements) != 1 {
+		t.Fatalf("expect rescue body to have 1 statement. got=%d", len(exp.Rescue.Statements))
+	}
+
+	testIdentifier(t, exp.Rescue.Statements[0].(*ast.ExpressionStatement).Expression, "e")
+}
+
+func TestBeginExpressionWithoutRescueVariable(t *testing.T) {
+	input := `
+	begin
+	  foo
+	rescue
+	  10
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.BeginExpression)
+
+	if !ok {
+		t.Fatalf("expect statement to be a BeginExpression. got=%T", stmt.Expression)
+	}
+
+	if exp.RescueVariable != nil {
+		t.Fatalf("expect rescue variable to be nil. got=%s", exp.RescueVariable.String())
+	}
+
+	testIntegerLiteral(t, exp.Rescue.Statements[0].(*ast.ExpressionStatement).Expression, 10)
+}
@@ -1,12 +1,22 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/goby-lang/goby/compiler/ast"
 	"github.com/goby-lang/goby/compiler/token"
 )
 
 func (p *Parser) parseCallExpressionWithoutReceiver(receiver ast.Expression) ast.Expression {
-	methodToken := receiver.(*ast.Identifier).Token
+	// Only a method name can be called without receiver, something like `{ a: 1 }(2)` isn't a method call
+	method, ok := receiver.(*ast.Identifier)
+
+	if !ok {
+		p.error = &Error{Message: fmt.Sprintf("unexpected %s Line: %d", p.curToken.Literal, p.curToken.Line), errType: UnexpectedTokenError, Line: p.curToken.Line}
+		return nil
+	}
+
+	methodToken := method.Token
 
 	exp := &ast.CallExpression{BaseNode: &ast.BaseNode{}}
 
@@ -25,6 +35,13 @@ func (p *Parser) parseCallExpressionWithoutReceiver(receiver ast.Expression) ast
 
 	if p.curTokenIs(token.LParen) {
 		exp.Arguments = p.parseCallArgumentsWithParens()
+
+		// foo(x) { |y| ... }
+		if p.peekTokenIs(token.LBrace) && p.peekTokenAtSameLine() && p.acceptBlock {
+			p.fsm.Event(eventTable[oldState])
+			p.parseBlockArgument(exp)
+			return exp
+		}
 	} else if p.curToken.Line == methodToken.Line && p.curToken != methodToken { // 'foo x' but not 'thread'
 		exp.Arguments = p.parseCallArguments()
 	}
@@ -33,6 +50,8 @@ func (p *Parser) parseCallExpressionWithoutReceiver(receiver ast.Expression) ast
 
 	if p.peekTokenIs(token.Do) && p.acceptBlock { // foo do
 		p.parseBlockArgument(exp)
+	} else if p.peekTokenIs(token.LBrace) && p.peekTokenAtSameLine() && p.acceptBlock { // foo { |x| ... }
+		p.parseBlockArgument(exp)
 	}
 
 	return exp
@@ -76,6 +95,8 @@ func (p *Parser) parseCallExpressionWithReceiver(receiver ast.Expression) ast.Ex
 	// Parse block
 	if p.peekTokenIs(token.Do) && p.acceptBlock {
 		p.parseBlockArgument(exp)
+	} else if p.peekTokenIs(token.LBrace) && p.peekTokenAtSameLine() && p.acceptBlock { // p.foo { |x| ... }
+		p.parseBlockArgument(exp)
 	}
 
 	return exp
@@ -103,20 +124,66 @@ func (p *Parser) parseCallArgumentsWithParens() []ast.Expression {
 func (p *Parser) parseCallArguments() []ast.Expression {
 	args := []ast.Expression{}
 
+	if p.curTokenIsKeywordArgument() {
+		return append(args, p.parseKeywordArguments())
+	}
+
 	args = append(args, p.parseExpression(NORMAL))
 
 	for p.peekTokenIs(token.Comma) {
 		p.nextToken() // ","
 		p.nextToken() // start of next expression
+
+		// Keyword arguments like `foo(1, bar: 2)` are passed as a hash, so they must be the last arguments
+		if p.curTokenIsKeywordArgument() {
+			return append(args, p.parseKeywordArguments())
+		}
+
 		args = append(args, p.parseExpression(NORMAL))
 	}
 
 	return args
 }
 
+func (p *Parser) curTokenIsKeywordArgument() bool {
+	return p.curTokenIs(token.Ident) && p.peekTokenIs(token.Colon)
+}
+
+// parseKeywordArguments parses `foo: 1, bar: 2` in method call's arguments into a hash expression
+func (p *Parser) parseKeywordArguments() ast.Expression {
+	hash := &ast.HashExpression{BaseNode: &ast.BaseNode{Token: p.curToken}, Data: map[string]ast.Expression{}}
+	p.parseKeywordArgument(hash.Data)
+
+	for p.peekTokenIs(token.Comma) {
+		p.nextToken() // ","
+		p.nextToken() // keyword
+
+		if !p.curTokenIsKeywordArgument() {
+			p.error = &Error{Message: fmt.Sprintf("Positional argument can't be passed after keyword arguments. Line: %d", p.curToken.Line), errType: SyntaxError, Line: p.curToken.Line}
+			return hash
+		}
+
+		p.parseKeywordArgument(hash.Data)
+	}
+
+	return hash
+}
+
+func (p *Parser) parseKeywordArgument(pairs map[string]ast.Expression) {
+	key := p.curToken.Literal
+
+	p.nextToken() // ':'
+	p.nextToken() // value
+
+	pairs[key] = p.parseExpression(NORMAL)
+}
+
 func (p *Parser) parseBlockArgument(exp *ast.CallExpression) {
 	p.nextToken()
 
+	// Block can be surrounded by `do`/`end` or braces
+	isBraceBlock := p.curTokenIs(token.LBrace)
+
 	// Parse block arguments
 	if p.peekTokenIs(token.Bar) {
 		var params []*ast.Identifier
@@ -141,6 +208,11 @@ func (p *Parser) parseBlockArgument(exp *ast.CallExpression) {
 		exp.BlockArguments = params
 	}
 
-	exp.Block = p.parseBlockStatement()
+	if isBraceBlock {
+		exp.Block = p.parseBraceBlockStatement()
+	} else {
+		exp.Block = p.parseBlockStatement()
+	}
+
 	exp.Block.KeepLastValue()
 }
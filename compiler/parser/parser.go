@@ -31,6 +31,8 @@ const (
 type Error struct {
 	// Message contains the readable message of error
 	Message string
+	// Line is the line number where the error happened, it starts from 0 like token's line
+	Line    int
 	errType int
 }
 
@@ -46,8 +48,9 @@ func (e *Error) IsUnexpectedEnd() bool {
 
 // Parser represents lexical analyzer struct
 type Parser struct {
-	Lexer *lexer.Lexer
-	error *Error
+	Lexer  *lexer.Lexer
+	error  *Error
+	errors []*Error
 
 	curToken  token.Token
 	peekToken token.Token
@@ -115,8 +118,13 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.Ident, p.parseIdentifier)
 	p.registerPrefix(token.Constant, p.parseConstant)
 	p.registerPrefix(token.InstanceVariable, p.parseInstanceVariable)
+	p.registerPrefix(token.ClassVariable, p.parseClassVariable)
+	p.registerPrefix(token.GlobalVariable, p.parseGlobalVariable)
 	p.registerPrefix(token.Int, p.parseIntegerLiteral)
+	p.registerPrefix(token.Float, p.parseFloatLiteral)
 	p.registerPrefix(token.String, p.parseStringLiteral)
+	p.registerPrefix(token.Symbol, p.parseSymbolLiteral)
+	p.registerPrefix(token.InterpolatedStringBegin, p.parseInterpolatedString)
 	p.registerPrefix(token.True, p.parseBooleanLiteral)
 	p.registerPrefix(token.False, p.parseBooleanLiteral)
 	p.registerPrefix(token.Null, p.parseNilExpression)
@@ -124,21 +132,29 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.Bang, p.parsePrefixExpression)
 	p.registerPrefix(token.LParen, p.parseGroupedExpression)
 	p.registerPrefix(token.If, p.parseIfExpression)
+	p.registerPrefix(token.For, p.parseForExpression)
+	p.registerPrefix(token.Unless, p.parseUnlessExpression)
 	p.registerPrefix(token.Self, p.parseSelfExpression)
 	p.registerPrefix(token.LBracket, p.parseArrayExpression)
 	p.registerPrefix(token.LBrace, p.parseHashExpression)
 	p.registerPrefix(token.Semicolon, p.parseSemicolon)
 	p.registerPrefix(token.Yield, p.parseYieldExpression)
+	p.registerPrefix(token.Super, p.parseSuperExpression)
+	p.registerPrefix(token.Begin, p.parseBeginExpression)
+	p.registerPrefix(token.Case, p.parseCaseExpression)
 
 	p.infixParseFns = make(map[token.Type]infixParseFn)
 	p.registerInfix(token.Plus, p.parseInfixExpression)
+	p.registerInfix(token.Question, p.parseTernaryExpression)
 	p.registerInfix(token.PlusEq, p.parseAssignExpression)
 	p.registerInfix(token.Modulo, p.parseInfixExpression)
 	p.registerInfix(token.Minus, p.parseInfixExpression)
 	p.registerInfix(token.MinusEq, p.parseAssignExpression)
 	p.registerInfix(token.Slash, p.parseInfixExpression)
+	p.registerInfix(token.SlashEq, p.parseAssignExpression)
 	p.registerInfix(token.Eq, p.parseInfixExpression)
 	p.registerInfix(token.Asterisk, p.parseInfixExpression)
+	p.registerInfix(token.AsteriskEq, p.parseAssignExpression)
 	p.registerInfix(token.Pow, p.parseInfixExpression)
 	p.registerInfix(token.NotEq, p.parseInfixExpression)
 	p.registerInfix(token.LT, p.parseInfixExpression)
@@ -146,6 +162,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.GT, p.parseInfixExpression)
 	p.registerInfix(token.GTE, p.parseInfixExpression)
 	p.registerInfix(token.COMP, p.parseInfixExpression)
+	p.registerInfix(token.LShift, p.parseInfixExpression)
 	p.registerInfix(token.Incr, p.parsePostfixExpression)
 	p.registerInfix(token.Decr, p.parsePostfixExpression)
 	p.registerInfix(token.And, p.parseInfixExpression)
@@ -162,9 +179,13 @@ func New(l *lexer.Lexer) *Parser {
 	return p
 }
 
-// ParseProgram update program statements and return program
+// ParseProgram update program statements and return program.
+// When the program has syntax errors, it keeps parsing the following lines to collect them
+// and returns the first one, all of them can be retrieved with Errors.
+// In REPL mode it returns the first error immediately, because the REPL decides whether to wait for more input by it.
 func (p *Parser) ParseProgram() (*ast.Program, *Error) {
 	p.error = nil
+	p.errors = []*Error{}
 	// Read two tokens, so curToken and peekToken are both set.
 	p.nextToken()
 	p.nextToken()
@@ -174,19 +195,24 @@ func (p *Parser) ParseProgram() (*ast.Program, *Error) {
 	for !p.curTokenIs(token.EOF) {
 		stmt := p.parseStatement()
 
-		if stmt != nil {
-			program.Statements = append(program.Statements, stmt)
-		} else {
-			if p.error != nil {
+		if p.error != nil {
+			if p.Mode == REPLMode {
 				return nil, p.error
 			}
+
+			p.recoverFromError()
+			continue
+		}
+
+		if stmt != nil {
+			program.Statements = append(program.Statements, stmt)
 		}
 
 		p.nextToken()
+	}
 
-		if p.error != nil {
-			return nil, p.error
-		}
+	if len(p.errors) > 0 {
+		return nil, p.errors[0]
 	}
 
 	if p.Mode == TestMode {
@@ -201,6 +227,29 @@ func (p *Parser) ParseProgram() (*ast.Program, *Error) {
 	return program, nil
 }
 
+// Errors returns all syntax errors found by the last ParseProgram call in the order they appear
+func (p *Parser) Errors() []*Error {
+	return p.errors
+}
+
+// recoverFromError records current error and skips the rest of the line where it happened,
+// so the parser can continue with the next line. The `end`s left by the broken statement are skipped too,
+// because they can't start a statement and would only cause another error.
+func (p *Parser) recoverFromError() {
+	p.errors = append(p.errors, p.error)
+
+	for !p.curTokenIs(token.EOF) && (p.curToken.Line <= p.error.Line || p.curTokenIs(token.End)) {
+		p.nextToken()
+	}
+
+	p.error = nil
+	p.acceptBlock = true
+
+	if !p.fsm.Is(normal) {
+		p.fsm.Event(backToNormal)
+	}
+}
+
 func (p *Parser) parseSemicolon() ast.Expression {
 	return nil
 }
@@ -253,16 +302,16 @@ func (p *Parser) registerInfix(tokenType token.Type, fn infixParseFn) {
 
 func (p *Parser) peekError(t token.Type) {
 	msg := fmt.Sprintf("expected next token to be %s, got %s instead. Line: %d", t, p.peekToken.Type, p.peekToken.Line)
-	p.error = &Error{Message: msg, errType: WrongTokenError}
+	p.error = &Error{Message: msg, errType: WrongTokenError, Line: p.peekToken.Line}
 }
 
 func (p *Parser) noPrefixParseFnError(t token.Type) {
 	msg := fmt.Sprintf("unexpected %s Line: %d", p.curToken.Literal, p.curToken.Line)
 
 	if t == token.End {
-		p.error = &Error{Message: msg, errType: UnexpectedEndError}
+		p.error = &Error{Message: msg, errType: UnexpectedEndError, Line: p.curToken.Line}
 	} else {
-		p.error = &Error{Message: msg, errType: UnexpectedTokenError}
+		p.error = &Error{Message: msg, errType: UnexpectedTokenError, Line: p.curToken.Line}
 	}
 }
 
@@ -59,6 +59,18 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"a + b + c",
 			"((a + b) + c)",
 		},
+		{
+			"a << b + c",
+			"(a << (b + c))",
+		},
+		{
+			"a << b << c",
+			"((a << b) << c)",
+		},
+		{
+			"a << b < c",
+			"((a << b) < c)",
+		},
 		{
 			"a + b - c",
 			"((a + b) - c)",
@@ -83,6 +95,18 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"-5 * -5",
 			"((-5) * (-5))",
 		},
+		{
+			"a ** b ** c",
+			"(a ** (b ** c))",
+		},
+		{
+			"a * b ** c",
+			"(a * (b ** c))",
+		},
+		{
+			"a ** b * c",
+			"((a ** b) * c)",
+		},
 		{
 			"5 > 4 == 3 < 4",
 			"((5 > 4) == (3 < 4))",
@@ -91,6 +115,14 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"5 < 4 != 3 > 4",
 			"((5 < 4) != (3 > 4))",
 		},
+		{
+			"5 >= 4 == 3 <= 4",
+			"((5 >= 4) == (3 <= 4))",
+		},
+		{
+			"a + b <= c * d",
+			"((a + b) <= (c * d))",
+		},
 		{
 			"3 + 4 * 5 == 3 * 1 + 4 * 5",
 			"((3 + (4 * 5)) == ((3 * 1) + (4 * 5)))",
@@ -151,6 +183,26 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"n.add(a + b + c * d / f + g)",
 			"n.add((((a + b) + ((c * d) / f)) + g))",
 		},
+		{
+			"a.b + c.d",
+			"(a.b() + c.d())",
+		},
+		{
+			"a.b.c * d.e - f",
+			"((a.b().c() * d.e()) - f)",
+		},
+		{
+			"-a.b",
+			"(-a.b())",
+		},
+		{
+			"a.b(c).d == e.f.g",
+			"(a.b(c).d() == e.f().g())",
+		},
+		{
+			"a.b + c.d(e + f).g",
+			"(a.b() + c.d((e + f)).g())",
+		},
 	}
 
 	for _, tt := range tests {
@@ -197,6 +249,134 @@ func TestIgnoreComments(t *testing.T) {
 
 }
 
+func TestCommentsBetweenTokens(t *testing.T) {
+	withComments := `
+	# leading comment
+	a = [1, # first element
+	  2] # second element
+	h = { # open hash
+	  k: 1 # key
+	}
+	def foo(x) # method
+	  # inside method
+	  x + 1
+	end
+	puts("not a # comment #{h[:k]}") # trailing
+	`
+	withoutComments := `
+	a = [1,
+	  2]
+	h = {
+	  k: 1
+	}
+	def foo(x)
+	  x + 1
+	end
+	puts("not a # comment #{h[:k]}")
+	`
+
+	programs := []string{}
+
+	for _, input := range []string{withComments, withoutComments} {
+		l := lexer.New(input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatal(err.Message)
+		}
+
+		programs = append(programs, program.String())
+	}
+
+	if programs[0] != programs[1] {
+		t.Fatalf("expect program with comments to be %q. got=%q", programs[1], programs[0])
+	}
+}
+
+func TestParseProgramReportsMultipleErrors(t *testing.T) {
+	input := `
+	a = )
+	b = 2
+	p.connect(host: "localhost", 1)
+	c = 3
+	def add(a, a)
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	_, err := p.ParseProgram()
+
+	if err == nil {
+		t.Fatal("expect program to have syntax errors")
+	}
+
+	expected := []*Error{
+		{Message: "unexpected ) Line: 1", Line: 1, errType: UnexpectedTokenError},
+		{Message: "Positional argument can't be passed after keyword arguments. Line: 3", Line: 3, errType: SyntaxError},
+		{Message: "Duplicate argument name: \"a\". Line: 5", Line: 5, errType: SyntaxError},
+	}
+
+	errors := p.Errors()
+
+	if len(errors) != len(expected) {
+		t.Fatalf("expect %d errors. got: %d", len(expected), len(errors))
+	}
+
+	if err != errors[0] {
+		t.Fatalf("expect ParseProgram to return the first error. got: %s", err.Message)
+	}
+
+	for i, e := range expected {
+		if *errors[i] != *e {
+			t.Fatalf("At case %d expect error to be:\n  %+v. got: \n%+v", i, *e, *errors[i])
+		}
+	}
+}
+
+func TestParseProgramStopsAtFirstErrorInREPLMode(t *testing.T) {
+	input := `
+	a = )
+	b = (
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	p.Mode = REPLMode
+	_, err := p.ParseProgram()
+
+	if err == nil {
+		t.Fatal("expect program to have syntax errors")
+	}
+
+	if len(p.Errors()) != 0 {
+		t.Fatalf("expect REPL mode not to collect errors. got: %d", len(p.Errors()))
+	}
+
+	expected := "unexpected ) Line: 1"
+
+	if err.Message != expected {
+		t.Fatalf("expect error message to be:\n  %s. got: \n%s", expected, err.Message)
+	}
+}
+
+func TestCallWithoutMethodNameFail(t *testing.T) {
+	l := lexer.New(`{ a: 1 }(2)`)
+	p := New(l)
+	_, err := p.ParseProgram()
+
+	if err == nil {
+		t.Fatal("expect program to have syntax errors")
+	}
+
+	expected := "unexpected ( Line: 0"
+
+	if err.Message != expected {
+		t.Fatalf("expect error message to be:\n  %s. got: \n%s", expected, err.Message)
+	}
+}
+
 func testIntegerLiteral(t *testing.T, exp ast.Expression, value int) bool {
 	il, ok := exp.(*ast.IntegerLiteral)
 	if !ok {
@@ -288,6 +468,44 @@ func testInstanceVariable(t *testing.T, exp ast.Expression, value string) bool {
 	return true
 }
 
+func testClassVariable(t *testing.T, exp ast.Expression, value string) bool {
+	classVar, ok := exp.(*ast.ClassVariable)
+	if !ok {
+		t.Errorf("exp not *ast.ClassVariable. got=%T", exp)
+		return false
+	}
+	if classVar.Value != value {
+		t.Errorf("classVar.Value not %s. got=%s", value, classVar.Value)
+		return false
+	}
+
+	if classVar.TokenLiteral() != value {
+		t.Errorf("classVar.TokenLiteral not %s. got=%s", value, classVar.TokenLiteral())
+		return false
+	}
+
+	return true
+}
+
+func testGlobalVariable(t *testing.T, exp ast.Expression, value string) bool {
+	globalVar, ok := exp.(*ast.GlobalVariable)
+	if !ok {
+		t.Errorf("exp not *ast.GlobalVariable. got=%T", exp)
+		return false
+	}
+	if globalVar.Value != value {
+		t.Errorf("globalVar.Value not %s. got=%s", value, globalVar.Value)
+		return false
+	}
+
+	if globalVar.TokenLiteral() != value {
+		t.Errorf("globalVar.TokenLiteral not %s. got=%s", value, globalVar.TokenLiteral())
+		return false
+	}
+
+	return true
+}
+
 func testMethodName(t *testing.T, exp ast.Expression, value string) {
 	callExp, ok := exp.(*ast.CallExpression)
 
@@ -9,11 +9,9 @@ import (
 func (p *Parser) parseStatement() ast.Statement {
 	switch p.curToken.Type {
 	case token.Return:
-		return p.parseReturnStatement()
+		return p.parseModifiedStatement(p.parseReturnStatement())
 	case token.Def:
 		return p.parseDefMethodStatement()
-	case token.Comment:
-		return nil
 	case token.While:
 		return p.parseWhileStatement()
 	case token.Class:
@@ -21,9 +19,12 @@ func (p *Parser) parseStatement() ast.Statement {
 	case token.Module:
 		return p.parseModuleStatement()
 	case token.Next:
-		return &ast.NextStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+		return p.parseModifiedStatement(p.parseNextStatement())
 	case token.Break:
-		return &ast.BreakStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+		return p.parseModifiedStatement(p.parseBreakStatement())
+	case token.Semicolon:
+		// Semicolons only separate statements like newlines, so extra ones like `a;; b` are skipped
+		return nil
 	default:
 		exp := p.parseExpressionStatement()
 
@@ -59,7 +60,7 @@ func (p *Parser) parseDefMethodStatement() *ast.DefStatement {
 		case token.Self:
 			stmt.Receiver = &ast.SelfExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
 		default:
-			p.error = &Error{Message: fmt.Sprintf("Invalid method receiver: %s. Line: %d", p.curToken.Literal, p.curToken.Line), errType: MethodDefinitionError}
+			p.error = &Error{Message: fmt.Sprintf("Invalid method receiver: %s. Line: %d", p.curToken.Literal, p.curToken.Line), errType: MethodDefinitionError, Line: p.curToken.Line}
 		}
 
 		p.nextToken() // .
@@ -77,7 +78,7 @@ func (p *Parser) parseDefMethodStatement() *ast.DefStatement {
 	}
 
 	if p.peekTokenIs(token.Ident) && p.peekTokenAtSameLine() { // def foo x, next token is x and at same line
-		p.error = &Error{Message: fmt.Sprintf("Please add parentheses around method \"%s\"'s parameters. Line: %d", stmt.Name.Value, p.curToken.Line), errType: MethodDefinitionError}
+		p.error = &Error{Message: fmt.Sprintf("Please add parentheses around method \"%s\"'s parameters. Line: %d", stmt.Name.Value, p.curToken.Line), errType: MethodDefinitionError, Line: p.curToken.Line}
 	}
 
 	if p.peekTokenIs(token.LParen) {
@@ -110,16 +111,32 @@ func (p *Parser) parseParameters() []ast.Expression {
 	params := []ast.Expression{}
 
 	p.nextToken()
-	param := p.parseExpression(NORMAL)
+	param := p.parseParameter()
 	params = append(params, param)
 
 	for p.peekTokenIs(token.Comma) {
 		p.nextToken()
 		p.nextToken()
-		param := p.parseExpression(NORMAL)
+		param := p.parseParameter()
+
+		if param == nil {
+			break
+		}
 
 		if paramDuplicated(params, param) {
-			p.error = &Error{Message: fmt.Sprintf("Duplicate argument name: \"%s\". Line: %d", getArgName(param), p.curToken.Line), errType: SyntaxError}
+			p.error = &Error{Message: fmt.Sprintf("Duplicate argument name: \"%s\". Line: %d", getArgName(param), p.curToken.Line), errType: SyntaxError, Line: p.curToken.Line}
+		}
+
+		if _, ok := param.(*ast.ArgumentPairExpression); !ok && keywordParameterDefined(params) {
+			p.error = &Error{Message: fmt.Sprintf("Argument \"%s\" can't be defined after keyword argument. Line: %d", getArgName(param), p.curToken.Line), errType: SyntaxError, Line: p.curToken.Line}
+		}
+
+		if _, ok := param.(*ast.AssignExpression); ok && splatParameterDefined(params) {
+			p.error = &Error{Message: fmt.Sprintf("Optioned argument \"%s\" can't be defined after splat argument. Line: %d", getArgName(param), p.curToken.Line), errType: SyntaxError, Line: p.curToken.Line}
+		}
+
+		if isSplatParameter(param) && splatParameterDefined(params) {
+			p.error = &Error{Message: fmt.Sprintf("Duplicate splat argument: \"%s\". Line: %d", getArgName(param), p.curToken.Line), errType: SyntaxError, Line: p.curToken.Line}
 		}
 		params = append(params, param)
 	}
@@ -128,6 +145,46 @@ func (p *Parser) parseParameters() []ast.Expression {
 	return params
 }
 
+// parseParameter parses a method parameter, `*name` is parsed as a splat parameter which collects rest arguments
+// and `name:` is parsed as a keyword parameter.
+func (p *Parser) parseParameter() ast.Expression {
+	if p.curTokenIs(token.Ident) && p.peekTokenIs(token.Colon) {
+		return p.parseKeywordParameter()
+	}
+
+	if !p.curTokenIs(token.Asterisk) {
+		return p.parseExpression(NORMAL)
+	}
+
+	pe := &ast.PrefixExpression{
+		BaseNode: &ast.BaseNode{Token: p.curToken},
+		Operator: p.curToken.Literal,
+	}
+
+	if !p.expectPeek(token.Ident) {
+		return nil
+	}
+
+	pe.Right = p.parseIdentifier()
+	return pe
+}
+
+func (p *Parser) parseKeywordParameter() ast.Expression {
+	ape := &ast.ArgumentPairExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	ape.Key = p.parseIdentifier().(*ast.Identifier)
+
+	p.nextToken() // ':'
+
+	// Required keyword like `def foo(bar:)`
+	if p.peekTokenIs(token.Comma) || p.peekTokenIs(token.RParen) {
+		return ape
+	}
+
+	p.nextToken()
+	ape.Value = p.parseExpression(NORMAL)
+	return ape
+}
+
 func (p *Parser) parseClassStatement() *ast.ClassStatement {
 	stmt := &ast.ClassStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
 
@@ -183,9 +240,48 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	return stmt
 }
 
+func (p *Parser) parseNextStatement() *ast.NextStatement {
+	stmt := &ast.NextStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	stmt.Value = p.parseJumpValue()
+
+	if p.peekTokenIs(token.Semicolon) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseBreakStatement() *ast.BreakStatement {
+	stmt := &ast.BreakStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	stmt.Value = p.parseJumpValue()
+
+	if p.peekTokenIs(token.Semicolon) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// parseJumpValue parses the optional value of `next` or `break`, which must be at the same line like `break x * 2`.
+// It returns nil when the keyword is followed by a modifier or ends the statement.
+func (p *Parser) parseJumpValue() ast.Expression {
+	if !p.peekTokenAtSameLine() || p.peekTokenIsModifier() {
+		return nil
+	}
+
+	switch p.peekToken.Type {
+	case token.Semicolon, token.End, token.RBrace, token.Else, token.ElsIf:
+		return nil
+	}
+
+	p.nextToken()
+
+	return p.parseExpression(NORMAL)
+}
+
 func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 	stmt := &ast.ExpressionStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
-	if p.curTokenIs(token.Ident) || p.curTokenIs(token.InstanceVariable) {
+	if p.curTokenIs(token.Ident) || p.curTokenIs(token.InstanceVariable) || p.curTokenIs(token.ClassVariable) || p.curTokenIs(token.GlobalVariable) {
 		// This is used for identifying method call without parens
 		// Or multiple variable assignment
 		stmt.Expression = p.parseExpression(LOWEST)
@@ -193,9 +289,66 @@ func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 		stmt.Expression = p.parseExpression(NORMAL)
 	}
 
+	for p.peekTokenIsModifier() {
+		stmt.Expression = p.parseModifierExpression(&ast.ExpressionStatement{BaseNode: stmt.BaseNode, Expression: stmt.Expression})
+	}
+
 	return stmt
 }
 
+// parseModifiedStatement wraps statements like `return x if y` into an expression statement if they have modifiers.
+func (p *Parser) parseModifiedStatement(stmt ast.Statement) ast.Statement {
+	if !p.peekTokenIsModifier() {
+		return stmt
+	}
+
+	exp := &ast.ExpressionStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	for p.peekTokenIsModifier() {
+		exp.Expression = p.parseModifierExpression(stmt)
+		stmt = &ast.ExpressionStatement{BaseNode: exp.BaseNode, Expression: exp.Expression}
+	}
+
+	if p.Mode == REPLMode {
+		exp.Expression.MarkAsExp()
+	} else {
+		exp.Expression.MarkAsStmt()
+	}
+
+	return exp
+}
+
+// peekTokenIsModifier checks if next token is an `if` or `unless` modifier at the same line
+func (p *Parser) peekTokenIsModifier() bool {
+	return (p.peekTokenIs(token.If) || p.peekTokenIs(token.Unless)) && p.peekTokenAtSameLine()
+}
+
+// parseModifierExpression wraps a statement followed by a modifier like `x = 1 if ready` or `x = 1 unless done`
+// into an `if` expression, which evaluates to nil when the statement is skipped.
+func (p *Parser) parseModifierExpression(stmt ast.Statement) ast.Expression {
+	p.nextToken()
+
+	ie := &ast.IfExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	ce := &ast.ConditionalExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	body := &ast.BlockStatement{BaseNode: &ast.BaseNode{Token: p.curToken}, Statements: []ast.Statement{stmt}}
+	body.KeepLastValue()
+	isUnless := p.curTokenIs(token.Unless)
+
+	p.nextToken()
+	ce.Condition = p.parseExpression(NORMAL)
+	ie.Conditionals = []*ast.ConditionalExpression{ce}
+
+	if isUnless {
+		// `x unless y` is `if y; nil; else; x; end`
+		ce.Consequence = &ast.BlockStatement{BaseNode: &ast.BaseNode{Token: p.curToken}, Statements: []ast.Statement{}}
+		ie.Alternative = body
+	} else {
+		ce.Consequence = body
+	}
+
+	return ie
+}
+
 func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 
 	// curToken is '{'
@@ -208,10 +361,34 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 		p.nextToken()
 	}
 
-	for !p.curTokenIs(token.End) && !p.curTokenIs(token.Else) && !p.curTokenIs(token.ElsIf) {
+	for !p.curTokenIs(token.End) && !p.curTokenIs(token.Else) && !p.curTokenIs(token.ElsIf) && !p.curTokenIs(token.Rescue) && !p.curTokenIs(token.When) {
+
+		if p.curTokenIs(token.EOF) {
+			p.error = &Error{Message: "Unexpected EOF", errType: EndOfFileError, Line: p.curToken.Line}
+			return bs
+		}
+		stmt := p.parseStatement()
+
+		if stmt != nil {
+			bs.Statements = append(bs.Statements, stmt)
+		}
+		p.nextToken()
+	}
+
+	return bs
+}
+
+// parseBraceBlockStatement parses a block's body that ends with '}', like `{ |x| x + 1 }`
+func (p *Parser) parseBraceBlockStatement() *ast.BlockStatement {
+	bs := &ast.BlockStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	bs.Statements = []ast.Statement{}
+
+	p.nextToken()
+
+	for !p.curTokenIs(token.RBrace) {
 
 		if p.curTokenIs(token.EOF) {
-			p.error = &Error{Message: "Unexpected EOF", errType: EndOfFileError}
+			p.error = &Error{Message: "Unexpected EOF", errType: EndOfFileError, Line: p.curToken.Line}
 			return bs
 		}
 		stmt := p.parseStatement()
@@ -233,9 +410,9 @@ func (p *Parser) parseWhileStatement() *ast.WhileStatement {
 	p.acceptBlock = false
 	ws.Condition = p.parseExpression(NORMAL)
 	p.acceptBlock = true
-	p.nextToken()
 
-	if p.curTokenIs(token.Semicolon) {
+	// `do` and `;` are optional, the block statement parsing skips current token anyway
+	if p.peekTokenIs(token.Do) || p.peekTokenIs(token.Semicolon) {
 		p.nextToken()
 	}
 
@@ -260,5 +437,36 @@ func getArgName(exp ast.Expression) string {
 		return assignExp.Variables[0].TokenLiteral()
 	}
 
+	if isSplatParameter(exp) {
+		return exp.(*ast.PrefixExpression).Right.TokenLiteral()
+	}
+
+	if ape, ok := exp.(*ast.ArgumentPairExpression); ok {
+		return ape.Key.Value
+	}
+
 	return exp.TokenLiteral()
 }
+
+func isSplatParameter(exp ast.Expression) bool {
+	_, ok := exp.(*ast.PrefixExpression)
+	return ok
+}
+
+func splatParameterDefined(params []ast.Expression) bool {
+	for _, p := range params {
+		if isSplatParameter(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func keywordParameterDefined(params []ast.Expression) bool {
+	for _, p := range params {
+		if _, ok := p.(*ast.ArgumentPairExpression); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -43,6 +43,78 @@ func TestReturnStatements(t *testing.T) {
 
 }
 
+func TestBreakAndNextStatements(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedValue interface{}
+	}{
+		{"break", nil},
+		{"break;", nil},
+		{"break 5", 5},
+		{"break x", "x"},
+		{"next", nil},
+		{"next true;", true},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatal(err.Message)
+		}
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("At case %d program.Statements does not contain 1 statements. got=%d", i, len(program.Statements))
+		}
+
+		var value ast.Expression
+
+		switch stmt := program.Statements[0].(type) {
+		case *ast.BreakStatement:
+			value = stmt.Value
+		case *ast.NextStatement:
+			value = stmt.Value
+		default:
+			t.Fatalf("At case %d stmt not *ast.BreakStatement or *ast.NextStatement. got=%T", i, stmt)
+		}
+
+		if tt.expectedValue == nil {
+			if value != nil {
+				t.Errorf("At case %d expect no value. got=%s", i, value.String())
+			}
+			continue
+		}
+
+		testLiteralExpression(t, value, tt.expectedValue)
+	}
+}
+
+func TestBreakStatementWithModifier(t *testing.T) {
+	input := `break x * 2 if x > 1`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	exp, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("expect expression to be *ast.IfExpression. got=%T", program.Statements[0].(*ast.ExpressionStatement).Expression)
+	}
+
+	stmt, ok := exp.Conditionals[0].Consequence.Statements[0].(*ast.BreakStatement)
+	if !ok {
+		t.Fatalf("expect consequence to be *ast.BreakStatement. got=%T", exp.Conditionals[0].Consequence.Statements[0])
+	}
+
+	testInfixExpression(t, stmt.Value, "x", "*", 2)
+}
+
 func TestClassStatement(t *testing.T) {
 	input := `
 	class Foo
@@ -197,6 +269,83 @@ func TestDefStatement(t *testing.T) {
 	testIntegerLiteral(t, secondExpressionStmt.Expression, 123)
 }
 
+func TestDefStatementWithSplatParameter(t *testing.T) {
+	input := `
+	def foo(a, *b, c)
+	  b
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.DefStatement)
+
+	if len(stmt.Parameters) != 3 {
+		t.Fatalf("expect method to have 3 parameters. got=%d", len(stmt.Parameters))
+	}
+
+	testLiteralExpression(t, stmt.Parameters[0], "a")
+
+	splat, ok := stmt.Parameters[1].(*ast.PrefixExpression)
+
+	if !ok {
+		t.Fatalf("expect splat parameter to be PrefixExpression. got=%T", stmt.Parameters[1])
+	}
+
+	if splat.Operator != "*" {
+		t.Fatalf("expect splat parameter's operator to be '*'. got=%s", splat.Operator)
+	}
+
+	testIdentifier(t, splat.Right, "b")
+	testLiteralExpression(t, stmt.Parameters[2], "c")
+}
+
+func TestDefStatementWithKeywordParameters(t *testing.T) {
+	input := `
+	def connect(a, host:, port: 80)
+	  host
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.DefStatement)
+
+	if len(stmt.Parameters) != 3 {
+		t.Fatalf("expect method to have 3 parameters. got=%d", len(stmt.Parameters))
+	}
+
+	testLiteralExpression(t, stmt.Parameters[0], "a")
+
+	host, ok := stmt.Parameters[1].(*ast.ArgumentPairExpression)
+
+	if !ok {
+		t.Fatalf("expect keyword parameter to be ArgumentPairExpression. got=%T", stmt.Parameters[1])
+	}
+
+	testIdentifier(t, host.Key, "host")
+
+	if host.Value != nil {
+		t.Fatalf("expect required keyword parameter not to have a value. got=%s", host.Value.String())
+	}
+
+	port := stmt.Parameters[2].(*ast.ArgumentPairExpression)
+	testIdentifier(t, port.Key, "port")
+	testIntegerLiteral(t, port.Value, 80)
+}
+
 func TestDefStatementFailWithDuplicateArgumentName(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -227,6 +376,16 @@ func TestDefStatementFailWithDuplicateArgumentName(t *testing.T) {
 			a + b
 		end
 		`, "Duplicate argument name: \"b\". Line: 1"},
+		{`
+		def add(a, *b, b)
+			a + b
+		end
+		`, "Duplicate argument name: \"b\". Line: 1"},
+		{`
+		def add(a, a: 1)
+			a
+		end
+		`, "Duplicate argument name: \"a\". Line: 1"},
 	}
 
 	for i, tt := range tests {
@@ -244,6 +403,44 @@ func TestDefStatementFailWithDuplicateArgumentName(t *testing.T) {
 	}
 }
 
+func TestDefStatementFailWithInvalidParameterOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`
+		def foo(*a, *b)
+		end
+		`, "Duplicate splat argument: \"b\". Line: 1"},
+		{`
+		def foo(*a, b = 1)
+		end
+		`, "Optioned argument \"b\" can't be defined after splat argument. Line: 1"},
+		{`
+		def foo(a:, b)
+		end
+		`, "Argument \"b\" can't be defined after keyword argument. Line: 1"},
+		{`
+		def foo(a:, *b)
+		end
+		`, "Argument \"b\" can't be defined after keyword argument. Line: 1"},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		_, err := p.ParseProgram()
+
+		if err == nil {
+			t.Fatalf("At case %d expect not to allow invalid argument order", i)
+		}
+
+		if err.Message != tt.expected {
+			t.Fatalf("At case %d expect error message to be:\n  %s. got: \n%s", i, tt.expected, err.Message)
+		}
+	}
+}
+
 func TestDefStatementWithYield(t *testing.T) {
 	input := `
 	def foo
@@ -280,6 +477,44 @@ func TestDefStatementWithYield(t *testing.T) {
 	}
 }
 
+func TestDefStatementWithSuper(t *testing.T) {
+	input := `
+	def foo(a)
+	  super(1, a)
+	  super
+	  super()
+	end
+	`
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.DefStatement)
+	block := stmt.BlockStatement
+
+	expected := []string{"super(1, a)", "super", "super()"}
+
+	for i, s := range block.Statements {
+		se, ok := s.(*ast.ExpressionStatement).Expression.(*ast.SuperExpression)
+
+		if !ok {
+			t.Fatalf("Expect method's body is a SuperExpression. got=%T", s)
+		}
+
+		if se.String() != expected[i] {
+			t.Fatalf("Expect super expression to be %s. got=%s", expected[i], se.String())
+		}
+	}
+
+	if !block.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.SuperExpression).ImplicitArgs {
+		t.Fatalf("Expect bare super to pass arguments implicitly")
+	}
+}
+
 func TestWhileStatement(t *testing.T) {
 	input := `
 	while i < a.length do
@@ -330,3 +565,99 @@ func TestWhileStatement(t *testing.T) {
 	testIdentifier(t, secondCall.Receiver, "i")
 	testMethodName(t, secondCall, "++")
 }
+
+func TestWhileStatementWithoutDoKeyword(t *testing.T) {
+	input := `
+	while i < a.length
+	  puts(i)
+	  i++
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	whileStatement := program.Statements[0].(*ast.WhileStatement)
+
+	if whileStatement.Condition.String() != "(i < a.length())" {
+		t.Fatalf("Expect condition to be (i < a.length()). got: %s", whileStatement.Condition.String())
+	}
+
+	block := whileStatement.Body
+
+	if len(block.Statements) != 2 {
+		t.Fatalf("Expect while's body to have 2 statements. got: %d", len(block.Statements))
+	}
+
+	firstCall := block.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	testMethodName(t, firstCall, "puts")
+	testIdentifier(t, firstCall.Arguments[0], "i")
+}
+
+func TestSemicolonSeparatedStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{`a = 1; b = 2; a + b`, []string{"(a = 1)", "(b = 2)", "(a + b)"}},
+		{`a = 5; -a`, []string{"(a = 5)", "(-a)"}},
+		{`a;;b`, []string{"a", "b"}},
+		{`a; b;`, []string{"a", "b"}},
+		{`;a;;;`, []string{"a"}},
+		{`
+		a = 1;
+		;
+		b = 2;;
+		`, []string{"(a = 1)", "(b = 2)"}},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d got error: %s", i, err.Message)
+		}
+
+		if len(program.Statements) != len(tt.expected) {
+			t.Fatalf("At case %d expect %d statements. got: %d", i, len(tt.expected), len(program.Statements))
+		}
+
+		for j, stmt := range program.Statements {
+			if stmt.String() != tt.expected[j] {
+				t.Fatalf("At case %d expect statement %d to be %s. got: %s", i, j, tt.expected[j], stmt.String())
+			}
+		}
+	}
+}
+
+func TestWhileStatementWithSemicolon(t *testing.T) {
+	input := `while i < 2; puts(i); i += 1; end`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	whileStatement := program.Statements[0].(*ast.WhileStatement)
+
+	if whileStatement.Condition.String() != "(i < 2)" {
+		t.Fatalf("Expect condition to be (i < 2). got: %s", whileStatement.Condition.String())
+	}
+
+	if len(whileStatement.Body.Statements) != 2 {
+		t.Fatalf("Expect while's body to have 2 statements. got: %d", len(whileStatement.Body.Statements))
+	}
+
+	firstCall := whileStatement.Body.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	testMethodName(t, firstCall, "puts")
+}
@@ -18,26 +18,36 @@ const (
 	Constant         = "CONSTANT"
 	Ident            = "IDENT"
 	InstanceVariable = "INSTANCE_VAR"
+	ClassVariable    = "CLASS_VAR"
+	GlobalVariable   = "GLOBAL_VAR"
 	Int              = "INT"
+	Float            = "FLOAT"
 	String           = "STRING"
-	Comment          = "COMMENT"
+	Symbol           = "SYMBOL"
 
-	Assign   = "="
-	Plus     = "+"
-	PlusEq   = "+="
-	Minus    = "-"
-	MinusEq  = "-="
-	Bang     = "!"
-	Asterisk = "*"
-	Pow      = "**"
-	Slash    = "/"
-	Dot      = "."
-	Incr     = "++"
-	Decr     = "--"
-	And      = "&&"
-	Or       = "||"
-	OrEq     = "||="
-	Modulo   = "%"
+	InterpolatedStringBegin = "INTERPOLATED_STRING_BEGIN"
+	InterpolatedStringEnd   = "INTERPOLATED_STRING_END"
+	InterpolationBegin      = "#{"
+	InterpolationEnd        = "}"
+
+	Assign     = "="
+	Plus       = "+"
+	PlusEq     = "+="
+	Minus      = "-"
+	MinusEq    = "-="
+	Bang       = "!"
+	Asterisk   = "*"
+	AsteriskEq = "*="
+	Pow        = "**"
+	Slash      = "/"
+	SlashEq    = "/="
+	Dot        = "."
+	Incr       = "++"
+	Decr       = "--"
+	And        = "&&"
+	Or         = "||"
+	OrEq       = "||="
+	Modulo     = "%"
 
 	LT   = "<"
 	LTE  = "<="
@@ -45,9 +55,12 @@ const (
 	GTE  = ">="
 	COMP = "<=>"
 
+	LShift = "<<"
+
 	Comma     = ","
 	Semicolon = ";"
 	Colon     = ":"
+	Question  = "?"
 	Bar       = "|"
 
 	LParen   = "("
@@ -57,14 +70,16 @@ const (
 	LBracket = "["
 	RBracket = "]"
 
-	Eq    = "=="
-	NotEq = "!="
-	Range = ".."
+	Eq         = "=="
+	NotEq      = "!="
+	Range      = ".."
+	HashRocket = "=>"
 
 	True   = "TRUE"
 	False  = "FALSE"
 	Null   = "Null"
 	If     = "IF"
+	Unless = "UNLESS"
 	ElsIf  = "ELSIF"
 	Else   = "ELSE"
 	Return = "RETURN"
@@ -74,10 +89,18 @@ const (
 	Self   = "SELF"
 	End    = "END"
 	While  = "WHILE"
+	For    = "FOR"
+	In     = "IN"
 	Do     = "DO"
 	Yield  = "YIELD"
+	Super  = "SUPER"
 	Class  = "CLASS"
 	Module = "MODULE"
+	Begin  = "BEGIN"
+	Rescue = "RESCUE"
+	Case   = "CASE"
+	When   = "WHEN"
+	Then   = "THEN"
 
 	ResolutionOperator = "::"
 )
@@ -88,18 +111,27 @@ var keywords = map[string]Type{
 	"false":  False,
 	"nil":    Null,
 	"if":     If,
+	"unless": Unless,
 	"elsif":  ElsIf,
 	"else":   Else,
 	"return": Return,
 	"self":   Self,
 	"end":    End,
 	"while":  While,
+	"for":    For,
+	"in":     In,
 	"do":     Do,
 	"yield":  Yield,
+	"super":  Super,
 	"next":   Next,
 	"class":  Class,
 	"module": Module,
 	"break":  Break,
+	"begin":  Begin,
+	"rescue": Rescue,
+	"case":   Case,
+	"when":   When,
+	"then":   Then,
 }
 
 // LookupIdent is used for keyword identification
@@ -21,6 +21,7 @@ func main() {
 	profileOptionPtr := flag.Bool("p", false, "Profile program execution")
 	versionOptionPtr := flag.Bool("v", false, "Show current Goby version")
 	interactiveOptionPtr := flag.Bool("i", false, "Run interactive goby")
+	optimizeOptionPtr := flag.Bool("O", false, "Fold constant expressions before execution")
 
 	flag.Parse()
 
@@ -41,7 +42,7 @@ func main() {
 	fp := flag.Arg(0)
 
 	if fp == "" {
-		flag.Usage()
+		igb.StartIgb(Version)
 		os.Exit(0)
 	}
 
@@ -56,7 +57,13 @@ func main() {
 
 	switch fileExt {
 	case "gb", "rb":
-		instructionSets, err := compiler.CompileToInstructions(string(file), parser.NormalMode)
+		compile := compiler.CompileToInstructions
+
+		if *optimizeOptionPtr {
+			compile = compiler.CompileToOptimizedInstructions
+		}
+
+		instructionSets, err := compile(string(file), parser.NormalMode)
 
 		if err != nil {
 			fmt.Println(err.Error())
@@ -209,7 +209,14 @@ reset:
 				continue
 			}
 
-			instructions := ivm.g.GenerateInstructions(program.Statements)
+			instructions, err := ivm.g.GenerateInstructions(program.Statements)
+
+			if err != nil {
+				fmt.Println(err.Error())
+				igb.cmds = nil
+				continue
+			}
+
 			ivm.v.REPLExec(instructions)
 
 			r := ivm.v.GetREPLResult()
@@ -2,6 +2,7 @@ package vm
 
 import (
 	"bytes"
+	"sort"
 	"strings"
 )
 
@@ -143,6 +144,31 @@ func builtInArrayClassMethods() []*BuiltInMethodObject {
 
 func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 	return []*BuiltInMethodObject{
+		{
+			// Appends the given object to the array and returns the array.
+			//
+			// ```ruby
+			// a = [1, 2]
+			// a << 3      # => [1, 2, 3]
+			// a << 4 << 5 # => [1, 2, 3, 4, 5]
+			// ```
+			// @return [Array]
+			Name: "<<",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver.isFrozen() {
+						return t.frozenError(receiver)
+					}
+
+					if len(args) != 1 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 1 argument. got=%d", len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					return arr.push(args)
+				}
+			},
+		},
 		{
 			// Retrieves an object in an array using Integer index.
 			// The index starts from 0. It returns `null` if the given index is bigger than its size.
@@ -202,6 +228,9 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 			Name: "[]=",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver.isFrozen() {
+						return t.frozenError(receiver)
+					}
 
 					// First arg is index
 					// Second arg is assigned value
@@ -255,7 +284,8 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 			// a.at(-2) # => 2
 			// a.at(-4) # => nil
 			// ```
-			Name: "at",
+			Name:  "at",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					i := args[0]
@@ -293,6 +323,10 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 			Name: "clear",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver.isFrozen() {
+						return t.frozenError(receiver)
+					}
+
 					arr := receiver.(*ArrayObject)
 					arr.Elements = []Object{}
 
@@ -311,6 +345,10 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 			Name: "concat",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver.isFrozen() {
+						return t.frozenError(receiver)
+					}
+
 					arr := receiver.(*ArrayObject)
 
 					for _, arg := range args {
@@ -354,7 +392,7 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 					if blockFrame != nil {
 						for _, obj := range arr.Elements {
 							result := t.builtInMethodYield(blockFrame, obj)
-							if result.Target.(*BooleanObject).value {
+							if isTruthy(result.Target) {
 								count++
 							}
 						}
@@ -368,6 +406,7 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 
 					arg := args[0]
 					findInt, findIsInt := arg.(*IntegerObject)
+					findFloat, findIsFloat := arg.(*FloatObject)
 					findString, findIsString := arg.(*StringObject)
 					findBoolean, findIsBoolean := arg.(*BooleanObject)
 
@@ -379,6 +418,11 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 							if findIsInt && findInt.equal(elInt) {
 								count++
 							}
+						case *FloatObject:
+							elFloat := el.(*FloatObject)
+							if findIsFloat && findFloat.equal(elFloat) {
+								count++
+							}
 						case *StringObject:
 							elString := el.(*StringObject)
 							if findIsString && findString.equal(elString) {
@@ -460,26 +504,29 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 
 					arr := receiver.(*ArrayObject)
 
-					if arr.length() == 0 {
-						return TRUE
-					}
-
-					return FALSE
+					return toBooleanObject(arr.length() == 0)
 				}
 			},
 		},
 		{
-			// Returns the first element of the array.
+			// Returns the first element of the array, or nil if the array is empty.
+			// If an Integer n is given, returns an array with the first n elements.
+			//
+			// ```ruby
+			// [1, 2, 3].first    # => 1
+			// [1, 2, 3].first(2) # => [1, 2]
+			// [].first           # => nil
+			// ```
 			Name: "first",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					arr := receiver.(*ArrayObject)
 
-					if len(arr.Elements) == 0 {
-						return NULL
-					}
-
 					if len(args) == 0 {
+						if len(arr.Elements) == 0 {
+							return NULL
+						}
+
 						return arr.Elements[0]
 					}
 
@@ -488,7 +535,16 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
 					}
 
-					return t.vm.initArrayObject(arr.Elements[:arg.value])
+					if arg.value < 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect non-negative array size. got=%d", arg.value)
+					}
+
+					n := arg.value
+					if n > len(arr.Elements) {
+						n = len(arr.Elements)
+					}
+
+					return t.vm.initArrayObject(arr.Elements[:n:n])
 				}
 			},
 		},
@@ -517,13 +573,61 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 				}
 			},
 		},
+		{
+			// Returns true if the array contains an element that is `==` to the given object.
+			//
+			// ```ruby
+			// [1, 2, 3].include?(2)   # => true
+			// [1, 2, 3].include?("2") # => false
+			// [[1], 2].include?([1])  # => true
+			// ```
+			// @return [Boolean]
+			Name: "include?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+
+					if len(args) != 1 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 1 argument. got=%d", len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+
+					for _, e := range arr.Elements {
+						result := t.sendMethod(e, "==", args[0])
+
+						if err, ok := result.(*Error); ok {
+							return err
+						}
+
+						if isTruthy(result) {
+							return TRUE
+						}
+					}
+
+					return FALSE
+				}
+			},
+		},
+		{
+			// Same as `reduce`.
+			//
+			// ```ruby
+			// [1, 2, 3].inject(0) do |sum, n|
+			//   sum + n
+			// end
+			// # => 6
+			// ```
+			Name: "inject",
+			Fn:   reduce,
+		},
 		{
 			// Returns a string by concatenating each element to string, separated by given separator.
-			// If separator is nil, it uses empty string.
+			// Each element is converted with its `to_s` method. If separator is nil, it uses empty string.
 			//
 			// ```ruby
 			// [ 1, 2, 3 ].join # => "123"
 			// [ 1, 2, 3 ].join("-") # => "1-2-3"
+			// [ 1, "a", nil, :b ].join(", ") # => "1, a, , b"
 			// ```
 			// @param separator [String]
 			// @return [String]
@@ -549,7 +653,13 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 
 					elements := []string{}
 					for _, e := range arr.flatten() {
-						elements = append(elements, e.toString())
+						str := t.sendMethod(e, "to_s")
+
+						if err, ok := str.(*Error); ok {
+							return err
+						}
+
+						elements = append(elements, str.toString())
 					}
 
 					return t.vm.initStringObject(strings.Join(elements, sep))
@@ -557,14 +667,26 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			// Returns the last element of the array.
+			// Returns the last element of the array, or nil if the array is empty.
+			// If an Integer n is given, returns an array with the last n elements.
+			//
+			// ```ruby
+			// [1, 2, 3].last    # => 3
+			// [1, 2, 3].last(2) # => [2, 3]
+			// [].last           # => nil
+			// ```
 			Name: "last",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					arr := receiver.(*ArrayObject)
+					l := len(arr.Elements)
 
 					if len(args) == 0 {
-						return arr.Elements[len(arr.Elements)-1]
+						if l == 0 {
+							return NULL
+						}
+
+						return arr.Elements[l-1]
 					}
 
 					arg, ok := args[0].(*IntegerObject)
@@ -572,8 +694,16 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
 					}
 
-					l := len(arr.Elements)
-					return t.vm.initArrayObject(arr.Elements[l-arg.value : l])
+					if arg.value < 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect non-negative array size. got=%d", arg.value)
+					}
+
+					n := arg.value
+					if n > l {
+						n = l
+					}
+
+					return t.vm.initArrayObject(arr.Elements[l-n : l : l])
 				}
 			},
 		},
@@ -638,6 +768,9 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 			Name: "pop",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver.isFrozen() {
+						return t.frozenError(receiver)
+					}
 
 					if len(args) != 0 {
 						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got=%d", len(args))
@@ -658,6 +791,9 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 			Name: "push",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver.isFrozen() {
+						return t.frozenError(receiver)
+					}
 
 					arr := receiver.(*ArrayObject)
 					return arr.push(args)
@@ -666,7 +802,8 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 		},
 		{
 			// Loop through each elements and accumulate each results of given block in the first argument of the block
-			// If you do not give an argument, the first element of collection is used as an initial value
+			// If you do not give an argument, the first element of collection is used as an initial value,
+			// and an empty array returns nil.
 			//
 			// ```ruby
 			// a = [1, 2, 7]
@@ -682,33 +819,7 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 			// # => 20
 			// ```
 			Name: "reduce",
-			Fn: func(receiver Object) builtinMethodBody {
-				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					arr := receiver.(*ArrayObject)
-					if blockFrame == nil {
-						return t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
-					}
-
-					var prev Object
-					var start int
-					if len(args) == 0 {
-						prev = arr.Elements[0]
-						start = 1
-					} else if len(args) == 1 {
-						prev = args[0]
-						start = 0
-					} else {
-						return t.vm.initErrorObject(ArgumentError, "Expect 0 or 1 argument. got=%d", len(args))
-					}
-
-					for i := start; i < len(arr.Elements); i++ {
-						result := t.builtInMethodYield(blockFrame, prev, arr.Elements[i])
-						prev = result.Target
-					}
-
-					return prev
-				}
-			},
+			Fn:   reduce,
 		},
 		{
 			// Returns a new array by putting the desired element as the first element.
@@ -770,7 +881,7 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 
 					for _, obj := range arr.Elements {
 						result := t.builtInMethodYield(blockFrame, obj)
-						if result.Target.(*BooleanObject).value {
+						if isTruthy(result.Target) {
 							elements = append(elements, obj)
 						}
 					}
@@ -790,6 +901,10 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 			Name: "shift",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver.isFrozen() {
+						return t.frozenError(receiver)
+					}
+
 					if len(args) != 0 {
 						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got=%d", len(args))
 					}
@@ -799,5 +914,163 @@ func builtinArrayInstanceMethods() []*BuiltInMethodObject {
 				}
 			},
 		},
+		{
+			// Returns a new array with sorted elements, which are compared by their `<=>` method.
+			// It raises an error if any two elements can't be compared.
+			//
+			// ```ruby
+			// [3, 1, 2].sort       # => [1, 2, 3]
+			// ["b", "c", "a"].sort # => ["a", "b", "c"]
+			// ```
+			// @return [Array]
+			Name: "sort",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					sorted, err := sortByKeys(t, arr.Elements, arr.Elements)
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initArrayObject(sorted)
+				}
+			},
+		},
+		{
+			// Returns a new array with elements sorted by the block's results, which are compared by their `<=>` method.
+			// The block is called once for each element, and elements with equal results keep their order.
+			//
+			// ```ruby
+			// ["ccc", "a", "bb"].sort_by do |s|
+			//   s.length
+			// end
+			// # => ["a", "bb", "ccc"]
+			// ```
+			// @return [Array]
+			Name: "sort_by",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
+					}
+
+					arr := receiver.(*ArrayObject)
+					keys := make([]Object, len(arr.Elements))
+
+					for i, obj := range arr.Elements {
+						keys[i] = t.builtInMethodYield(blockFrame, obj).Target
+					}
+
+					sorted, err := sortByKeys(t, arr.Elements, keys)
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initArrayObject(sorted)
+				}
+			},
+		},
+		{
+			// Returns self.
+			//
+			// ```ruby
+			// [1, 2, 3].to_a # => [1, 2, 3]
+			// ```
+			// @return [Array]
+			Name: "to_a",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
+
+					return receiver
+				}
+			},
+		},
+	}
+}
+
+// Other helper functions -----------------------------------------------
+
+// reduce is the implementation of `reduce` and `inject`
+func reduce(receiver Object) builtinMethodBody {
+	return func(t *thread, args []Object, blockFrame *callFrame) Object {
+		arr := receiver.(*ArrayObject)
+		if blockFrame == nil {
+			return t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
+		}
+
+		var prev Object
+		var start int
+		if len(args) == 0 {
+			if len(arr.Elements) == 0 {
+				return NULL
+			}
+
+			prev = arr.Elements[0]
+			start = 1
+		} else if len(args) == 1 {
+			prev = args[0]
+			start = 0
+		} else {
+			return t.vm.initErrorObject(ArgumentError, "Expect 0 or 1 argument. got=%d", len(args))
+		}
+
+		for i := start; i < len(arr.Elements); i++ {
+			result := t.builtInMethodYield(blockFrame, prev, arr.Elements[i])
+			prev = result.Target
+		}
+
+		return prev
+	}
+}
+
+// sortByKeys returns a sorted copy of elements, the order is decided by comparing their keys with `<=>`.
+// The sort is stable, and it returns an error if any two keys can't be compared.
+func sortByKeys(t *thread, elements, keys []Object) ([]Object, *Error) {
+	indexes := make([]int, len(elements))
+
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	var err *Error
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+
+		result, e := spaceship(t, keys[indexes[i]], keys[indexes[j]])
+
+		if e != nil {
+			err = e
+			return false
+		}
+
+		return result < 0
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]Object, len(elements))
+
+	for i, index := range indexes {
+		sorted[i] = elements[index]
 	}
+
+	return sorted, nil
 }
@@ -99,6 +99,12 @@ func TestArrayIndex(t *testing.T) {
 		{`
 			[1, "a", 10, 5][1]
 		`, "a"},
+		{`
+			[1, 2.5, "three"][1]
+		`, 2.5},
+		{`
+			[1, 2.5, "three"].to_s
+		`, `[1, 2.5, "three"]`},
 		{`
 		    [1, "a", 10, "b"][-2]
 		`, 10},
@@ -159,6 +165,50 @@ func TestArrayIndex(t *testing.T) {
 	}
 }
 
+func TestArrayLeftShiftMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []interface{}
+	}{
+		{`
+		a = [1, 2]
+		a << 3
+		a
+		`, []interface{}{1, 2, 3}},
+		{`
+		a = []
+		a << "a" << "b"
+		`, []interface{}{"a", "b"}},
+		{`
+		a = [1]
+		a << 1 + 1
+		`, []interface{}{1, 2}},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		testArrayObject(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayLeftShiftMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1, 2].send("<<", 3, 4)
+		`, "ArgumentError: Expect 1 argument. got=2", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestArrayAtMethod(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -297,6 +347,10 @@ func TestArrayCountMethod(t *testing.T) {
 		a.count(true)
 		`, 4},
 		{`
+		a = [1.5, 2, 1.5, "1.5"]
+		a.count(1.5)
+		`, 2},
+		{`
 		a = []
 		a.count(true)
 		`, 0},
@@ -312,6 +366,12 @@ func TestArrayCountMethod(t *testing.T) {
 			i.size > 1
 		end
 		`, 3},
+		{`
+		a = [1, nil, false, "a"]
+		a.count do |i|
+			i
+		end
+		`, 2},
 	}
 
 	for i, tt := range tests {
@@ -351,6 +411,23 @@ func TestArrayEachMethod(t *testing.T) {
 		end
 		sum
 		`, 15},
+		{`
+		sum = 0
+		[].each do |i|
+		  sum = sum + i
+		end
+		sum
+		`, 0},
+		{`
+		[1, 2, 3].each do |i|
+		  i * 2
+		end.length
+		`, 3},
+		{`
+		[].each do |i|
+		  i
+		end.length
+		`, 0},
 	}
 
 	for i, tt := range tests {
@@ -358,6 +435,7 @@ func TestArrayEachMethod(t *testing.T) {
 		evaluated := v.testEval(t, tt.input, getFilename())
 		checkExpected(t, i, evaluated, tt.expected)
 		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
 	}
 }
 
@@ -425,6 +503,9 @@ func TestArrayFirstMethod(t *testing.T) {
 		a = [1, 2]
 		a.first
 		`, 1},
+		{`
+		[].first
+		`, nil},
 	}
 
 	for i, tt := range testsInt {
@@ -446,6 +527,19 @@ func TestArrayFirstMethod(t *testing.T) {
 		a = ["a", "b", "d", "q"]
 		a.first(2)
 		`, []interface{}{"a", "b"}},
+		{`
+		a = [1, 2]
+		a.first(5)
+		`, []interface{}{1, 2}},
+		{`
+		[].first(2)
+		`, []interface{}{}},
+		{`
+		a = [1, 2, 3]
+		b = a.first(1)
+		b.push(4)
+		a
+		`, []interface{}{1, 2, 3}},
 	}
 
 	for i, tt := range testsArray {
@@ -461,6 +555,9 @@ func TestArrayFirstMethodFail(t *testing.T) {
 		{`a = [1, 2]
 		a.first("a")
 		`, "TypeError: Expect argument to be Integer. got: String", 2},
+		{`a = [1, 2]
+		a.first(-1)
+		`, "ArgumentError: Expect non-negative array size. got=-1", 2},
 	}
 
 	for i, tt := range testsFail {
@@ -515,6 +612,53 @@ func TestArrayFlattenMethodFail(t *testing.T) {
 	}
 }
 
+func TestArrayIncludeMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[1, 2, 3].include?(2)`, true},
+		{`[1, 2, 3].include?(4)`, false},
+		{`[1, 2, 3].include?("2")`, false},
+		{`["a", nil, :b].include?(nil)`, true},
+		{`[[1, 2], 3].include?([1, 2])`, true},
+		{`[].include?(1)`, false},
+		{`
+		class Foo
+		  def ==(other)
+		    true
+		  end
+		end
+		[Foo.new].include?(1)
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayIncludeMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1, 2].include?
+		`, "ArgumentError: Expect 1 argument. got=0", 1},
+		{`[1, 2].include?(1, 2)
+		`, "ArgumentError: Expect 1 argument. got=2", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestArrayJoinMethod(t *testing.T) {
 	testsInt := []struct {
 		input    string
@@ -532,6 +676,20 @@ func TestArrayJoinMethod(t *testing.T) {
 		{`
 		[1, 2, [3, 4]].join(",")
 		`, "1,2,3,4"},
+		{`
+		[1, "a", 1.5, nil, true, :b].join(", ")
+		`, "1, a, 1.5, , true, b"},
+		{`
+		class Foo
+		  def to_s
+		    "foo"
+		  end
+		end
+		[Foo.new, 1].join("-")
+		`, "foo-1"},
+		{`
+		[].join(",")
+		`, ""},
 	}
 
 	for i, tt := range testsInt {
@@ -587,6 +745,13 @@ func TestArrayLastMethod(t *testing.T) {
 		a = ["a", "b", "d", "q"]
 		a.last(2)
 		`, []interface{}{"d", "q"}},
+		{`
+		a = [1, 2]
+		a.last(5)
+		`, []interface{}{1, 2}},
+		{`
+		[].last(2)
+		`, []interface{}{}},
 	}
 
 	for i, tt := range testsArray {
@@ -595,6 +760,26 @@ func TestArrayLastMethod(t *testing.T) {
 		testArrayObject(t, i, evaluated, tt.expected)
 		vm.checkCFP(t, i, 0)
 	}
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		a = [1, 2]
+		a.last
+		`, 2},
+		{`
+		[].last
+		`, nil},
+	}
+
+	for i, tt := range tests {
+		vm := initTestVM()
+		evaluated := vm.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		vm.checkCFP(t, i, 0)
+	}
 }
 
 func TestArrayLastMethodFail(t *testing.T) {
@@ -602,6 +787,9 @@ func TestArrayLastMethodFail(t *testing.T) {
 		{`a = [1, 2]
 		a.last("l")
 		`, "TypeError: Expect argument to be Integer. got: String", 2},
+		{`a = [1, 2]
+		a.last(-1)
+		`, "ArgumentError: Expect non-negative array size. got=-1", 2},
 	}
 
 	for i, tt := range testsFail {
@@ -668,6 +856,14 @@ func TestArrayMapMethod(t *testing.T) {
 			i + "1"
 		end
 		`, []interface{}{"11", "sss1", "qwe1"}},
+		{`
+		[].map do |i|
+			i + 1
+		end
+		`, []interface{}{}},
+		{`
+		[1, 2].map { |i| i * 10 }
+		`, []interface{}{10, 20}},
 	}
 
 	for i, tt := range tests {
@@ -752,6 +948,61 @@ func TestArrayPushMethod(t *testing.T) {
 	}
 }
 
+func TestArrayFrozen(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[1, 2].frozen?`, false},
+		{`[1, 2].freeze.frozen?`, true},
+		{`
+		a = [1, 2, 3].freeze
+		a[1] + a.last + a.length
+		`, 8},
+		{`
+		a = [3, 1, 2].freeze
+		a.sort.to_s + a.map do |i| i * 2 end.to_s
+		`, "[1, 2, 3][6, 2, 4]"},
+		{`
+		a = [1, 2].freeze
+		b = a.sort
+		b.push(3)
+		b.length
+		`, 3},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayFrozenFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1, 2].freeze.push(3)`, "FrozenError: Can't modify frozen Array: [1, 2]", 1},
+		{`[1, 2].freeze << 3`, "FrozenError: Can't modify frozen Array: [1, 2]", 1},
+		{`[1, 2].freeze.pop`, "FrozenError: Can't modify frozen Array: [1, 2]", 1},
+		{`[1, 2].freeze.shift`, "FrozenError: Can't modify frozen Array: [1, 2]", 1},
+		{`[1, 2].freeze.clear`, "FrozenError: Can't modify frozen Array: [1, 2]", 1},
+		{`[1, 2].freeze.concat([3])`, "FrozenError: Can't modify frozen Array: [1, 2]", 1},
+		{`
+		a = [1, 2].freeze
+		a[0] = 3
+		`, "FrozenError: Can't modify frozen Array: [1, 2]", 3},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestArrayReduceMethod(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -781,6 +1032,16 @@ func TestArrayReduceMethod(t *testing.T) {
 			prev + s
 		end
 		`, "Yes, this is a test!"},
+		{`[1, 2, 3].reduce(0) { |acc, x| acc + x }`, 6},
+		{`[5].reduce { |acc, x| acc + x }`, 5},
+		{`[].reduce { |acc, x| acc + x }`, nil},
+		{`[].reduce(0) { |acc, x| acc + x }`, 0},
+		{`
+		[1, 2, 3].inject(1) do |product, n|
+			product * n
+		end
+		`, 6},
+		{`["a", "b", "c"].inject { |acc, x| acc + x }`, "abc"},
 	}
 
 	for i, tt := range tests {
@@ -896,6 +1157,14 @@ func TestArraySelectMethod(t *testing.T) {
 			i == "test"
 		end
 		`, []interface{}{"test", "test"}},
+		{`
+		[].select do |i|
+			i > 3
+		end
+		`, []interface{}{}},
+		{`
+		[1, nil, "a", false, 0].select { |i| i }
+		`, []interface{}{1, "a", 0}},
 	}
 
 	for i, tt := range tests {
@@ -956,3 +1225,117 @@ func TestArrayShiftMethodFail(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestArraySortMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[3, 1, 2].sort.to_s`, "[1, 2, 3]"},
+		{`["b", "c", "a"].sort.to_s`, `["a", "b", "c"]`},
+		{`[2.5, 1, 3].sort.to_s`, "[1, 2.5, 3]"},
+		{`[].sort.to_s`, "[]"},
+		{`
+		a = [2, 1]
+		a.sort
+		a.to_s
+		`, "[2, 1]"},
+		{`
+		class Version
+		  attr_reader :number
+
+		  def initialize(number)
+		    @number = number
+		  end
+
+		  def <=>(other)
+		    @number <=> other.number
+		  end
+		end
+
+		[Version.new(3), Version.new(1), Version.new(2)].sort.map do |v|
+		  v.number
+		end.to_s
+		`, "[1, 2, 3]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySortMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1, 2].sort(1)`, "ArgumentError: Expect 0 argument. got=1", 1},
+		{`[1, "a"].sort`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`class Foo
+		end
+
+		[Foo.new, Foo.new].sort
+		`, "ArgumentError: Comparison of Foo with Foo failed", 4},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySortByMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		["ccc", "a", "bb"].sort_by do |s|
+		  s.length
+		end.to_s
+		`, `["a", "bb", "ccc"]`},
+		// Elements with equal keys keep their order
+		{`
+		[3, 1, 4, 2].sort_by do |i|
+		  i % 2
+		end.to_s
+		`, "[4, 2, 3, 1]"},
+		{`
+		count = 0
+		[3, 1, 2].sort_by do |i|
+		  count += 1
+		  i
+		end
+		count
+		`, 3},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySortByMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1, 2].sort_by`, "InternalError: Can't yield without a block", 1},
+		{`[1, 2].sort_by do |i|
+		  [i]
+		end`, "ArgumentError: Comparison of Array with Array failed", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
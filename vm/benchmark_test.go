@@ -0,0 +1,197 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/goby-lang/goby/compiler"
+	"github.com/goby-lang/goby/compiler/parser"
+)
+
+// benchmarkEval compiles the program once and runs it in a new vm in every iteration.
+// Only the execution is measured, allocations are reported as allocs/op.
+//
+// Run them with `go test ./vm -run none -bench . -benchmem`
+func benchmarkEval(b *testing.B, input string) {
+	iss, err := compiler.CompileToInstructions(input, parser.TestMode)
+
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		v := initTestVM()
+		b.StartTimer()
+
+		v.ExecInstructions(iss, getFilename())
+
+		if top := v.mainThread.stack.top(); top != nil {
+			if err, ok := top.Target.(*Error); ok {
+				b.Fatal(err.toString())
+			}
+		}
+	}
+}
+
+func BenchmarkCompile(b *testing.B) {
+	input := `
+	class Foo
+	  def initialize(x)
+	    @x = x
+	  end
+
+	  def bar(y)
+	    if y > @x
+	      [1, 2, 3].map do |i|
+	        i * y
+	      end
+	    else
+	      { a: y, b: @x }
+	    end
+	  end
+	end
+
+	Foo.new(1).bar(2)
+	`
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := compiler.CompileToInstructions(input, parser.TestMode); err != nil {
+			b.Fatal(err.Error())
+		}
+	}
+}
+
+func BenchmarkFib(b *testing.B) {
+	benchmarkEval(b, `
+	def fib(n)
+	  if n < 2
+	    n
+	  else
+	    fib(n - 1) + fib(n - 2)
+	  end
+	end
+
+	fib(15)
+	`)
+}
+
+func BenchmarkLoop(b *testing.B) {
+	benchmarkEval(b, `
+	i = 0
+	sum = 0
+	while i < 10000 do
+	  sum += i
+	  i += 1
+	end
+	sum
+	`)
+}
+
+func BenchmarkBlockYield(b *testing.B) {
+	benchmarkEval(b, `
+	sum = 0
+	1000.times do |i|
+	  sum += i
+	end
+	sum
+	`)
+}
+
+func BenchmarkMethodCall(b *testing.B) {
+	benchmarkEval(b, `
+	class Foo
+	  def bar(x)
+	    x
+	  end
+	end
+
+	f = Foo.new
+	i = 0
+	while i < 1000 do
+	  f.bar(i)
+	  i += 1
+	end
+	`)
+}
+
+func BenchmarkDeeplyInheritedMethodCall(b *testing.B) {
+	benchmarkEval(b, `
+	class A
+	  def foo
+	    1
+	  end
+	end
+
+	class B < A; end
+	class C < B; end
+	class D < C; end
+	class E < D; end
+
+	e = E.new
+	i = 0
+	while i < 1000 do
+	  e.foo
+	  i += 1
+	end
+	`)
+}
+
+func BenchmarkObjectAllocation(b *testing.B) {
+	benchmarkEval(b, `
+	class Point
+	  def initialize(x, y)
+	    @x = x
+	    @y = y
+	  end
+	end
+
+	i = 0
+	while i < 1000 do
+	  Point.new(i, i)
+	  i += 1
+	end
+	`)
+}
+
+func BenchmarkArrayMap(b *testing.B) {
+	benchmarkEval(b, `
+	a = []
+	i = 0
+	while i < 1000 do
+	  a.push(i)
+	  i += 1
+	end
+	a.map do |x|
+	  x * 2
+	end.length
+	`)
+}
+
+func BenchmarkHashAccess(b *testing.B) {
+	benchmarkEval(b, `
+	h = {}
+	i = 0
+	while i < 1000 do
+	  h[i.to_s] = i
+	  h[i.to_s]
+	  i += 1
+	end
+	`)
+}
+
+func BenchmarkStringConcatenation(b *testing.B) {
+	benchmarkEval(b, `
+	s = ""
+	i = 0
+	while i < 1000 do
+	  s = s + "a"
+	  i += 1
+	end
+	s.length
+	`)
+}
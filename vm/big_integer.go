@@ -0,0 +1,769 @@
+package vm
+
+import (
+	"math"
+	"math/big"
+)
+
+// initIntegerFromBigInt returns a BigInteger object, or an Integer object if the value fits in an Integer.
+// So an Integer and a BigInteger never represent the same number.
+func (vm *VM) initIntegerFromBigInt(value *big.Int) Object {
+	if value.IsInt64() && int64(minInt) <= value.Int64() && value.Int64() <= int64(maxInt) {
+		return vm.initIntegerObject(int(value.Int64()))
+	}
+
+	return &BigIntegerObject{
+		baseObj: &baseObj{class: vm.topLevelClass(bigIntegerClass)},
+		value:   value,
+	}
+}
+
+func (vm *VM) initBigIntegerClass(ic *RClass) *RClass {
+	bc := vm.initializeClass(bigIntegerClass, false)
+	bc.inherits(ic)
+	bc.setBuiltInMethods(builtinBigIntegerInstanceMethods(), false)
+	bc.setBuiltInMethods(builtInBigIntegerClassMethods(), true)
+	return bc
+}
+
+// BigIntegerObject represents integers that are too large to be an Integer.
+// Integer arithmetic promotes its result to a BigInteger when it overflows,
+// and a BigInteger's result becomes an Integer again once it's small enough.
+// BigInteger is a subclass of Integer and overrides all of Integer's methods, so it can be used wherever an Integer can.
+//
+// ```ruby
+// a = 10 ** 30
+// a                # => 1000000000000000000000000000000
+// a.class          # => BigInteger
+// a.is_a?(Integer) # => true
+// a / a            # => 1
+// ```
+//
+// - `BigInteger.new` is not supported.
+// - Integer literals out of Integer's range (like `10000000000000000000`) are not supported yet,
+// such values can only be created by arithmetic for now.
+// - Powers whose results would have more than 16777216 bits raise an ArgumentError.
+// - The conversions for Go values like `to_int64` raise an ArgumentError, because a BigInteger doesn't fit in them.
+type BigIntegerObject struct {
+	*baseObj
+	value *big.Int
+}
+
+func (b *BigIntegerObject) Value() interface{} {
+	return b.value
+}
+
+// Polymorphic helper functions -----------------------------------------
+func (b *BigIntegerObject) toString() string {
+	return b.value.String()
+}
+
+func (b *BigIntegerObject) toJSON() string {
+	return b.toString()
+}
+
+// equalTo returns if self represents the same number as given object, which can be an Integer, a BigInteger or a Float
+func (b *BigIntegerObject) equalTo(right Object) bool {
+	switch right := right.(type) {
+	case *IntegerObject:
+		return b.value.Cmp(right.bigValue()) == 0
+	case *BigIntegerObject:
+		return b.value.Cmp(right.value) == 0
+	case *FloatObject:
+		return b.floatValue() == right.value
+	default:
+		return false
+	}
+}
+
+// arithmeticOperation applies the operation that matches right hand side's type.
+// The result is promoted to Float when right hand side is a Float.
+func (b *BigIntegerObject) arithmeticOperation(t *thread, right Object, bigOperation func(*big.Int, *big.Int) *big.Int, floatOperation func(float64, float64) float64) Object {
+	switch right := right.(type) {
+	case *IntegerObject:
+		return t.vm.initIntegerFromBigInt(bigOperation(b.value, right.bigValue()))
+	case *BigIntegerObject:
+		return t.vm.initIntegerFromBigInt(bigOperation(b.value, right.value))
+	case *FloatObject:
+		return t.vm.initFloatObject(floatOperation(b.floatValue(), right.value))
+	default:
+		return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, numericName, right.Class().Name)
+	}
+}
+
+// numericComparison compares self with an Integer, a BigInteger or a Float and returns the result as a Boolean object.
+// Integers are compared by passing the result of Cmp and 0 to intOperation.
+func (b *BigIntegerObject) numericComparison(t *thread, right Object, intOperation func(int, int) bool, floatOperation func(float64, float64) bool) Object {
+	var result bool
+
+	switch right := right.(type) {
+	case *IntegerObject:
+		result = intOperation(b.value.Cmp(right.bigValue()), 0)
+	case *BigIntegerObject:
+		result = intOperation(b.value.Cmp(right.value), 0)
+	case *FloatObject:
+		result = floatOperation(b.floatValue(), right.value)
+	default:
+		return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, numericName, right.Class().Name)
+	}
+
+	return toBooleanObject(result)
+}
+
+// floatValue returns the nearest float64 value of self
+func (b *BigIntegerObject) floatValue() float64 {
+	f, _ := new(big.Float).SetInt(b.value).Float64()
+	return f
+}
+
+// Other helper functions ----------------------------------------------
+
+// goNumberConversions are the names of Integer's methods that convert it to a Go number type
+var goNumberConversions = []string{"to_int", "to_int8", "to_int16", "to_int32", "to_int64", "to_uint", "to_uint8", "to_uint16", "to_uint32", "to_uint64", "to_float32", "to_float64"}
+
+// isZeroInteger returns true if the object is Integer 0, BigIntegers are never 0
+func isZeroInteger(obj Object) bool {
+	i, ok := obj.(*IntegerObject)
+	return ok && i.value == 0
+}
+
+func builtInBigIntegerClassMethods() []*BuiltInMethodObject {
+	return []*BuiltInMethodObject{
+		{
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.unsupportedMethodError("#new", receiver)
+				}
+			},
+		},
+	}
+}
+
+func builtinBigIntegerInstanceMethods() []*BuiltInMethodObject {
+	methods := []*BuiltInMethodObject{
+		{
+			// Returns the sum of self and another Numeric.
+			//
+			// ```Ruby
+			// 10 ** 30 + 1 # => 1000000000000000000000000000001
+			// ```
+			// @return [Numeric]
+			Name:  "+",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Add(leftValue, rightValue)
+					}
+					floatOperation := func(leftValue float64, rightValue float64) float64 {
+						return leftValue + rightValue
+					}
+
+					return receiver.(*BigIntegerObject).arithmeticOperation(t, args[0], bigOperation, floatOperation)
+				}
+			},
+		},
+		{
+			// Divides self by another Numeric and returns the remainder, which has the same sign as self.
+			//
+			// ```Ruby
+			// 10 ** 30 % 7 # => 1
+			// ```
+			// @return [Numeric]
+			Name:  "%",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if isZeroInteger(args[0]) {
+						return t.vm.initErrorObject(ZeroDivisionError, DividedByZeroFormat)
+					}
+
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Rem(leftValue, rightValue)
+					}
+					floatOperation := func(leftValue float64, rightValue float64) float64 {
+						return math.Mod(leftValue, rightValue)
+					}
+
+					return receiver.(*BigIntegerObject).arithmeticOperation(t, args[0], bigOperation, floatOperation)
+				}
+			},
+		},
+		{
+			// Returns the subtraction of another Numeric from self.
+			//
+			// ```Ruby
+			// 10 ** 30 - 10 ** 30 # => 0
+			// ```
+			// @return [Numeric]
+			Name:  "-",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Sub(leftValue, rightValue)
+					}
+					floatOperation := func(leftValue float64, rightValue float64) float64 {
+						return leftValue - rightValue
+					}
+
+					return receiver.(*BigIntegerObject).arithmeticOperation(t, args[0], bigOperation, floatOperation)
+				}
+			},
+		},
+		{
+			// Returns the negation of self.
+			//
+			// ```Ruby
+			// -(10 ** 30) # => -1000000000000000000000000000000
+			// ```
+			// @return [BigInteger]
+			Name: "-@",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerFromBigInt(new(big.Int).Neg(receiver.(*BigIntegerObject).value))
+				}
+			},
+		},
+		{
+			// Returns self multiplying another Numeric.
+			//
+			// ```Ruby
+			// 10 ** 30 * 2 # => 2000000000000000000000000000000
+			// ```
+			// @return [Numeric]
+			Name:  "*",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Mul(leftValue, rightValue)
+					}
+					floatOperation := func(leftValue float64, rightValue float64) float64 {
+						return leftValue * rightValue
+					}
+
+					return receiver.(*BigIntegerObject).arithmeticOperation(t, args[0], bigOperation, floatOperation)
+				}
+			},
+		},
+		{
+			// Returns self squaring another Numeric. A negative exponent returns a Float.
+			// An exponent that makes the result too large to calculate raises an ArgumentError.
+			//
+			// ```Ruby
+			// (10 ** 30) ** 2 # => 1000000000000000000000000000000000000000000000000000000000000
+			// ```
+			// @return [Numeric]
+			Name:  "**",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					b := receiver.(*BigIntegerObject)
+
+					switch right := args[0].(type) {
+					case *IntegerObject:
+						if right.value < 0 {
+							return t.vm.initFloatObject(math.Pow(b.floatValue(), float64(right.value)))
+						}
+
+						if err := checkPowerSize(t, b.value, right.bigValue()); err != nil {
+							return err
+						}
+					case *BigIntegerObject:
+						if right.value.Sign() < 0 {
+							return t.vm.initFloatObject(math.Pow(b.floatValue(), right.floatValue()))
+						}
+
+						if err := checkPowerSize(t, b.value, right.value); err != nil {
+							return err
+						}
+					}
+
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Exp(leftValue, rightValue, nil)
+					}
+					floatOperation := func(leftValue float64, rightValue float64) float64 {
+						return math.Pow(leftValue, rightValue)
+					}
+
+					return b.arithmeticOperation(t, args[0], bigOperation, floatOperation)
+				}
+			},
+		},
+		{
+			// Returns self divided by another Numeric. Dividing by an Integer drops the remainder.
+			//
+			// ```Ruby
+			// 10 ** 30 / 10 ** 29 # => 10
+			// ```
+			// @return [Numeric]
+			Name:  "/",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if isZeroInteger(args[0]) {
+						return t.vm.initErrorObject(ZeroDivisionError, DividedByZeroFormat)
+					}
+
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Quo(leftValue, rightValue)
+					}
+					floatOperation := func(leftValue float64, rightValue float64) float64 {
+						return leftValue / rightValue
+					}
+
+					return receiver.(*BigIntegerObject).arithmeticOperation(t, args[0], bigOperation, floatOperation)
+				}
+			},
+		},
+		{
+			// Returns if self is larger than another Numeric.
+			//
+			// ```Ruby
+			// 10 ** 30 > 1 # => true
+			// ```
+			// @return [Boolean]
+			Name:  ">",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					intOperation := func(leftValue int, rightValue int) bool {
+						return leftValue > rightValue
+					}
+					floatOperation := func(leftValue float64, rightValue float64) bool {
+						return leftValue > rightValue
+					}
+
+					return receiver.(*BigIntegerObject).numericComparison(t, args[0], intOperation, floatOperation)
+				}
+			},
+		},
+		{
+			// Returns if self is larger than or equals to another Numeric.
+			//
+			// ```Ruby
+			// 10 ** 30 >= 10 ** 30 # => true
+			// ```
+			// @return [Boolean]
+			Name:  ">=",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					intOperation := func(leftValue int, rightValue int) bool {
+						return leftValue >= rightValue
+					}
+					floatOperation := func(leftValue float64, rightValue float64) bool {
+						return leftValue >= rightValue
+					}
+
+					return receiver.(*BigIntegerObject).numericComparison(t, args[0], intOperation, floatOperation)
+				}
+			},
+		},
+		{
+			// Returns if self is smaller than another Numeric.
+			//
+			// ```Ruby
+			// 10 ** 30 < 1 # => false
+			// ```
+			// @return [Boolean]
+			Name:  "<",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					intOperation := func(leftValue int, rightValue int) bool {
+						return leftValue < rightValue
+					}
+					floatOperation := func(leftValue float64, rightValue float64) bool {
+						return leftValue < rightValue
+					}
+
+					return receiver.(*BigIntegerObject).numericComparison(t, args[0], intOperation, floatOperation)
+				}
+			},
+		},
+		{
+			// Returns if self is smaller than or equals to another Numeric.
+			//
+			// ```Ruby
+			// 10 ** 30 <= 10 ** 31 # => true
+			// ```
+			// @return [Boolean]
+			Name:  "<=",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					intOperation := func(leftValue int, rightValue int) bool {
+						return leftValue <= rightValue
+					}
+					floatOperation := func(leftValue float64, rightValue float64) bool {
+						return leftValue <= rightValue
+					}
+
+					return receiver.(*BigIntegerObject).numericComparison(t, args[0], intOperation, floatOperation)
+				}
+			},
+		},
+		{
+			// Returns 1 if self is larger than the incoming Numeric, -1 if smaller. Otherwise 0.
+			//
+			// ```Ruby
+			// 10 ** 30 <=> 1        # => 1
+			// 10 ** 30 <=> 10 ** 31 # => -1
+			// ```
+			// @return [Integer]
+			Name:  "<=>",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					b := receiver.(*BigIntegerObject)
+
+					switch right := args[0].(type) {
+					case *IntegerObject:
+						return t.vm.initIntegerObject(b.value.Cmp(right.bigValue()))
+					case *BigIntegerObject:
+						return t.vm.initIntegerObject(b.value.Cmp(right.value))
+					case *FloatObject:
+						leftValue := b.floatValue()
+
+						if leftValue < right.value {
+							return t.vm.initIntegerObject(-1)
+						}
+						if leftValue > right.value {
+							return t.vm.initIntegerObject(1)
+						}
+
+						return t.vm.initIntegerObject(0)
+					default:
+						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, numericName, args[0].Class().Name)
+					}
+				}
+			},
+		},
+		{
+			// Returns if self is equal to another Numeric.
+			//
+			// ```Ruby
+			// 10 ** 30 == 10 ** 30 # => true
+			// 10 ** 30 == 1        # => false
+			// ```
+			// @return [Boolean]
+			Name:  "==",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*BigIntegerObject).equalTo(args[0]))
+				}
+			},
+		},
+		{
+			// Returns if self is not equal to another Numeric.
+			//
+			// ```Ruby
+			// 10 ** 30 != 1 # => true
+			// ```
+			// @return [Boolean]
+			Name:  "!=",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(!receiver.(*BigIntegerObject).equalTo(args[0]))
+				}
+			},
+		},
+		{
+			// Returns the absolute value of self.
+			//
+			// ```Ruby
+			// (-(10 ** 30)).abs # => 1000000000000000000000000000000
+			// ```
+			// @return [BigInteger]
+			Name: "abs",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					return t.vm.initIntegerFromBigInt(new(big.Int).Abs(receiver.(*BigIntegerObject).value))
+				}
+			},
+		},
+		{
+			// Returns self, because a BigInteger has no decimal part to round up.
+			//
+			// ```Ruby
+			// (10 ** 30).ceil # => 1000000000000000000000000000000
+			// ```
+			// @return [BigInteger]
+			Name: "ceil",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					return receiver
+				}
+			},
+		},
+		{
+			// Returns self, because a BigInteger has no decimal part to round down.
+			//
+			// ```Ruby
+			// (10 ** 30).floor # => 1000000000000000000000000000000
+			// ```
+			// @return [BigInteger]
+			Name: "floor",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					return receiver
+				}
+			},
+		},
+		{
+			// Returns the greatest common divisor of self and another Integer, which is never negative.
+			//
+			// ```Ruby
+			// (10 ** 30).gcd(15)      # => 5
+			// (10 ** 30).gcd(6 ** 30) # => 1073741824
+			// ```
+			// @param other [Integer]
+			// @return [Integer]
+			Name:  "gcd",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					b := receiver.(*BigIntegerObject)
+
+					switch right := args[0].(type) {
+					case *IntegerObject:
+						return t.vm.initIntegerFromBigInt(new(big.Int).GCD(nil, nil, b.value, right.bigValue()))
+					case *BigIntegerObject:
+						return t.vm.initIntegerFromBigInt(new(big.Int).GCD(nil, nil, b.value, right.value))
+					default:
+						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
+					}
+				}
+			},
+		},
+		{
+			// Returns if self is even.
+			//
+			// ```Ruby
+			// (10 ** 30).even? # => true
+			// ```
+			// @return [Boolean]
+			Name: "even?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					return toBooleanObject(receiver.(*BigIntegerObject).value.Bit(0) == 0)
+				}
+			},
+		},
+		{
+			// Returns if self is odd.
+			//
+			// ```Ruby
+			// (10 ** 30 + 1).odd? # => true
+			// ```
+			// @return [Boolean]
+			Name: "odd?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					return toBooleanObject(receiver.(*BigIntegerObject).value.Bit(0) == 1)
+				}
+			},
+		},
+		{
+			// Returns self + 1.
+			//
+			// ```Ruby
+			// (2 ** 64).next # => 18446744073709551617
+			// ```
+			// @return [Integer]
+			Name: "next",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerFromBigInt(new(big.Int).Add(receiver.(*BigIntegerObject).value, big.NewInt(1)))
+				}
+			},
+		},
+		{
+			// Returns self - 1.
+			//
+			// ```Ruby
+			// (2 ** 64).pred # => 18446744073709551615
+			// ```
+			// @return [Integer]
+			Name: "pred",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerFromBigInt(new(big.Int).Sub(receiver.(*BigIntegerObject).value, big.NewInt(1)))
+				}
+			},
+		},
+		{
+			// Rounds self to the given decimal digits like `Integer#round`, which defaults to 0.
+			//
+			// ```Ruby
+			// (10 ** 30 + 51).round(-2) # => 1000000000000000000000000000100
+			// ```
+			// @param digits [Integer]
+			// @return [Integer]
+			Name: "round",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					digits, err := roundDigits(t, args)
+
+					if err != nil {
+						return err
+					}
+
+					if digits >= 0 {
+						return receiver
+					}
+
+					return roundInteger(t, receiver.(*BigIntegerObject).value, digits)
+				}
+			},
+		},
+		{
+			// Yields a block a number of times equals to self, passing the index from 0 to self - 1. Returns self.
+			// Without a block it returns an Enumerator of the indexes, which is infinite because its size is too large.
+			//
+			// ```Ruby
+			// (10 ** 30).times do |i|
+			//   break i if i == 3
+			// end # => 3
+			// ```
+			Name: "times",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					b := receiver.(*BigIntegerObject)
+
+					if b.value.Sign() < 0 {
+						return t.vm.initErrorObject(InternalError, "Expect integer greater than or equal 0. got: %s", b.toString())
+					}
+
+					if blockFrame == nil {
+						return t.vm.initEnumeratorObject(b, "times", -1, func(i int) Object {
+							return t.vm.initIntegerObject(i)
+						})
+					}
+
+					one := big.NewInt(1)
+
+					for i := new(big.Int); i.Cmp(b.value) < 0; i = new(big.Int).Add(i, one) {
+						t.builtInMethodYield(blockFrame, t.vm.initIntegerFromBigInt(i))
+					}
+
+					return b
+				}
+			},
+		},
+		{
+			// Returns a Float of the nearest value of self.
+			//
+			// ```Ruby
+			// (10 ** 30).to_f # => 1.0e+30
+			// ```
+			// @return [Float]
+			Name: "to_f",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					return t.vm.initFloatObject(receiver.(*BigIntegerObject).floatValue())
+				}
+			},
+		},
+		{
+			// Returns self.
+			//
+			// ```Ruby
+			// (10 ** 30).to_i # => 1000000000000000000000000000000
+			// ```
+			// @return [BigInteger]
+			Name: "to_i",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					return receiver
+				}
+			},
+		},
+		{
+			// Returns a String of self's digits in the given radix, which defaults to 10.
+			//
+			// ```Ruby
+			// (10 ** 30).to_s    # => "1000000000000000000000000000000"
+			// (2 ** 64).to_s(16) # => "10000000000000000"
+			// ```
+			// @param radix [Integer]
+			// @return [String]
+			Name: "to_s",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					radix, err := radixArgument(t, args)
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initStringObject(receiver.(*BigIntegerObject).value.Text(radix))
+				}
+			},
+		},
+		{
+			// Returns false because a BigInteger is never 0.
+			//
+			// ```Ruby
+			// (10 ** 30).zero? # => false
+			// ```
+			// @return [Boolean]
+			Name: "zero?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					return FALSE
+				}
+			},
+		},
+	}
+
+	// A BigInteger doesn't fit in Go's number types, so the conversions for Go values raise an ArgumentError
+	for _, name := range goNumberConversions {
+		methods = append(methods, &BuiltInMethodObject{
+			Name: name,
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initErrorObject(ArgumentError, "Can't convert %s to a Go number", receiver.toString())
+				}
+			},
+		})
+	}
+
+	return methods
+}
@@ -0,0 +1,228 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestBigIntegerClassSuperclass(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`BigInteger.class.name`, "Class"},
+		{`BigInteger.superclass.name`, "Integer"},
+		{`(2 ** 64).is_a?(Integer).to_s`, "true"},
+		{`(2 ** 64).is_a?(BigInteger).to_s`, "true"},
+		{`(2 ** 63 - 1).is_a?(BigInteger).to_s`, "false"},
+		{`(10 ** 30).class.name`, "BigInteger"},
+		{`(10 ** 18).class.name`, "Integer"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestIntegerOverflowPromotion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`(10 ** 30).to_s`, "1000000000000000000000000000000"},
+		{`(9223372036854775807 + 1).to_s`, "9223372036854775808"},
+		{`(-9223372036854775807 - 2).to_s`, "-9223372036854775809"},
+		{`(3037000500 * 3037000500).to_s`, "9223372037000250000"},
+		{`(2 ** 64).to_s`, "18446744073709551616"},
+		{`(-(2 ** 62) * 2 / -1).to_s`, "9223372036854775808"},
+		{`
+		a = -9223372036854775807 - 1
+		(-a).to_s
+		`, "9223372036854775808"},
+		// The results fit in an Integer again
+		{`3 ** 39`, 4052555153018976267},
+		{`2 ** 62 * 2 / 2`, 4611686018427387904},
+		{`(10 ** 30) / (10 ** 29)`, 10},
+		{`(10 ** 30) - (10 ** 30)`, 0},
+		{`(10 ** 30) % 7`, 1},
+		{`(9223372036854775807 + 1) - 1`, 9223372036854775807},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestBigIntegerArithmeticOperation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`(10 ** 30 + 1).to_s`, "1000000000000000000000000000001"},
+		{`(1 + 10 ** 30).to_s`, "1000000000000000000000000000001"},
+		{`(10 ** 30 - 1).to_s`, "999999999999999999999999999999"},
+		{`(10 ** 30 * 10 ** 30).to_s`, "1000000000000000000000000000000000000000000000000000000000000"},
+		{`((10 ** 30) ** 2).to_s`, "1000000000000000000000000000000000000000000000000000000000000"},
+		{`(-(10 ** 30)).to_s`, "-1000000000000000000000000000000"},
+		{`(-(10 ** 30) % 7).to_s`, "-1"},
+		{`5 / 10 ** 30`, 0},
+		{`10 ** 30 * 2.0`, 2e30},
+		{`2.0 * 10 ** 30`, 2e30},
+		{`2 ** -(10 ** 30)`, 0.0},
+		{`(10 ** 30).to_f`, 1e30},
+		{`(10 ** 30).to_i.to_s`, "1000000000000000000000000000000"},
+		{`(10 ** 30).even?`, true},
+		{`(10 ** 30).odd?`, false},
+		{`(10 ** 30).zero?`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestBigIntegerArithmeticOperationFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`10 ** 30 / 0`, "ZeroDivisionError: Divided by 0", 1},
+		{`10 ** 30 % 0`, "ZeroDivisionError: Divided by 0", 1},
+		{`10 ** 30 + "1"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`10 ** 30 > nil`, "TypeError: Expect argument to be Numeric. got: Null", 1},
+		{`BigInteger.new`, "UnsupportedMethodError: Unsupported Method #new for BigInteger", 1},
+		{`(10 ** 30) ** 100000000000`, "ArgumentError: Exponent is too large. got: 100000000000", 1},
+		{`(10 ** 30) ** (10 ** 30)`, "ArgumentError: Exponent is too large. got: 1000000000000000000000000000000", 1},
+		{`(10 ** 30).gcd("1")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`(10 ** 30).to_s(1)`, "ArgumentError: Expect radix to be between 2 and 36. got: 1", 1},
+		{`(10 ** 30).round("1")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`(-(10 ** 30)).times do end`, "InternalError: Expect integer greater than or equal 0. got: -1000000000000000000000000000000", 1},
+		{`(2 ** 64).to_int64`, "ArgumentError: Can't convert 18446744073709551616 to a Go number", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestBigIntegerMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		// Values on both sides of Integer's bounds
+		{`(2 ** 63 - 1).abs.to_s`, "9223372036854775807"},
+		{`(-(2 ** 63)).abs.to_s`, "9223372036854775808"},
+		{`(-(2 ** 64)).abs.to_s`, "18446744073709551616"},
+		{`(-(2 ** 64)).abs.class.name`, "BigInteger"},
+		{`(2 ** 64).ceil.to_s`, "18446744073709551616"},
+		{`(2 ** 64).floor.to_s`, "18446744073709551616"},
+		{`(2 ** 63 - 1).next.to_s`, "9223372036854775808"},
+		{`(2 ** 63).pred.to_s`, "9223372036854775807"},
+		{`(2 ** 63).pred.class.name`, "Integer"},
+		{`(-(2 ** 63)).pred.to_s`, "-9223372036854775809"},
+		{`(-(2 ** 63) - 1).next.class.name`, "Integer"},
+		{`(2 ** 64).to_s(2)`, "10000000000000000000000000000000000000000000000000000000000000000"},
+		{`(2 ** 64).to_s(16)`, "10000000000000000"},
+		{`(-(2 ** 64)).to_s(16)`, "-10000000000000000"},
+		{`(2 ** 63 - 1).to_s(16)`, "7fffffffffffffff"},
+		{`(10 ** 30).gcd(15)`, 5},
+		{`(10 ** 30).gcd(-15)`, 5},
+		{`(10 ** 30).gcd(0).to_s`, "1000000000000000000000000000000"},
+		{`(10 ** 30).gcd(6 ** 30)`, 1073741824},
+		{`12.gcd(6 ** 30)`, 12},
+		{`(2 ** 64).even?`, true},
+		{`(2 ** 64 + 1).odd?`, true},
+		{`(10 ** 30).round.to_s`, "1000000000000000000000000000000"},
+		{`(10 ** 30 + 51).round(-2).to_s`, "1000000000000000000000000000100"},
+		{`(-(10 ** 30) - 50).round(-2).to_s`, "-1000000000000000000000000000100"},
+		{`(10 ** 30).round(-31)`, 0},
+		{`(5 * 10 ** 30).round(-31).to_s`, "10000000000000000000000000000000"},
+		{`(10 ** 30).round(-1000000000000)`, 0},
+		{`
+		a = []
+		r = (10 ** 30).times do |i|
+		  break i if i == 3
+		  a.push(i)
+		end
+		a.to_s + r.to_s
+		`, "[0, 1, 2]3"},
+		{`
+		e = (10 ** 30).times
+		e.next
+		e.next.to_s + e.size.to_s
+		`, "1"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+// BigInteger is a subclass of Integer, so an Integer method it doesn't override would get a BigInteger receiver it can't handle
+func TestBigIntegerOverridesIntegerMethods(t *testing.T) {
+	v := initTestVM()
+	bc := v.topLevelClass(bigIntegerClass)
+
+	for _, m := range builtinIntegerInstanceMethods() {
+		if _, ok := bc.Methods.get(m.Name); !ok {
+			t.Errorf("expect BigInteger to override Integer#%s", m.Name)
+		}
+	}
+}
+
+func TestBigIntegerComparison(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`10 ** 30 > 1`, true},
+		{`1 > 10 ** 30`, false},
+		{`-(10 ** 30) < 1`, true},
+		{`1 < -(10 ** 30)`, false},
+		{`10 ** 30 >= 10 ** 30`, true},
+		{`10 ** 30 <= 10 ** 31`, true},
+		{`10 ** 31 <= 10 ** 30`, false},
+		{`1 <= 10 ** 30`, true},
+		{`10 ** 30 > 1.5`, true},
+		{`1.5 > 10 ** 30`, false},
+		{`10 ** 30 == 10 ** 30`, true},
+		{`10 ** 30 == 1`, false},
+		{`1 == 10 ** 30`, false},
+		{`10 ** 30 == (10 ** 30).to_f`, true},
+		{`(10 ** 30).to_f == 10 ** 30`, true},
+		{`10 ** 30 != 10 ** 30`, false},
+		{`10 ** 30 != 1`, true},
+		{`10 ** 30 <=> 1`, 1},
+		{`1 <=> 10 ** 30`, -1},
+		{`10 ** 30 <=> 10 ** 31`, -1},
+		{`10 ** 30 <=> 10 ** 30`, 0},
+		{`10 ** 30 <=> 1.5`, 1},
+		{`1.5 <=> 10 ** 30`, -1},
+		{`[10 ** 30, 1, 10 ** 20].sort.to_s`, "[1, 100000000000000000000, 1000000000000000000000000000000]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
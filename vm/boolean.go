@@ -16,14 +16,24 @@ func (vm *VM) initBoolClass() *RClass {
 	b.setBuiltInMethods(builtinBooleanInstanceMethods(), false)
 	b.setBuiltInMethods(builtInBooleanClassMethods(), true)
 
-	TRUE = &BooleanObject{value: true, baseObj: &baseObj{class: b}}
-	FALSE = &BooleanObject{value: false, baseObj: &baseObj{class: b}}
+	TRUE = &BooleanObject{value: true, baseObj: &baseObj{class: b, frozen: true}}
+	FALSE = &BooleanObject{value: false, baseObj: &baseObj{class: b, frozen: true}}
 
 	return b
 }
 
+// toBooleanObject returns the shared TRUE or FALSE object, so every boolean value is one of these two objects
+func toBooleanObject(value bool) *BooleanObject {
+	if value {
+		return TRUE
+	}
+
+	return FALSE
+}
+
 // BooleanObject represents boolean object in goby.
 // It includes `true` and `FALSE` which represents logically true and false value.
+// Both are shared by the whole program, so they're frozen and setting their instance variables raises a FrozenError.
 // - `Boolean.new` is not supported.
 type BooleanObject struct {
 	*baseObj
@@ -70,15 +80,12 @@ func builtinBooleanInstanceMethods() []*BuiltInMethodObject {
 			// 100 == 33 # => false
 			// ```
 			// @return [Boolean]
-			Name: "==",
+			Name:  "==",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
-					if receiver == args[0] {
-						return TRUE
-					}
-
-					return FALSE
+					return toBooleanObject(receiver == args[0])
 				}
 			},
 		},
@@ -90,14 +97,12 @@ func builtinBooleanInstanceMethods() []*BuiltInMethodObject {
 			// 45 != 45 # => false
 			// ```
 			// @return [Boolean]
-			Name: "!=",
+			Name:  "!=",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
-					if receiver != args[0] {
-						return TRUE
-					}
-					return FALSE
+					return toBooleanObject(receiver != args[0])
 				}
 			},
 		},
@@ -131,7 +136,8 @@ func builtinBooleanInstanceMethods() []*BuiltInMethodObject {
 			// 3 > 2 && 5 > 10 # => false
 			// ```
 			// @return [Boolean]
-			Name: "&&",
+			Name:  "&&",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
@@ -145,11 +151,7 @@ func builtinBooleanInstanceMethods() []*BuiltInMethodObject {
 
 					rightValue := right.value
 
-					if leftValue && rightValue {
-						return TRUE
-					}
-
-					return FALSE
+					return toBooleanObject(leftValue && rightValue)
 				}
 			},
 		},
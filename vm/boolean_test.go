@@ -108,6 +108,52 @@ func TestBooleanLogicalExpression(t *testing.T) {
 	}
 }
 
+func TestBooleanLogicalShortCircuit(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`false && foo`, false},
+		{`nil && foo`, nil},
+		{`true || foo`, true},
+		{`1 || foo`, 1},
+		{`1 && 2`, 2},
+		{`"a" && nil`, nil},
+		{`nil || "b"`, "b"},
+		{`false || nil`, nil},
+		{`
+		a = 0
+		false && (a = 1)
+		a
+		`, 0},
+		{`
+		a = 0
+		true || (a = 1)
+		a
+		`, 0},
+		{`
+		a = 0
+		nil || (a = 1)
+		a
+		`, 1},
+		{`
+		def foo
+		  10
+		end
+
+		nil || foo
+		`, 10},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestBooleanAssignmentByOperation(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -155,3 +201,47 @@ func TestInitializeBoolean(t *testing.T) {
 		t.Errorf("expected 'false'. got=%t", FALSE.value)
 	}
 }
+
+func TestBooleanResultsAreSharedObjects(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`1 < 2`, true},
+		{`1 == 2`, false},
+		{`1.5 >= 1.5`, true},
+		{`"a" == "a"`, true},
+		{`"a" != "a"`, false},
+		{`:a == :a`, true},
+		{`[].empty?`, true},
+		{`{ a: 1 }.empty?`, false},
+		{`2.even?`, true},
+		{`!nil`, true},
+		{`(1..2) == (1..2)`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+
+		// TRUE and FALSE are reassigned when a vm is initialized
+		if evaluated != toBooleanObject(tt.expected) {
+			t.Fatalf("At case %d expect result to be the shared %t object. got: %v", i, tt.expected, evaluated)
+		}
+	}
+}
+
+func TestBooleanFrozenFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`true.instance_variable_set("@x", 1)`, "FrozenError: Can't modify frozen Boolean: true", 1},
+		{`(1 > 2).instance_variable_set("@x", 1)`, "FrozenError: Can't modify frozen Boolean: false", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -1,6 +1,10 @@
 package vm
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/goby-lang/goby/compiler/bytecode"
+)
 
 type callFrameStack struct {
 	callFrames []*callFrame
@@ -19,9 +23,23 @@ type callFrame struct {
 	lPr        int
 	isBlock    bool
 	blockFrame *callFrame
+	// method is the goby method this frame is executing, it's nil for blocks and other frames
+	method *MethodObject
+	// args are the arguments given to the method call, bare `super` forwards them to super method
+	args []Object
+	// rescueHandlers are registered by `begin` expressions, the last one handles the error raised in this frame
+	rescueHandlers []*rescueHandler
 	sync.RWMutex
 }
 
+// rescueHandler records where to continue when an error is rescued
+type rescueHandler struct {
+	// pc is the beginning of the rescue block
+	pc int
+	// sp is the stack pointer when the handler is registered
+	sp int
+}
+
 // We use lock on every local variable retrieval and insertion.
 // The main scenario is when multiple threads want to access local variables outside it's block
 // Since they share same block frame, they will all access to that frame's locals.
@@ -35,7 +53,7 @@ func (cf *callFrame) getLCL(index, depth int) *Pointer {
 		return cf.locals[index]
 	}
 
-	return cf.blockFrame.ep.getLCL(index, depth-1)
+	return cf.ep.getLCL(index, depth-1)
 }
 
 func (cf *callFrame) insertLCL(index, depth int, value Object) {
@@ -59,6 +77,43 @@ func (cf *callFrame) insertLCL(index, depth int, value Object) {
 	}
 }
 
+// methodFrame returns the frame of the method which current frame belongs to, blocks are traced back via their ep
+func (cf *callFrame) methodFrame() *callFrame {
+	for f := cf; f != nil; f = f.ep {
+		if f.method != nil {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// isTailCall returns true if the frame calls its own method on the same receiver and returns the result directly,
+// which means the frame can be replaced by the callee's frame. Frames with rescue handlers are never replaced.
+func (cf *callFrame) isTailCall(receiver Object, method *MethodObject) bool {
+	if cf.method != method || cf.self != receiver || len(cf.rescueHandlers) > 0 {
+		return false
+	}
+
+	instructions := cf.instructionSet.instructions
+
+	// The value of `if` or `case` is returned through the jumps to the end of method
+	for pc, jumps := cf.pc, 0; pc < len(instructions) && jumps < len(instructions); jumps++ {
+		i := instructions[pc]
+
+		switch i.action.name {
+		case bytecode.Leave:
+			return true
+		case bytecode.Jump:
+			pc = i.Params[0].(int)
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
 func (cf *callFrame) storeConstant(constName string, constant interface{}) *Pointer {
 	var ptr *Pointer
 
@@ -85,17 +140,29 @@ func (cf *callFrame) storeConstant(constName string, constant interface{}) *Poin
 }
 
 func (cf *callFrame) lookupConstant(constName string) *Pointer {
-	var c *Pointer
+	if owner := cf.methodOwner(); owner != nil {
+		return owner.lookupConstant(constName, true)
+	}
 
 	switch scope := cf.self.(type) {
 	case *RClass:
-		c = scope.lookupConstant(constName, true)
+		return scope.lookupConstant(constName, true)
 	default:
-		scopeClass := scope.Class()
-		c = scopeClass.lookupConstant(constName, true)
+		return scope.Class().lookupConstant(constName, true)
+	}
+}
+
+// methodOwner returns the class that defines the method this frame (or its block) belongs to,
+// so constants are looked up from where the method is defined instead of self's class.
+// It returns nil outside methods and for singleton methods, whose constants are looked up from self.
+func (cf *callFrame) methodOwner() *RClass {
+	frame := cf.methodFrame()
+
+	if frame == nil || frame.method.owner == nil || frame.method.owner.isSingleton {
+		return nil
 	}
 
-	return c
+	return frame.method.owner
 }
 
 func (cfs *callFrameStack) push(cf *callFrame) {
@@ -88,7 +88,8 @@ func builtinChannelInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			Name: "deliver",
+			Name:  "deliver",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					id := t.vm.channelObjectMap.storeObj(args[0])
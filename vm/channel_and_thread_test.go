@@ -159,3 +159,32 @@ func TestObjectDeliveryBetweenThread(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestBreakInThread(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		c = Channel.new
+
+		thread do
+		  begin
+		    break 3
+		  rescue => e
+		    c.deliver(e.class.name)
+		  end
+		end
+
+		c.receive
+		`, "InternalError"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -2,26 +2,36 @@ package vm
 
 import (
 	"fmt"
+	"hash/fnv"
 	"io/ioutil"
 	"path"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const (
-	objectClass   = "Object"
-	classClass    = "Class"
-	integerClass  = "Integer"
-	stringClass   = "String"
-	arrayClass    = "Array"
-	hashClass     = "Hash"
-	booleanClass  = "Boolean"
-	nullClass     = "Null"
-	channelClass  = "Channel"
-	rangeClass    = "Range"
-	methodClass   = "method"
-	pluginClass   = "Plugin"
-	goObjectClass = "GoObject"
+	objectClass     = "Object"
+	classClass      = "Class"
+	integerClass    = "Integer"
+	bigIntegerClass = "BigInteger"
+	floatClass      = "Float"
+	stringClass     = "String"
+	symbolClass     = "Symbol"
+	arrayClass      = "Array"
+	hashClass       = "Hash"
+	booleanClass    = "Boolean"
+	nullClass       = "Null"
+	channelClass    = "Channel"
+	rangeClass      = "Range"
+	enumeratorClass = "Enumerator"
+	methodClass     = "method"
+	pluginClass     = "Plugin"
+	goObjectClass   = "GoObject"
 )
 
 // initializeClass is a common function for vm, which initializes and returns
@@ -127,9 +137,24 @@ type RClass struct {
 	isModule    bool
 	constants   map[string]*Pointer
 	scope       *RClass
+	// classVariables holds class variables like `@@count`, it's initialized when the first class variable is set
+	classVariables *environment
+	// methodCache caches results of lookupMethod, it's only valid when methodCacheSerial equals to methodSerial
+	methodCache       map[string]Object
+	methodCacheSerial uint64
+	methodCacheMutex  sync.RWMutex
 	*baseObj
 }
 
+// methodSerial is increased whenever any method is defined or any class's ancestors are changed.
+// So every class's method cache is invalidated together, which keeps the cache correct even if a method is
+// redefined in one of the class's ancestors.
+var methodSerial uint64
+
+func invalidateMethodCache() {
+	atomic.AddUint64(&methodSerial, 1)
+}
+
 // ReturnName returns the name of the class
 func (c *RClass) ReturnName() string {
 	return c.Name
@@ -148,6 +173,8 @@ func (c *RClass) toJSON() string {
 
 // Common internal helper functions -------------------------------------
 func (c *RClass) inherits(sc *RClass) {
+	defer invalidateMethodCache()
+
 	c.superClass = sc
 	c.pseudoSuperClass = sc
 	c.singletonClass.superClass = sc.singletonClass
@@ -156,16 +183,22 @@ func (c *RClass) inherits(sc *RClass) {
 
 func (c *RClass) setBuiltInMethods(methodList []*BuiltInMethodObject, classMethods bool) {
 	for _, m := range methodList {
-		c.Methods.set(m.Name, m)
+		c.setMethod(m.Name, m)
 	}
 
 	if classMethods {
 		for _, m := range methodList {
-			c.singletonClass.Methods.set(m.Name, m)
+			c.singletonClass.setMethod(m.Name, m)
 		}
 	}
 }
 
+// setMethod defines a method in the class and invalidates method cache
+func (c *RClass) setMethod(methodName string, method Object) {
+	c.Methods.set(methodName, method)
+	invalidateMethodCache()
+}
+
 func (c *RClass) findMethod(methodName string) (method Object) {
 	if c.isSingleton {
 		method = c.superClass.lookupMethod(methodName)
@@ -177,6 +210,31 @@ func (c *RClass) findMethod(methodName string) (method Object) {
 }
 
 func (c *RClass) lookupMethod(methodName string) Object {
+	serial := atomic.LoadUint64(&methodSerial)
+
+	c.methodCacheMutex.RLock()
+	method, ok := c.methodCache[methodName]
+	cached := ok && c.methodCacheSerial == serial
+	c.methodCacheMutex.RUnlock()
+
+	if cached {
+		return method
+	}
+
+	method = c.lookupMethodWithoutCache(methodName)
+
+	c.methodCacheMutex.Lock()
+	if c.methodCache == nil || c.methodCacheSerial != serial {
+		c.methodCache = map[string]Object{}
+		c.methodCacheSerial = serial
+	}
+	c.methodCache[methodName] = method
+	c.methodCacheMutex.Unlock()
+
+	return method
+}
+
+func (c *RClass) lookupMethodWithoutCache(methodName string) Object {
 	method, ok := c.Methods.get(methodName)
 
 	if !ok {
@@ -194,22 +252,108 @@ func (c *RClass) lookupMethod(methodName string) Object {
 	return method
 }
 
+// methodNames returns the names of methods defined in the class in alphabetical order.
+// When inherited is true, it also collects the names from superclasses the same way lookupMethod walks them.
+func (c *RClass) methodNames(inherited bool) []string {
+	set := map[string]bool{}
+
+	for class := c; class != nil; class = class.superClass {
+		for name := range class.Methods.store {
+			set[name] = true
+		}
+
+		if !inherited || class.superClass == class || class.Name == classClass {
+			break
+		}
+	}
+
+	names := []string{}
+
+	for name := range set {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// lookupConstant searches the constant in current class first, then the classes and modules it's defined in
+// when findInScope is true, and then its superclasses. Top-level constants are found at last in Object.
 func (c *RClass) lookupConstant(constName string, findInScope bool) *Pointer {
-	constant, ok := c.constants[constName]
+	if constant, ok := c.constants[constName]; ok {
+		return constant
+	}
 
-	if !ok {
-		if findInScope && c.scope != nil {
-			return c.scope.lookupConstant(constName, true)
+	if findInScope {
+		for scope := c.scope; scope != nil; scope = scope.scope {
+			if constant, ok := scope.constants[constName]; ok {
+				return constant
+			}
+		}
+	}
+
+	for class := c; class.superClass != nil && class.superClass != class && class.Name != objectClass; {
+		class = class.superClass
+
+		if constant, ok := class.constants[constName]; ok {
+			return constant
 		}
+	}
+
+	return nil
+}
 
-		if c.superClass != nil && c.Name != objectClass {
-			return c.superClass.lookupConstant(constName, false)
+// lookupClassVariable returns the class that holds the class variable, it searches from current class to its superclasses.
+func (c *RClass) lookupClassVariable(name string) (*RClass, bool) {
+	for class := c; class != nil; class = class.superClass {
+		if class.classVariables != nil {
+			if _, ok := class.classVariables.get(name); ok {
+				return class, true
+			}
 		}
 
-		return nil
+		if class.Name == objectClass {
+			break
+		}
+	}
+
+	return nil, false
+}
+
+func (c *RClass) classVariableGet(name string) (Object, bool) {
+	class, ok := c.lookupClassVariable(name)
+
+	if !ok {
+		return NULL, false
+	}
+
+	return class.classVariables.get(name)
+}
+
+// classVariableSet updates the class variable if current class or its superclasses already have it,
+// otherwise the variable is defined in current class.
+func (c *RClass) classVariableSet(name string, value Object) Object {
+	class, ok := c.lookupClassVariable(name)
+
+	if !ok {
+		class = c
+
+		if class.classVariables == nil {
+			class.classVariables = newEnvironment()
+		}
+	}
+
+	return class.classVariables.set(name, value)
+}
+
+// classOf returns the class for looking up class variables and defining constants, which is self in class body and class methods,
+// or self's class in instance methods (Object at top level).
+func classOf(self Object) *RClass {
+	if c, ok := self.(*RClass); ok {
+		return c
 	}
 
-	return constant
+	return self.Class()
 }
 
 func (c *RClass) setClassConstant(constant *RClass) {
@@ -252,14 +396,9 @@ func (c *RClass) initializeInstance() *RObject {
 func (c *RClass) setAttrWriter(args interface{}) {
 
 	switch args := args.(type) {
-	case []Object:
-		for _, attr := range args {
-			attrName := attr.(*StringObject).value
-			c.Methods.set(attrName+"=", generateAttrWriteMethod(attrName))
-		}
 	case []string:
 		for _, attrName := range args {
-			c.Methods.set(attrName+"=", generateAttrWriteMethod(attrName))
+			c.setMethod(attrName+"=", generateAttrWriteMethod(attrName))
 		}
 	}
 
@@ -267,17 +406,12 @@ func (c *RClass) setAttrWriter(args interface{}) {
 
 func (c *RClass) setAttrReader(args interface{}) {
 	switch args := args.(type) {
-	case []Object:
-		for _, attr := range args {
-			attrName := attr.(*StringObject).value
-			c.Methods.set(attrName, generateAttrReadMethod(attrName))
-		}
 	case []string:
 		for _, attrName := range args {
-			c.Methods.set(attrName, generateAttrReadMethod(attrName))
+			c.setMethod(attrName, generateAttrReadMethod(attrName))
 		}
 	case string:
-		c.Methods.set(args, generateAttrReadMethod(args))
+		c.setMethod(args, generateAttrReadMethod(args))
 	}
 
 }
@@ -288,11 +422,45 @@ func (c *RClass) setAttrAccessor(args interface{}) {
 }
 
 // Other helper functions -----------------------------------------------
+
+// attrNames converts given attribute names into strings, it returns a TypeError if any of them isn't a symbol or string
+func attrNames(t *thread, args []Object) ([]string, *Error) {
+	names := []string{}
+
+	for _, arg := range args {
+		name, err := stringOrSymbolValue(t, arg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// stringOrSymbolValue returns the value of a String or a Symbol, it returns a TypeError for other objects
+func stringOrSymbolValue(t *thread, arg Object) (string, *Error) {
+	switch arg := arg.(type) {
+	case *StringObject:
+		return arg.value, nil
+	case *SymbolObject:
+		return arg.value, nil
+	default:
+		return "", t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass+" or "+symbolClass, arg.Class().Name)
+	}
+}
+
 func generateAttrWriteMethod(attrName string) *BuiltInMethodObject {
 	return &BuiltInMethodObject{
 		Name: attrName + "=",
 		Fn: func(receiver Object) builtinMethodBody {
 			return func(t *thread, args []Object, blockFrame *callFrame) Object {
+				if receiver.isFrozen() {
+					return t.frozenError(receiver)
+				}
+
 				v := receiver.instanceVariableSet("@"+attrName, args[0])
 				return v
 			}
@@ -346,23 +514,30 @@ func builtinCommonInstanceMethods() []*BuiltInMethodObject {
 			// # Array will concern about the order of the elements
 			// [1, 2, 3] == [1, 2, 3] # => true
 			// [1, 2, 3] == [3, 2, 1] # => false
+			//
+			// # Instances of user-defined classes are only equal to themselves, unless the class overrides `==`
+			// Foo.new == Foo.new # => false
 			// ```
 			//
 			// @return [@boolean]
-			Name: "==",
+			Name:  "==",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					// Comparing instances' content could also recurse forever if they reference each other
+					if _, ok := receiver.(*RObject); ok {
+						return toBooleanObject(receiver == args[0])
+					}
+
 					className := receiver.Class().Name
 					compareClassName := args[0].Class().Name
 
-					if className == compareClassName && reflect.DeepEqual(receiver, args[0]) {
-						return TRUE
-					}
-					return FALSE
+					return toBooleanObject(className == compareClassName && reflect.DeepEqual(receiver, args[0]))
 				}
 			},
 		}, {
-			// General method for comparing inequalty of the objects
+			// General method for comparing inequalty of the objects, which negates the result of receiver's `==` method.
+			// So a class that overrides `==` gets a consistent `!=`.
 			//
 			// ```ruby
 			// 123 != 123   # => false
@@ -385,16 +560,140 @@ func builtinCommonInstanceMethods() []*BuiltInMethodObject {
 			Name: "!=",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					className := receiver.Class().Name
-					compareClassName := args[0].Class().Name
+					if len(args) != 1 {
+						return t.vm.initErrorObject(ArgumentError, WrongNumberOfArgumentFormat, 1, len(args))
+					}
 
-					if className == compareClassName && reflect.DeepEqual(receiver, args[0]) {
+					result := t.sendMethod(receiver, "==", args[0])
+
+					if err, ok := result.(*Error); ok {
+						return err
+					}
+
+					if isTruthy(result) {
 						return FALSE
 					}
 					return TRUE
 				}
 			},
 		},
+		{
+			// Returns true if the argument is the same object as the receiver.
+			// Unlike `==`, it shouldn't be overridden.
+			//
+			// ```ruby
+			// a = Object.new
+			// a.equal?(a)          # => true
+			// a.equal?(Object.new) # => false
+			// "a".equal?("a")      # => false
+			// ```
+			//
+			// @return [Boolean]
+			Name:  "equal?",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver == args[0])
+				}
+			},
+		},
+		{
+			// Returns an Integer hash value of the receiver, which is used for looking up hash keys.
+			// Objects that are equal by the default `==` have the same hash value,
+			// so a class overriding `==` should also override `hash`.
+			//
+			// ```ruby
+			// 1.hash == 1.hash               # => true
+			// [1, 2].hash == [1, 2].hash     # => true
+			// Object.new.hash == Object.new.hash # => false
+			// ```
+			//
+			// @return [Integer]
+			Name: "hash",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					return t.vm.initIntegerObject(hashValue(receiver))
+				}
+			},
+		},
+		{
+			// General method for comparing objects, which returns 0 if the objects are equal by `==`, otherwise nil.
+			// A class that overrides it gets `<`, `<=`, `>` and `>=` derived from it.
+			//
+			// ```ruby
+			// class Version
+			//   attr_reader :number
+			//
+			//   def initialize(number)
+			//     @number = number
+			//   end
+			//
+			//   def <=>(other)
+			//     @number <=> other.number
+			//   end
+			// end
+			//
+			// Version.new(1) < Version.new(2)  # => true
+			// Version.new(2) >= Version.new(3) # => false
+			// ```
+			//
+			// @return [Integer]
+			Name: "<=>",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(ArgumentError, WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					result := t.sendMethod(receiver, "==", args[0])
+
+					if err, ok := result.(*Error); ok {
+						return err
+					}
+
+					if isTruthy(result) {
+						return t.vm.initIntegerObject(0)
+					}
+					return NULL
+				}
+			},
+		},
+		{
+			// General method which is true if receiver's `<=>` returns a negative number.
+			// It raises an ArgumentError if `<=>` returns nil, which means the objects can't be compared.
+			//
+			// @return [Boolean]
+			Name: "<",
+			Fn:   compareBySpaceship(func(result int) bool { return result < 0 }),
+		},
+		{
+			// General method which is true if receiver's `<=>` returns a negative number or 0.
+			// It raises an ArgumentError if `<=>` returns nil, which means the objects can't be compared.
+			//
+			// @return [Boolean]
+			Name: "<=",
+			Fn:   compareBySpaceship(func(result int) bool { return result <= 0 }),
+		},
+		{
+			// General method which is true if receiver's `<=>` returns a positive number.
+			// It raises an ArgumentError if `<=>` returns nil, which means the objects can't be compared.
+			//
+			// @return [Boolean]
+			Name: ">",
+			Fn:   compareBySpaceship(func(result int) bool { return result > 0 }),
+		},
+		{
+			// General method which is true if receiver's `<=>` returns a positive number or 0.
+			// It raises an ArgumentError if `<=>` returns nil, which means the objects can't be compared.
+			//
+			// @return [Boolean]
+			Name: ">=",
+			Fn:   compareBySpaceship(func(result int) bool { return result >= 0 }),
+		},
 		{
 			// Returns the receiver if it is truthy value. However, if the receiver value is falsey, it will
 			// return the right value
@@ -470,7 +769,8 @@ func builtinCommonInstanceMethods() []*BuiltInMethodObject {
 			//
 			// @param filename [String] Quoted file name of the library, without extension
 			// @return [Boolean] Result of loading module
-			Name: "require",
+			Name:  "require",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					libName := args[0].(*StringObject).value
@@ -498,7 +798,8 @@ func builtinCommonInstanceMethods() []*BuiltInMethodObject {
 			//
 			// @param path/name [String] Quoted file path to library plus name, without extension
 			// @return [Boolean] Result of loading module
-			Name: "require_relative",
+			Name:  "require_relative",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					callerDir := path.Dir(t.vm.currentFilePath())
@@ -520,7 +821,7 @@ func builtinCommonInstanceMethods() []*BuiltInMethodObject {
 		},
 		{
 			// Puts string literals or objects into stdout with a tailing line feed, converting into String
-			// if needed.
+			// with object's `to_s` method if needed.
 			//
 			// ```ruby
 			// puts("foo", "bar")
@@ -531,8 +832,18 @@ func builtinCommonInstanceMethods() []*BuiltInMethodObject {
 			// # => String
 			// puts("foo" + "bar")
 			// # => foobar
+			// puts "Hello #{1 + 2}"
+			// # => Hello 3
+			// ```
+			//
+			// Arrays are printed one element per line, nested arrays are flattened and an empty array prints an empty line.
+			//
+			// ```ruby
+			// puts([1, [2, 3]])
+			// # => 1
+			// # => 2
+			// # => 3
 			// ```
-			// TODO: interpolation is needed to be implemented.
 			//
 			// @param *args [Class] String literals, or other objects that can be converted into String.
 			// @return [Null]
@@ -541,7 +852,15 @@ func builtinCommonInstanceMethods() []*BuiltInMethodObject {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
 					for _, arg := range args {
-						fmt.Println(arg.toString())
+						lines, err := t.putsLines(arg, map[*ArrayObject]bool{})
+
+						if err != nil {
+							return err
+						}
+
+						for _, line := range lines {
+							fmt.Println(line)
+						}
 					}
 
 					return NULL
@@ -549,190 +868,459 @@ func builtinCommonInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			// Returns the class of the object. Receiver cannot be omitted.
-			//
-			// FYI: You can convert the class into String with `#name`.
+			// Prints string literals or objects into stdout without a tailing line feed, converting into String
+			// with object's `to_s` method if needed.
 			//
 			// ```ruby
-			// puts(100.class)         # => <Class:Integer>
-			// puts(100.class.name)    # => Integer
-			// puts("123".class)       # => <Class:String>
-			// puts("123".class.name)  # => String
+			// print("foo", "bar")
+			// # => foobar
+			// print(1, "\n")
+			// # => 1
 			// ```
 			//
-			// @param object [Object] Receiver (required)
-			// @return [Class] The class of the receiver
-			Name: "class",
+			// @param *args [Class] String literals, or other objects that can be converted into String.
+			// @return [Null]
+			Name: "print",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
-					switch r := receiver.(type) {
-					case Object:
-						return r.Class()
-					default:
-						return &Error{Message: "Can't call class on %T" + string(r.Class().ReturnName())}
+					for _, arg := range args {
+						str := t.sendMethod(arg, "to_s")
+
+						if err, ok := str.(*Error); ok {
+							return err
+						}
+
+						fmt.Print(str.toString())
 					}
+
+					return NULL
 				}
 			},
 		},
 		{
-			// Inverts the boolean value.
+			// Prints the debug form of objects into stdout, one object per line. Unlike `puts`, strings are quoted
+			// and objects are printed without calling their `to_s` method.
+			// It returns the object, or an array of the objects if more than one are given, so `p` can be used in expressions.
 			//
 			// ```ruby
-			// !true  # => false
-			// !false # => true
+			// p("foo")      # prints "foo" and returns "foo"
+			// p(1, nil)     # prints 1 and nil, returns [1, nil]
+			// p([1, "a"])   # prints [1, "a"]
+			// a = p(2) + 1  # => 3
 			// ```
 			//
-			// @param object [Object] object that return boolean value to invert
-			// @return [Object] Inverted boolean value
-			Name: "!",
+			// @param *args [Object]
+			// @return [Object]
+			Name: "p",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					for _, arg := range args {
+						fmt.Println(inspect(arg))
+					}
 
-					return FALSE
+					switch len(args) {
+					case 0:
+						return NULL
+					case 1:
+						return args[0]
+					default:
+						return t.vm.initArrayObject(args)
+					}
 				}
 			},
 		},
 		{
-			// Suspends the current thread for duration (sec).
-			//
-			// **Note:** currently, parameter cannot be omitted, and only Integer can be specified.
+			// Returns a String formatted from the format string and the arguments.
+			// See `String#%` for the supported directives.
 			//
 			// ```ruby
-			// a = sleep(2)
-			// puts(a)     # => 2
+			// sprintf("%d items for $%d", 3, 30) # => "3 items for $30"
+			// sprintf("%-5s|", "ab")             # => "ab   |"
+			// sprintf("%.1f%%", 12.34)           # => "12.3%"
 			// ```
 			//
-			// @param sec [Integer] time to wait in sec
-			// @return [Integer] actual time slept in sec
-			Name: "sleep",
+			// @param format [String], *args [Object]
+			// @return [String]
+			Name:  "sprintf",
+			Arity: &Arity{1, -1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					if len(args) != 1 {
-						return t.vm.initErrorObject(ArgumentError, "Expect 1 argument. got: %d", len(args))
+					format, ok := args[0].(*StringObject)
+
+					if !ok {
+						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, args[0].Class().Name)
 					}
 
-					int := args[0].(*IntegerObject)
-					seconds := int.value
-					time.Sleep(time.Duration(seconds) * time.Second)
-					return int
-				}
-			},
-		},
-		{
-			// Returns object's string representation.
-			// @param n/a []
-			// @return [String] Object's string representation.
-			Name: "to_s",
-			Fn: func(receiver Object) builtinMethodBody {
-				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					return t.vm.initStringObject(receiver.toString())
+					str, err := formatString(t, format.value, args[1:])
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initStringObject(str)
 				}
 			},
 		},
 		{
-			// Returns true if a block is given in the current context and `yield` is ready to call.
+			// Raises an error, which stops the program unless it's rescued by `begin ... rescue ... end`.
 			//
-			// **Note:** The method name does not end with '?' because the sign is unavalable in Goby for now.
+			// * With no argument, raises a `RuntimeError` with "unhandled error".
+			// * With a String, raises a `RuntimeError` with the String as message.
+			// * With an error class and an optional String, raises that type of error.
+			// * With a rescued error object, raises the error again.
 			//
 			// ```ruby
-			// class File
-			//   def self.open(filename, mode, perm)
-			//     file = new(filename, mode, perm)
-			//
-			//     if block_given?
-			//       yield(file)
-			//     end
-			//
-			//     file.close
-			//   end
+			// raise                           # => RuntimeError: unhandled error
+			// raise "Oops"                    # => RuntimeError: Oops
+			// raise ArgumentError, "Bad arg"  # => ArgumentError: Bad arg
+			//
+			// begin
+			//   10 / 0
+			// rescue => e
+			//   raise e                       # => ZeroDivisionError: Divided by 0
 			// end
 			// ```
 			//
-			// @param n/a []
-			// @return [Boolean] true/false
-			Name: "block_given?",
+			// @param error [Class] An error class, a String, or an error object.
+			// @param message [String] The message of the error, only used with an error class.
+			// @return [Error]
+			Name: "raise",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					cf := t.callFrameStack.top()
-
-					if cf.blockFrame == nil {
-						return FALSE
+					if len(args) > 2 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 2 or less arguments. got: %d", len(args))
 					}
 
-					return TRUE
-				}
-			},
-		},
-		{
-			Name: "thread",
-			Fn: func(receiver Object) builtinMethodBody {
-				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					if blockFrame == nil {
-						t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
+					if len(args) == 0 {
+						return t.vm.initErrorObject(RuntimeError, UnhandledErrorFormat)
 					}
 
-					newT := t.vm.newThread()
+					switch e := args[0].(type) {
+					case *StringObject:
+						if len(args) > 1 {
+							return t.vm.initErrorObject(ArgumentError, WrongNumberOfArgumentFormat, 1, len(args))
+						}
 
-					go func() {
-						newT.builtInMethodYield(blockFrame, args...)
-					}()
+						return t.vm.initErrorObject(RuntimeError, "%s", e.value)
+					case *Error:
+						if len(args) > 1 {
+							return t.vm.initErrorObject(ArgumentError, WrongNumberOfArgumentFormat, 1, len(args))
+						}
 
-					// We need to pop this frame from main thread manually,
-					// because the block's 'leave' instruction is running on other process
-					t.callFrameStack.pop()
+						return &Error{baseObj: e.baseObj, Message: e.Message}
+					case *RClass:
+						if !isErrorClass(e) {
+							return t.vm.initErrorObject(TypeError, "Expect argument to be an error class. got: %s", e.Name)
+						}
 
-					return NULL
-				}
-			},
-		},
-		{
-			// Returns true if Object class is equal to the input argument class
+						if len(args) == 1 {
+							return t.vm.initErrorObject(e.Name, UnhandledErrorFormat)
+						}
+
+						msg, ok := args[1].(*StringObject)
+
+						if !ok {
+							return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, args[1].Class().Name)
+						}
+
+						return t.vm.initErrorObject(e.Name, "%s", msg.value)
+					default:
+						return t.vm.initErrorObject(TypeError, "Expect argument to be an error class, String or error. got: %s", args[0].Class().Name)
+					}
+				}
+			},
+		},
+		{
+			// Returns the class of the object. Receiver cannot be omitted.
+			//
+			// FYI: You can convert the class into String with `#name`.
 			//
 			// ```ruby
-			// "Hello".is_a?(String)            # => true
-			// 123.is_a?(Integer)               # => true
-			// [1, true, "String"].is_a?(Array) # => true
-			// { a: 1, b: 2 }.is_a?(Hash)       # => true
-			// "Hello".is_a?(Integer)           # => false
-			// 123.is_a?(Range)                 # => false
-			// (2..4).is_a?(Hash)               # => false
-			// nil.is_a?(Integer)               # => false
+			// puts(100.class)         # => <Class:Integer>
+			// puts(100.class.name)    # => Integer
+			// puts("123".class)       # => <Class:String>
+			// puts("123".class.name)  # => String
+			// ```
+			//
+			// @param object [Object] Receiver (required)
+			// @return [Class] The class of the receiver
+			Name: "class",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					return receiver.Class()
+				}
+			},
+		},
+		{
+			// Returns a shallow copy of the receiver. Instance variables, array elements and hash values
+			// are copied to the new object, but the objects they refer to are shared.
+			// The copy is never frozen. Immutable objects like Integers and Symbols return themselves.
+			//
+			// ```ruby
+			// a = [[1], 2]
+			// b = a.dup
+			// b.push(3)
+			// a            # => [[1], 2]
+			// b[0] == a[0] # => true
+			// ```
+			//
+			// @return [Object] The copied object
+			Name: "dup",
+			Fn:   duplicate("#dup", false),
+		},
+		{
+			// Same as `dup` but the copy is also frozen if the receiver is frozen.
+			//
+			// ```ruby
+			// a = [1, 2].freeze
+			// a.clone.frozen? # => true
+			// a.dup.frozen?   # => false
+			// ```
+			//
+			// @return [Object] The copied object
+			Name: "clone",
+			Fn:   duplicate("#clone", true),
+		},
+		{
+			// Prevents further modifications to the receiver and returns it.
+			// Mutating methods (like `Array#push`) and instance variable assignments raise a FrozenError on a frozen object.
+			//
+			// ```ruby
+			// a = [1, 2].freeze
+			// a.push(3) # => FrozenError: Can't modify frozen Array: [1, 2]
+			// a[0]      # => 1
+			// ```
+			//
+			// @return [Object] The receiver
+			Name: "freeze",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					receiver.freeze()
+					return receiver
+				}
+			},
+		},
+		{
+			// Returns true if the receiver is frozen.
+			//
+			// ```ruby
+			// a = [1, 2]
+			// a.frozen? # => false
+			// a.freeze
+			// a.frozen? # => true
 			// ```
 			//
-			// @param n/a []
 			// @return [Boolean]
-			Name: "is_a?",
+			Name: "frozen?",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					if len(args) != 1 {
-						return t.vm.initErrorObject(ArgumentError, "Expect 1 argument. got: %d", len(args))
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
 					}
 
-					c := args[0]
-					gobyClass, ok := c.(*RClass)
+					return toBooleanObject(receiver.isFrozen())
+				}
+			},
+		},
+		{
+			// Inverts the object's truthiness. Only `nil` and `false` are falsey, so it returns false
+			// for any other object. `!!` can be used for converting an object into its truthiness.
+			//
+			// ```ruby
+			// !true   # => false
+			// !false  # => true
+			// !0      # => false
+			// !"foo"  # => false
+			// !!"foo" # => true
+			// ```
+			//
+			// @param object [Object] object that return boolean value to invert
+			// @return [Boolean] Inverted boolean value
+			Name: "!",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
-					if !ok {
-						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, classClass, c.Class().Name)
+					return FALSE
+				}
+			},
+		},
+		{
+			// Suspends the current thread for duration (sec).
+			//
+			// **Note:** currently, parameter cannot be omitted.
+			//
+			// ```ruby
+			// a = sleep(2)
+			// puts(a)     # => 2
+			// sleep(0.5)  # => 0.5
+			// ```
+			//
+			// @param sec [Numeric] time to wait in sec
+			// @return [Numeric] actual time slept in sec
+			Name:  "sleep",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					var duration time.Duration
+
+					switch sec := args[0].(type) {
+					case *IntegerObject:
+						duration = time.Duration(sec.value) * time.Second
+					case *FloatObject:
+						duration = time.Duration(sec.value * float64(time.Second))
+					default:
+						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, numericName, args[0].Class().Name)
 					}
 
-					receiverClass := receiver.Class()
+					if duration < 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect time interval not to be negative. got: %s", args[0].toString())
+					}
 
-					for {
-						if receiverClass.Name == gobyClass.Name {
-							return TRUE
-						}
+					time.Sleep(duration)
+					return args[0]
+				}
+			},
+		},
+		{
+			// Returns object's string representation.
+			// @param n/a []
+			// @return [String] Object's string representation.
+			Name: "to_s",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.toString())
+				}
+			},
+		},
+		{
+			// Returns true if a block is given in the current context and `yield` is ready to call.
+			//
+			// **Note:** The method name does not end with '?' because the sign is unavalable in Goby for now.
+			//
+			// ```ruby
+			// class File
+			//   def self.open(filename, mode, perm)
+			//     file = new(filename, mode, perm)
+			//
+			//     if block_given?
+			//       yield(file)
+			//     end
+			//
+			//     file.close
+			//   end
+			// end
+			// ```
+			//
+			// @param n/a []
+			// @return [Boolean] true/false
+			Name: "block_given?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					// Blocks inside the method don't count, only the block given to method itself does
+					cf := t.callFrameStack.top().methodFrame()
 
-						if receiverClass.Name == objectClass {
-							break
-						}
+					if cf == nil || cf.blockFrame == nil {
+						return FALSE
+					}
 
-						receiverClass = receiverClass.superClass
+					return TRUE
+				}
+			},
+		},
+		{
+			// Executes the given block repeatedly until it's stopped by `break`, and returns the value given to `break`.
+			// Without a block it returns an Enumerator that never ends.
+			//
+			// ```ruby
+			// i = 0
+			// loop do
+			//   i += 1
+			//   break i * 10 if i == 3
+			// end # => 30
+			//
+			// loop.next # => nil
+			// ```
+			//
+			// @param n/a []
+			// @return [Object]
+			Name: "loop",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
 					}
-					return FALSE
+
+					if blockFrame == nil {
+						return t.vm.initEnumeratorObject(receiver, "loop", -1, func(i int) Object {
+							return NULL
+						})
+					}
+
+					// `break` and errors raised in the block unwind the loop by themselves
+					for {
+						t.builtInMethodYield(blockFrame)
+					}
+				}
+			},
+		},
+		{
+			Name: "thread",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if blockFrame == nil {
+						t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
+					}
+
+					newT := t.vm.newThread()
+
+					go func() {
+						newT.yieldBlock(blockFrame, args...)
+					}()
+
+					// We need to pop this frame from main thread manually,
+					// because the block's 'leave' instruction is running on other process
+					t.callFrameStack.pop()
+
+					return NULL
 				}
 			},
 		},
+		{
+			// Returns true if Object class is equal to the input argument class
+			//
+			// ```ruby
+			// "Hello".is_a?(String)            # => true
+			// 123.is_a?(Integer)               # => true
+			// [1, true, "String"].is_a?(Array) # => true
+			// { a: 1, b: 2 }.is_a?(Hash)       # => true
+			// "Hello".is_a?(Integer)           # => false
+			// 123.is_a?(Range)                 # => false
+			// (2..4).is_a?(Hash)               # => false
+			// nil.is_a?(Integer)               # => false
+			// ```
+			//
+			// @param n/a []
+			// @return [Boolean]
+			Name: "is_a?",
+			Fn:   isA,
+		},
+		{
+			// Same as `is_a?`.
+			//
+			// @param class [Class]
+			// @return [Boolean]
+			Name: "kind_of?",
+			Fn:   isA,
+		},
 		{
 			// Returns true if Object is nil
 			//
@@ -757,16 +1345,173 @@ func builtinCommonInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			Name: "instance_variable_get",
+			// Returns true if the object responds to the given method name, which can be a String or a Symbol.
+			// Methods are looked up through the ancestors but not invoked.
+			//
+			// ```ruby
+			// 1.respond_to?(:to_s)      # => true
+			// "a".respond_to?("upcase") # => true
+			// String.respond_to?(:new)  # => true
+			// nil.respond_to?(:foo)     # => false
+			// ```
+			//
+			// @param method name [String/Symbol]
+			// @return [Boolean]
+			Name:  "respond_to?",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					arg, isStr := args[0].(*StringObject)
+					methodName, err := stringOrSymbolValue(t, args[0])
 
-					if !isStr {
-						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, args[0].Class().Name)
+					if err != nil {
+						return err
 					}
 
-					obj, ok := receiver.instanceVariableGet(arg.value)
+					return toBooleanObject(receiver.findMethod(methodName) != nil)
+				}
+			},
+		},
+		{
+			// Returns an array of Symbols naming the methods the receiver responds to, including its singleton methods.
+			// If the argument is false, only the singleton methods are returned.
+			//
+			// ```ruby
+			// class Foo
+			//   def bar; end
+			// end
+			//
+			// f = Foo.new
+			// def f.baz; end
+			//
+			// f.methods.include?(:bar)  # => true
+			// f.methods.include?(:to_s) # => true
+			// f.methods(false)          # => [:baz]
+			// Foo.methods.include?(:new) # => true
+			// ```
+			//
+			// @param inherited [Boolean] Whether to include inherited methods, true by default
+			// @return [Array]
+			Name: "methods",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					inherited, err := inheritedFlag(t, args)
+
+					if err != nil {
+						return err
+					}
+
+					var names []string
+
+					switch r := receiver.(type) {
+					case *RClass:
+						class := r.SingletonClass()
+
+						if r.isSingleton {
+							class = r.superClass
+						}
+
+						names = class.methodNames(inherited)
+					default:
+						names = []string{}
+
+						if r.SingletonClass() != nil {
+							names = r.SingletonClass().methodNames(false)
+						}
+
+						if inherited {
+							names = mergeNames(names, r.Class().methodNames(true))
+						}
+					}
+
+					return symbolArray(t, names)
+				}
+			},
+		},
+		{
+			// Invokes the method with given name (a String or a Symbol) and passes the rest of arguments and the block to it.
+			//
+			// ```ruby
+			// 1.send("+", 2)                   # => 3
+			// "a".send("upcase")               # => "A"
+			// [1, 2].send(:map) { |i| i * 2 }  # => [2, 4]
+			// ```
+			//
+			// @param method name [String/Symbol], arguments [Object]
+			// @return [Object]
+			Name:  "send",
+			Arity: &Arity{1, -1},
+			Fn:    send,
+		},
+		{
+			// Same as `send` because Goby doesn't have private methods yet.
+			//
+			// @param method name [String/Symbol], arguments [Object]
+			// @return [Object]
+			Name:  "public_send",
+			Arity: &Arity{1, -1},
+			Fn:    send,
+		},
+		{
+			// Yields the receiver to the block and returns the receiver, ignoring the block's result.
+			// It's useful for inspecting an object in the middle of a method chain.
+			//
+			// ```ruby
+			// [1, 2].tap { |a| puts(a.to_s) }.map { |i| i * 2 } # prints [1, 2] and returns [2, 4]
+			// ```
+			//
+			// @return [Object] The receiver
+			Name: "tap",
+			Fn:   yieldReceiver(true),
+		},
+		{
+			// Yields the receiver to the block and returns the block's result.
+			//
+			// ```ruby
+			// 3.then { |i| i * 2 }         # => 6
+			// "goby".then { |s| s.upcase } # => "GOBY"
+			// ```
+			//
+			// @return [Object] The block's result
+			Name: "then",
+			Fn:   yieldReceiver(false),
+		},
+		{
+			// Same as `then`.
+			//
+			// @return [Object] The block's result
+			Name: "yield_self",
+			Fn:   yieldReceiver(false),
+		},
+		{
+			// Returns the value of the receiver's instance variable, or nil if it's not set.
+			// The name can be a String or a Symbol, and the leading `@` can be omitted.
+			//
+			// ```ruby
+			// class Foo
+			//   def initialize
+			//     @bar = 1
+			//   end
+			// end
+			//
+			// f = Foo.new
+			// f.instance_variable_get("@bar") # => 1
+			// f.instance_variable_get(:bar)   # => 1
+			// f.instance_variable_get("@baz") # => nil
+			// ```
+			//
+			// @param name [String/Symbol]
+			// @return [Object]
+			Name:  "instance_variable_get",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					name, err := instanceVariableName(t, args[0])
+
+					if err != nil {
+						return err
+					}
+
+					obj, ok := receiver.instanceVariableGet(name)
 
 					if !ok {
 						return NULL
@@ -777,6 +1522,50 @@ func builtinCommonInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
+			// Returns an array of Symbols naming the receiver's instance variables in alphabetical order.
+			//
+			// ```ruby
+			// class Foo
+			//   def initialize
+			//     @b = 1
+			//     @a = 2
+			//   end
+			// end
+			//
+			// Foo.new.instance_variables    # => [:@a, :@b]
+			// Object.new.instance_variables # => []
+			// ```
+			//
+			// @return [Array]
+			Name: "instance_variables",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					names := []Object{}
+
+					for _, name := range receiver.instanceVariableNames() {
+						names = append(names, t.vm.initSymbolObject(name))
+					}
+
+					return t.vm.initArrayObject(names)
+				}
+			},
+		},
+		{
+			// Sets the receiver's instance variable and returns the value.
+			// The name can be a String or a Symbol, and the leading `@` can be omitted.
+			//
+			// ```ruby
+			// f = Object.new
+			// f.instance_variable_set(:bar, 1)
+			// f.instance_variable_get("@bar") # => 1
+			// ```
+			//
+			// @param name [String/Symbol], value [Object]
+			// @return [Object] The value
 			Name: "instance_variable_set",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
@@ -784,14 +1573,18 @@ func builtinCommonInstanceMethods() []*BuiltInMethodObject {
 						return t.vm.initErrorObject(ArgumentError, "Expect 2 arguments. got: %d", len(args))
 					}
 
-					argName, isStr := args[0].(*StringObject)
+					name, err := instanceVariableName(t, args[0])
 					obj := args[1]
 
-					if !isStr {
-						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, args[0].Class().Name)
+					if err != nil {
+						return err
+					}
+
+					if receiver.isFrozen() {
+						return t.frozenError(receiver)
 					}
 
-					receiver.instanceVariableSet(argName.value, obj)
+					receiver.instanceVariableSet(name, obj)
 
 					return obj
 				}
@@ -800,16 +1593,284 @@ func builtinCommonInstanceMethods() []*BuiltInMethodObject {
 	}
 }
 
+// isA is the implementation of `is_a?` and `kind_of?`
+func isA(receiver Object) builtinMethodBody {
+	return func(t *thread, args []Object, blockFrame *callFrame) Object {
+		if len(args) != 1 {
+			return t.vm.initErrorObject(ArgumentError, "Expect 1 argument. got: %d", len(args))
+		}
+
+		c := args[0]
+		gobyClass, ok := c.(*RClass)
+
+		if !ok {
+			return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, classClass, c.Class().Name)
+		}
+
+		receiverClass := receiver.Class()
+
+		for {
+			if receiverClass == gobyClass {
+				return TRUE
+			}
+
+			if receiverClass.Name == objectClass {
+				break
+			}
+
+			receiverClass = receiverClass.superClass
+		}
+		return FALSE
+	}
+}
+
+// send is the implementation of `send` and `public_send`
+func send(receiver Object) builtinMethodBody {
+	return func(t *thread, args []Object, blockFrame *callFrame) Object {
+		methodName, err := stringOrSymbolValue(t, args[0])
+
+		if err != nil {
+			return err
+		}
+
+		return t.sendMethodWithBlock(receiver, methodName, blockFrame, args[1:]...)
+	}
+}
+
+// instanceVariableName returns the name of an instance variable with the leading `@` from a String or a Symbol
+func instanceVariableName(t *thread, arg Object) (string, *Error) {
+	name, err := stringOrSymbolValue(t, arg)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasPrefix(name, "@") {
+		name = "@" + name
+	}
+
+	return name, nil
+}
+
+// inheritedFlag returns the optional Boolean argument of `methods` and `instance_methods`, which defaults to true
+func inheritedFlag(t *thread, args []Object) (bool, *Error) {
+	switch len(args) {
+	case 0:
+		return true, nil
+	case 1:
+		b, ok := args[0].(*BooleanObject)
+
+		if !ok {
+			return false, t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, booleanClass, args[0].Class().Name)
+		}
+
+		return b.value, nil
+	default:
+		return false, t.vm.initErrorObject(ArgumentError, "Expect 0 or 1 argument. got: %d", len(args))
+	}
+}
+
+// mergeNames returns the union of two name lists in alphabetical order
+func mergeNames(a, b []string) []string {
+	set := map[string]bool{}
+
+	for _, name := range append(a, b...) {
+		set[name] = true
+	}
+
+	names := []string{}
+
+	for name := range set {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// symbolArray converts method names into an array of Symbols
+func symbolArray(t *thread, names []string) *ArrayObject {
+	elems := []Object{}
+
+	for _, name := range names {
+		elems = append(elems, t.vm.initSymbolObject(name))
+	}
+
+	return t.vm.initArrayObject(elems)
+}
+
+// hashValue returns the default hash value of an object. Instances of user-defined classes are hashed by identity
+// like the default `==` compares them, other objects are hashed by their class and content.
+func hashValue(receiver Object) int {
+	if _, ok := receiver.(*RObject); ok {
+		return receiver.id()
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(receiver.Class().Name + ":" + receiver.toString()))
+
+	return int(h.Sum64())
+}
+
+// inspect returns the debug form of the object, which is what `p` prints
+func inspect(obj Object) string {
+	if s, ok := obj.(*StringObject); ok {
+		return strconv.Quote(s.value)
+	}
+
+	return obj.toString()
+}
+
+// putsLines returns the lines `puts` prints for the object. Elements of an array are printed in their own lines,
+// and an array that contains itself is printed as `[...]` where it recurses.
+func (t *thread) putsLines(obj Object, visited map[*ArrayObject]bool) ([]string, *Error) {
+	arr, ok := obj.(*ArrayObject)
+
+	if !ok {
+		str := t.sendMethod(obj, "to_s")
+
+		if err, ok := str.(*Error); ok {
+			return nil, err
+		}
+
+		return []string{str.toString()}, nil
+	}
+
+	if visited[arr] {
+		return []string{"[...]"}, nil
+	}
+
+	if len(arr.Elements) == 0 {
+		return []string{""}, nil
+	}
+
+	visited[arr] = true
+	defer delete(visited, arr)
+
+	lines := []string{}
+
+	for _, elem := range arr.Elements {
+		elemLines, err := t.putsLines(elem, visited)
+
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, elemLines...)
+	}
+
+	return lines, nil
+}
+
+// yieldReceiver returns the body of `tap` and `then`, which yield the receiver to the block.
+// It returns the receiver if keepReceiver is true, otherwise the block's result.
+func yieldReceiver(keepReceiver bool) func(receiver Object) builtinMethodBody {
+	return func(receiver Object) builtinMethodBody {
+		return func(t *thread, args []Object, blockFrame *callFrame) Object {
+			if len(args) != 0 {
+				return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+			}
+
+			if blockFrame == nil {
+				return t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
+			}
+
+			result := t.builtInMethodYield(blockFrame, receiver)
+
+			if !keepReceiver {
+				return result.Target
+			}
+
+			return receiver
+		}
+	}
+}
+
+// duplicate returns the body of `dup` and `clone`, which creates a new object of the same class with copied state.
+func duplicate(methodName string, keepFrozen bool) func(receiver Object) builtinMethodBody {
+	return func(receiver Object) builtinMethodBody {
+		return func(t *thread, args []Object, blockFrame *callFrame) Object {
+			if len(args) != 0 {
+				return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+			}
+
+			var obj Object
+
+			switch r := receiver.(type) {
+			case *RObject:
+				obj = &RObject{
+					baseObj:          &baseObj{class: r.class, InstanceVariables: r.InstanceVariables.copy()},
+					InitializeMethod: r.InitializeMethod,
+				}
+			case *ArrayObject:
+				a := r.copy().(*ArrayObject)
+				a.InstanceVariables = r.InstanceVariables.copy()
+				obj = a
+			case *HashObject:
+				h := r.copy().(*HashObject)
+				h.InstanceVariables = r.InstanceVariables.copy()
+				obj = h
+			case *StringObject:
+				s := t.vm.initStringObject(r.value)
+				s.InstanceVariables = r.InstanceVariables.copy()
+				obj = s
+			case *IntegerObject, *BigIntegerObject, *FloatObject, *SymbolObject, *BooleanObject, *NullObject, *RangeObject:
+				return receiver
+			default:
+				return t.unsupportedMethodError(methodName, receiver)
+			}
+
+			if keepFrozen && receiver.isFrozen() {
+				obj.freeze()
+			}
+
+			return obj
+		}
+	}
+}
+
+// compareBySpaceship is the implementation of the general `<`, `<=`, `>` and `>=`,
+// which checks the result of receiver's `<=>` with given function.
+func compareBySpaceship(compare func(result int) bool) func(receiver Object) builtinMethodBody {
+	return func(receiver Object) builtinMethodBody {
+		return func(t *thread, args []Object, blockFrame *callFrame) Object {
+			if len(args) != 1 {
+				return t.vm.initErrorObject(ArgumentError, WrongNumberOfArgumentFormat, 1, len(args))
+			}
+
+			result, err := spaceship(t, receiver, args[0])
+
+			if err != nil {
+				return err
+			}
+
+			return toBooleanObject(compare(result))
+		}
+	}
+}
+
+// spaceship returns the result of receiver's `<=>`, or an error if `<=>` raises one or the objects can't be compared
+func spaceship(t *thread, receiver, arg Object) (int, *Error) {
+	switch result := t.sendMethod(receiver, "<=>", arg).(type) {
+	case *Error:
+		return 0, result
+	case *IntegerObject:
+		return result.value, nil
+	}
+
+	return 0, t.vm.initErrorObject(ArgumentError, "Comparison of %s with %s failed", receiver.Class().Name, arg.Class().Name)
+}
+
 func builtinClassClassMethods() []*BuiltInMethodObject {
 	return []*BuiltInMethodObject{
 		{
 			// Creates instance variables and corresponding methods that return the value of
 			// each instance variable and assign an argument to each instance variable.
-			// Only string literal can be used for now.
+			// The names can be given as symbols or strings.
 			//
 			// ```ruby
 			// class Foo
-			//   attr_accessor("bar", "buz")
+			//   attr_accessor :bar, :buz
 			// end
 			// ```
 			// is equivalent to:
@@ -831,13 +1892,19 @@ func builtinClassClassMethods() []*BuiltInMethodObject {
 			// end
 			// ```
 			//
-			// @param *args [String] One or more quoted method names for 'getter/setter'
+			// @param *args [String] One or more method names for 'getter/setter'
 			// @return [Null]
 			Name: "attr_accessor",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					names, err := attrNames(t, args)
+
+					if err != nil {
+						return err
+					}
+
 					r := receiver.(*RClass)
-					r.setAttrAccessor(args)
+					r.setAttrAccessor(names)
 
 					return r
 				}
@@ -847,11 +1914,11 @@ func builtinClassClassMethods() []*BuiltInMethodObject {
 			// Creates instance variables and corresponding methods that return the value of each
 			// instance variable.
 			//
-			// Only string literal can be used for now.
+			// The names can be given as symbols or strings.
 			//
 			// ```ruby
 			// class Foo
-			//   attr_reader("bar", "buz")
+			//   attr_reader :bar, :buz
 			// end
 			// ```
 			// is equivalent to:
@@ -867,13 +1934,19 @@ func builtinClassClassMethods() []*BuiltInMethodObject {
 			// end
 			// ```
 			//
-			// @param *args [String] One or more quoted method names for 'getter'
+			// @param *args [String] One or more method names for 'getter'
 			// @return [Null]
 			Name: "attr_reader",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					names, err := attrNames(t, args)
+
+					if err != nil {
+						return err
+					}
+
 					r := receiver.(*RClass)
-					r.setAttrReader(args)
+					r.setAttrReader(names)
 
 					return r
 				}
@@ -883,11 +1956,11 @@ func builtinClassClassMethods() []*BuiltInMethodObject {
 			// Creates instance variables and corresponding methods that assign an argument to each
 			// instance variable. No return value.
 			//
-			// Only string literal can be used for now.
+			// The names can be given as symbols or strings.
 			//
 			// ```ruby
 			// class Foo
-			//   attr_writer("bar", "buz")
+			//   attr_writer :bar, :buz
 			// end
 			// ```
 			// is equivalent to:
@@ -903,18 +1976,68 @@ func builtinClassClassMethods() []*BuiltInMethodObject {
 			// end
 			// ```
 			//
-			// @param *args [String] One or more quoted method names for 'setter'
+			// @param *args [String] One or more method names for 'setter'
 			// @return [Null]
 			Name: "attr_writer",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					names, err := attrNames(t, args)
+
+					if err != nil {
+						return err
+					}
+
 					r := receiver.(*RClass)
-					r.setAttrWriter(args)
+					r.setAttrWriter(names)
 
 					return r
 				}
 			},
 		},
+		{
+			// Defines an instance method with the given name (a Symbol or a String), the block becomes the method's body
+			// and the block's parameters become the method's parameters.
+			//
+			// ```ruby
+			// class Foo
+			//   [:bar, :baz].each do |name|
+			//     define_method(name) do |x|
+			//       name.to_s + x.to_s
+			//     end
+			//   end
+			// end
+			//
+			// Foo.new.bar(1) # => "bar1"
+			// ```
+			//
+			// @param name [Symbol]
+			// @return [Symbol]
+			Name: "define_method",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 1 argument. got: %d", len(args))
+					}
+
+					names, err := attrNames(t, args)
+
+					if err != nil {
+						return err
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(InternalError, "Can't define method '%s' without a block", names[0])
+					}
+
+					is := blockFrame.instructionSet
+					r := receiver.(*RClass)
+					method := &MethodObject{Name: names[0], argc: len(is.argTypes), instructionSet: is, owner: r, ep: blockFrame.ep, baseObj: &baseObj{class: t.vm.topLevelClass(methodClass)}}
+					r.setMethod(names[0], method)
+
+					return t.vm.initSymbolObject(names[0])
+				}
+			},
+		},
 		{
 			// Includes a module for mixin, which inherits only methods and constants from the module.
 			// The included module is inserted into the path of the inheritance tree, between the class
@@ -970,7 +2093,8 @@ func builtinClassClassMethods() []*BuiltInMethodObject {
 			//
 			// @param module [Class] Module name to include
 			// @return [Null]
-			Name: "include",
+			Name:  "include",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					var class *RClass
@@ -993,13 +2117,15 @@ func builtinClassClassMethods() []*BuiltInMethodObject {
 
 					module.superClass = class.superClass
 					class.superClass = module
+					invalidateMethodCache()
 
 					return class
 				}
 			},
 		},
 		{
-			Name: "extend",
+			Name:  "extend",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					var class *RClass
@@ -1017,11 +2143,46 @@ func builtinClassClassMethods() []*BuiltInMethodObject {
 
 					module.superClass = class.superClass
 					class.superClass = module
+					invalidateMethodCache()
 
 					return class
 				}
 			},
 		},
+		{
+			// Returns an array of Symbols naming the public instance methods of the class (receiver).
+			// If the argument is false, only the methods defined in the class itself are returned.
+			//
+			// ```ruby
+			// class Foo
+			//   def bar; end
+			// end
+			//
+			// Foo.instance_methods(false)          # => [:bar]
+			// Foo.instance_methods.include?(:to_s) # => true
+			// ```
+			//
+			// @param inherited [Boolean] Whether to include inherited methods, true by default
+			// @return [Array]
+			Name: "instance_methods",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					inherited, err := inheritedFlag(t, args)
+
+					if err != nil {
+						return err
+					}
+
+					class, ok := receiver.(*RClass)
+
+					if !ok {
+						return t.vm.initErrorObject(UndefinedMethodError, "Undefined Method '%s' for %s", "#instance_methods", receiver.toString())
+					}
+
+					return symbolArray(t, class.methodNames(inherited))
+				}
+			},
+		},
 		{
 			// Returns the name of the class (receiver).
 			//
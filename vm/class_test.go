@@ -1,6 +1,12 @@
 package vm
 
-import "testing"
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
 
 func TestClassClassSuperclass(t *testing.T) {
 	tests := []struct {
@@ -96,6 +102,29 @@ func TestAttrReaderAndWriter(t *testing.T) {
 	}
 }
 
+func TestAttrReaderAndWriterFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`class Foo
+		  attr_reader 1
+		end`, "TypeError: Expect argument to be String or Symbol. got: Integer", 2},
+		{`class Foo
+		  attr_writer :bar, nil
+		end`, "TypeError: Expect argument to be String or Symbol. got: Null", 2},
+		{`class Foo
+		  attr_accessor true
+		end`, "TypeError: Expect argument to be String or Symbol. got: Boolean", 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		// The error is raised inside class body's frame
+		v.checkCFP(t, i, 2)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestClassInheritModule(t *testing.T) {
 	input := `module Foo
 end
@@ -146,53 +175,49 @@ func TestClassInstanceVariable(t *testing.T) {
 	}
 }
 
-func TestCustomClassConstructor(t *testing.T) {
-	input := `
-		class Foo
-			def initialize(x, y)
-				@x = x
-				@y = y
-			end
-
-			def bar
-				@x + @y
-			end
-		end
-
-		f = Foo.new(10, 20)
-		f.bar
-	`
-
-	v := initTestVM()
-	evaluated := v.testEval(t, input, getFilename())
-	checkExpected(t, 0, evaluated, 30)
-	v.checkCFP(t, 0, 0)
-	v.checkSP(t, 0, 1)
-}
-
-func TestDefClassMethod(t *testing.T) {
+func TestInstanceVariableGetAndSetMethods(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected int
+		expected interface{}
 	}{
 		{`
 		class Foo
-		  def self.bar
-		    10
+		  def initialize
+		    @bar = 10
 		  end
 		end
 
-		Foo.bar
-		`, 10},
+		f = Foo.new
+		f.instance_variable_get("@bar") + f.instance_variable_get("bar")
+		`, 20},
 		{`
-		module Foo
-		  def self.bar
-		    10
+		class Foo
+		  def initialize
+		    @bar = 10
 		  end
 		end
 
-		Foo.bar
-		`, 10},
+		f = Foo.new
+		f.instance_variable_get(:bar) * 2
+		`, 20},
+		{`Object.new.instance_variable_get("@bar")`, nil},
+		{`
+		class Foo
+		  attr_reader :bar
+		end
+
+		f = Foo.new
+		f.instance_variable_set(:bar, 10)
+		f.instance_variable_set("@baz", 5)
+		f.bar + f.instance_variable_get("baz")
+		`, 15},
+		{`Object.new.instance_variable_set(:bar, "foo")`, "foo"},
+		{`
+		a = [1, 2]
+		a.instance_variable_set(:bar, 10)
+		a.instance_variable_get("@bar") + a.length
+		`, 12},
+		{`"foo".instance_variable_get(:bar)`, nil},
 	}
 
 	for i, tt := range tests {
@@ -204,195 +229,135 @@ func TestDefClassMethod(t *testing.T) {
 	}
 }
 
-func TestBuiltInClassMonkeyPatching(t *testing.T) {
-	input := `
-	class String
-	  def buz
-	    "buz"
-	  end
-	end
-
-	"123".buz
-	`
+func TestInstanceVariableGetAndSetMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Object.new.instance_variable_get(1)`, "TypeError: Expect argument to be String or Symbol. got: Integer", 1},
+		{`Object.new.instance_variable_set(1, 2)`, "TypeError: Expect argument to be String or Symbol. got: Integer", 1},
+		{`Object.new.instance_variable_set(:bar)`, "ArgumentError: Expect 2 arguments. got: 1", 1},
+	}
 
-	v := initTestVM()
-	evaluated := v.testEval(t, input, getFilename())
-	checkExpected(t, 0, evaluated, "buz")
-	v.checkCFP(t, 0, 0)
-	v.checkSP(t, 0, 1)
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
 }
 
-func TestClassNamespace(t *testing.T) {
+func TestInstanceVariablesMethod(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected int
+		expected interface{}
 	}{
-		{`
-		module Foo
-		  class Bar
-		    def bar
-		      10
-		    end
-		  end
-		end
-
-		Foo::Bar.new.bar
-		`, 10},
+		{`Object.new.instance_variables.to_s`, "[]"},
+		{`[1, 2].instance_variables.to_s`, "[]"},
 		{`
 		class Foo
-		  class Bar
-		    def bar
-		      10
-		    end
+		  def initialize
+		    @b = 1
+		    @a = 2
 		  end
 		end
 
-		Foo::Bar.new.bar
-		`, 10},
+		Foo.new.instance_variables.to_s
+		`, "[:@a, :@b]"},
 		{`
 		class Foo
-		  def bar
-		    100
-		  end
-
-		  class Bar
-		    def bar
-		      10
-		    end
+		  def initialize
+		    @b = 1
 		  end
 		end
 
-		Foo.new.bar + Foo::Bar.new.bar
-		`, 110},
+		f = Foo.new
+		f.instance_variable_set(:c, 3)
+		f.instance_variables.to_s
+		`, "[:@b, :@c]"},
 		{`
 		class Foo
-		  def bar
-		    100
-		  end
-		end
-
-		module Baz
-		  class Bar
-		    def bar
-		      Foo.new.bar
-		    end
-		  end
-		end
-
-		Baz::Bar.new.bar
-		`, 100},
-		{`
-		module Baz
-		  class Bar
-		    class Foo
-		      def bar
-				100
-		      end
-		    end
+		  def initialize
+		    @a = 1
 		  end
 		end
 
-		Baz::Bar::Foo.new.bar
-		`, 100},
-		{`
-		module Baz
-		  class Foo
-		    def bar
-		      100
-		    end
-		  end
+		Foo.new.instance_variables.map do |name|
+		  name.class.name
+		end.to_s
+		`, "[\"Symbol\"]"},
+	}
 
-		  class Bar
-		    def bar
-		      Foo.new.bar
-		    end
-		  end
-		end
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
 
-		Baz::Bar.new.bar
-		`, 100},
-		{`
-		module Baz
-		  class Bar
-		    def bar
-		      Foo.new.bar
-		    end
+func TestInstanceVariablesMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Object.new.instance_variables(1)`, "ArgumentError: Expect 0 argument. got: 1", 1},
+	}
 
-		    class Foo
-		      def bar
-				100
-		      end
-		    end
-		  end
-		end
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
 
-		Baz::Bar.new.bar
-		`, 100},
+func TestMethodsMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
 		{`
-		module Foo
-		  class Bar
-		    def bar
-		      10
-		    end
-		  end
+		class Foo
+		  def bar; end
 		end
 
-		module Baz
-		  class Bar < Foo::Bar
-		    def foo
-		      100
-		    end
-		  end
+		class Baz < Foo
+		  def qux; end
 		end
 
-		b = Baz::Bar.new
-		b.foo + b.bar
-		`, 110},
+		b = Baz.new
+		b.methods.include?(:bar) && b.methods.include?(:qux) && b.methods.include?(:to_s)
+		`, true},
 		{`
-		module A
-		  class B
-		    class C
-		      class D
-		        def e
-		          10
-		        end
-		      end
-		    end
-		  end
+		class Foo
+		  def bar; end
 		end
 
-		A::B::C::D.new.e
-		`, 10},
+		f = Foo.new
+		def f.baz; end
+		f.methods(false).to_s
+		`, "[:baz]"},
 		{`
 		class Foo
-		  def self.bar
-		    10
-		  end
+		  def self.bar; end
 		end
 
-		Object::Foo.bar
-		`, 10},
-		{`
-		Foo = 10
-
-		Object::Foo
-		`, 10},
+		Foo.methods(false).to_s
+		`, "[:bar]"},
 		{`
-		class X
-		  Bar = 100
+		class Foo
+		  def bar; end
 		end
 
-		module Foo
-		  Bar = 10
-
-		  class Baz < X
-			def self.result
-			  Bar
-			end
-		  end
+		Foo.methods.include?(:superclass)
+		`, true},
+		{`
+		class Foo
+		  def bar; end
 		end
 
-		Foo::Baz.result`, 10},
+		Foo.methods.include?(:bar)
+		`, false},
+		{`Object.new.methods(false).to_s`, "[]"},
+		{`1.methods.include?(:even?)`, true},
 	}
 
 	for i, tt := range tests {
@@ -404,20 +369,1338 @@ func TestClassNamespace(t *testing.T) {
 	}
 }
 
-func TestPrimitiveType(t *testing.T) {
+func TestInstanceMethodsMethod(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected string
+		expected interface{}
 	}{
-		{
-			`100.class.name
-			`,
-			"Integer",
-		},
-		{
-			`Integer.name
-			`,
-			"Integer",
+		{`
+		class Foo
+		  def foo; end
+		end
+
+		class Bar < Foo
+		  def bar; end
+		  def baz; end
+		  def self.qux; end
+		end
+
+		Bar.instance_methods(false).to_s
+		`, "[:bar, :baz]"},
+		{`
+		class Foo
+		  def foo; end
+		end
+
+		class Bar < Foo
+		end
+
+		Bar.instance_methods.include?(:foo) && Bar.instance_methods.include?(:to_s)
+		`, true},
+		{`
+		class Foo
+		  def self.foo; end
+		end
+
+		Foo.instance_methods.include?(:foo)
+		`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodsMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Object.new.methods(1)`, "TypeError: Expect argument to be Boolean. got: Integer", 1},
+		{`Object.new.methods(true, false)`, "ArgumentError: Expect 0 or 1 argument. got: 2", 1},
+		{`Object.instance_methods("a")`, "TypeError: Expect argument to be Boolean. got: String", 1},
+		{`Object.instance_methods(true, false)`, "ArgumentError: Expect 0 or 1 argument. got: 2", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestClassVariable(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  @@count = 0
+
+		  def initialize
+		    @@count += 1
+		  end
+
+		  def self.count
+		    @@count
+		  end
+		end
+
+		Foo.new
+		Foo.new
+		Foo.count
+		`, 2},
+		// Class methods and instance methods share the same class variable
+		{`
+		class Foo
+		  def self.set(x)
+		    @@x = x
+		  end
+
+		  def get
+		    @@x
+		  end
+		end
+
+		Foo.set(10)
+		Foo.new.get
+		`, 10},
+		// Subclasses share class variables defined in superclass
+		{`
+		class Foo
+		  @@count = 0
+
+		  def self.incr
+		    @@count += 1
+		  end
+
+		  def self.count
+		    @@count
+		  end
+		end
+
+		class Bar < Foo
+		  def self.bar_incr
+		    @@count += 10
+		  end
+		end
+
+		Foo.incr
+		Bar.incr
+		Bar.bar_incr
+		Foo.count
+		`, 12},
+		// Class variable defined in subclass is not visible to superclass
+		{`
+		class Foo
+		  def self.set_x
+		    @@x = 1
+		  end
+		end
+
+		class Bar < Foo
+		  @@y = 2
+
+		  def self.y
+		    @@y
+		  end
+		end
+
+		Bar.y
+		`, 2},
+		{`
+		class Foo
+		  @@names = []
+
+		  def self.add(name)
+		    @@names.push(name)
+		  end
+
+		  def self.names
+		    @@names
+		  end
+		end
+
+		class Bar < Foo
+		end
+
+		Foo.add("a")
+		Bar.add("b")
+		Foo.names.length
+		`, 2},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestClassVariableFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`
+		class Foo
+		  a = @@x
+		end
+		`, "NameError: uninitialized class variable @@x in Foo", 3},
+		{`
+		class Foo
+		  @@x = 1
+		end
+
+		class Bar
+		  def x
+		    @@x
+		  end
+		end
+
+		Bar.new.x
+		`, "NameError: uninitialized class variable @@x in Bar", 8},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		// The error is raised inside class body or method's frame
+		v.checkCFP(t, i, 2)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestCustomClassConstructor(t *testing.T) {
+	input := `
+		class Foo
+			def initialize(x, y)
+				@x = x
+				@y = y
+			end
+
+			def bar
+				@x + @y
+			end
+		end
+
+		f = Foo.new(10, 20)
+		f.bar
+	`
+
+	v := initTestVM()
+	evaluated := v.testEval(t, input, getFilename())
+	checkExpected(t, 0, evaluated, 30)
+	v.checkCFP(t, 0, 0)
+	v.checkSP(t, 0, 1)
+}
+
+func TestDefineMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  define_method(:bar) do
+		    10
+		  end
+		end
+
+		Foo.new.bar
+		`, 10},
+		{`
+		class Foo
+		  define_method("bar") do |a, b|
+		    a + b
+		  end
+		end
+
+		Foo.new.bar(1, 2)
+		`, 3},
+		// Method body can access instance variables and local variables around the block
+		{`
+		class Foo
+		  prefix = "get_"
+
+		  [:bar, :baz].each do |name|
+		    define_method(name) do
+		      prefix + name.to_s + @x.to_s
+		    end
+		  end
+
+		  def initialize
+		    @x = 1
+		  end
+		end
+
+		f = Foo.new
+		f.bar + " " + f.baz
+		`, "get_bar1 get_baz1"},
+		// Defined method can be overridden and inherited like normal methods
+		{`
+		class Foo
+		  define_method(:bar) do
+		    1
+		  end
+		end
+
+		class Baz < Foo
+		  def bar
+		    super + 10
+		  end
+		end
+
+		Baz.new.bar
+		`, 11},
+		{`
+		class Foo
+		  define_method(:bar) do
+		    1
+		  end
+		end
+
+		Foo.new.respond_to?(:bar)
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDefineMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`class Foo
+		  define_method(:bar) do |x|
+		    x
+		  end
+		end
+
+		Foo.new.bar
+		`, "ArgumentError: Expect at least 1 args for method 'bar'. got: 0", 7},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDefineMethodInClassBodyFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`class Foo
+		  define_method(:bar)
+		end
+		`, "InternalError: Can't define method 'bar' without a block", 2},
+		{`class Foo
+		  define_method(1) do
+		  end
+		end
+		`, "TypeError: Expect argument to be String or Symbol. got: Integer", 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		// Errors raised in class body don't pop the class's frame
+		v.checkCFP(t, i, 2)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodMissing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  def method_missing(name, *args)
+		    name.to_s + args.to_s
+		  end
+		end
+
+		Foo.new.bar(1, "a")
+		`, `bar[1, "a"]`},
+		{`
+		class Foo
+		  def method_missing(name)
+		    name
+		  end
+		end
+
+		Foo.new.bar == :bar
+		`, true},
+		// Defined methods are not affected
+		{`
+		class Foo
+		  def bar
+		    "bar"
+		  end
+
+		  def method_missing(name)
+		    "missing"
+		  end
+		end
+
+		f = Foo.new
+		f.bar + " " + f.baz
+		`, "bar missing"},
+		// method_missing is inherited
+		{`
+		class Foo
+		  def method_missing(name)
+		    name.to_s
+		  end
+		end
+
+		class Bar < Foo; end
+
+		Bar.new.baz
+		`, "baz"},
+		{`
+		class Foo
+		  def method_missing(name, x)
+		    yield(x)
+		  end
+		end
+
+		Foo.new.bar(10) do |x|
+		  x * 2
+		end
+		`, 20},
+		{`
+		class Foo
+		  def method_missing(name)
+		    name.to_s
+		  end
+		end
+
+		Foo.new.send(:bar)
+		`, "bar"},
+		{`
+		class Foo
+		  def self.method_missing(name)
+		    "class " + name.to_s
+		  end
+		end
+
+		Foo.bar
+		`, "class bar"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodMissingFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`class Foo
+		end
+
+		Foo.new.bar
+		`, "UndefinedMethodError: Undefined Method 'bar' for <Instance of: Foo>", 4},
+		{`class Foo
+		  def method_missing(name)
+		    name
+		  end
+		end
+
+		Foo.new.bar(1)
+		`, "ArgumentError: Expect at most 1 args for method 'method_missing'. got: 2", 7},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodMissingRecursionFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		// Calling a missing method inside method_missing doesn't call method_missing again
+		{`class Foo
+		  def method_missing(name)
+		    baz
+		  end
+		end
+
+		Foo.new.bar
+		`, "UndefinedMethodError: Undefined Method 'baz' for <Instance of: Foo>", 3},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		// Errors raised in method don't pop the method's frame
+		v.checkCFP(t, i, 2)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodMissingIndirectRecursionFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		// The missing method is called by another method called from method_missing
+		{`class Foo
+		  def method_missing(name, *args)
+		    helper
+		  end
+
+		  def helper
+		    undefined_thing
+		  end
+		end
+
+		Foo.new.bar
+		`, "UndefinedMethodError: Undefined Method 'undefined_thing' for <Instance of: Foo>", 7},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		// Frames of method_missing and helper are not popped
+		v.checkCFP(t, i, 3)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDefClassMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{`
+		class Foo
+		  def self.bar
+		    10
+		  end
+		end
+
+		Foo.bar
+		`, 10},
+		{`
+		module Foo
+		  def self.bar
+		    10
+		  end
+		end
+
+		Foo.bar
+		`, 10},
+		// Class methods and instance methods with the same name don't clobber each other
+		{`
+		class Foo
+		  def self.bar
+		    1
+		  end
+
+		  def bar
+		    2
+		  end
+		end
+
+		Foo.bar * 10 + Foo.new.bar
+		`, 12},
+		{`
+		class Foo
+		  def bar
+		    2
+		  end
+
+		  def self.bar
+		    1
+		  end
+		end
+
+		Foo.bar * 10 + Foo.new.bar
+		`, 12},
+		{`
+		class Foo
+		  def self.bar
+		    1
+		  end
+		end
+
+		class Foo
+		  def bar
+		    2
+		  end
+		end
+
+		class Foo
+		  def self.bar
+		    3
+		  end
+		end
+
+		Foo.bar * 10 + Foo.new.bar
+		`, 32},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestBuiltInClassMonkeyPatching(t *testing.T) {
+	input := `
+	class String
+	  def buz
+	    "buz"
+	  end
+	end
+
+	"123".buz
+	`
+
+	v := initTestVM()
+	evaluated := v.testEval(t, input, getFilename())
+	checkExpected(t, 0, evaluated, "buz")
+	v.checkCFP(t, 0, 0)
+	v.checkSP(t, 0, 1)
+}
+
+func TestPrimitiveMethodLookup(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Integer
+		  def double
+		    self * 2
+		  end
+		end
+
+		21.double
+		`, 42},
+		{`
+		class Float
+		  def half
+		    self / 2.0
+		  end
+		end
+
+		3.0.half
+		`, 1.5},
+		{`
+		class Boolean
+		  def yes?
+		    self
+		  end
+		end
+
+		true.yes?
+		`, true},
+		{`
+		class Null
+		  def blank?
+		    true
+		  end
+		end
+
+		nil.blank?
+		`, true},
+		{`
+		class Symbol
+		  def shout
+		    to_s.upcase
+		  end
+		end
+
+		:hi.shout
+		`, "HI"},
+		// Methods defined on Object are inherited by primitives
+		{`
+		class Object
+		  def hello
+		    "hello " + self.class.name
+		  end
+		end
+
+		1.hello + ", " + "a".hello + ", " + nil.hello
+		`, "hello Integer, hello String, hello Null"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodRedefinition(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  def bar
+		    1
+		  end
+		end
+
+		f = Foo.new
+		a = f.bar
+
+		class Foo
+		  def bar
+		    2
+		  end
+		end
+
+		a + f.bar
+		`, 3},
+		// Redefine method in superclass after it's been called from subclass
+		{`
+		class Foo
+		  def bar
+		    1
+		  end
+		end
+
+		class Baz < Foo; end
+
+		b = Baz.new
+		a = b.bar
+
+		class Foo
+		  def bar
+		    10
+		  end
+		end
+
+		a + b.bar
+		`, 11},
+		// Define method in subclass after superclass's method has been called
+		{`
+		class Foo
+		  def bar
+		    1
+		  end
+		end
+
+		class Baz < Foo; end
+
+		b = Baz.new
+		a = b.bar
+
+		class Baz
+		  def bar
+		    100
+		  end
+		end
+
+		a + b.bar
+		`, 101},
+		// Include a module after method has been called
+		{`
+		module Bar
+		  def bar
+		    100
+		  end
+		end
+
+		class Foo
+		  def bar
+		    1
+		  end
+		end
+
+		class Baz < Foo; end
+
+		b = Baz.new
+		a = b.bar
+
+		class Baz
+		  include Bar
+		end
+
+		a + b.bar
+		`, 101},
+		// Missing method can be called after it's defined
+		{`
+		class Foo; end
+
+		f = Foo.new
+		a = 0
+
+		begin
+		  f.bar
+		rescue
+		  a = 5
+		end
+
+		class Foo
+		  def bar
+		    1
+		  end
+		end
+
+		a + f.bar
+		`, 6},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestClassNamespace(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{`
+		module Foo
+		  class Bar
+		    def bar
+		      10
+		    end
+		  end
+		end
+
+		Foo::Bar.new.bar
+		`, 10},
+		{`
+		class Foo
+		  class Bar
+		    def bar
+		      10
+		    end
+		  end
+		end
+
+		Foo::Bar.new.bar
+		`, 10},
+		{`
+		class Foo
+		  def bar
+		    100
+		  end
+
+		  class Bar
+		    def bar
+		      10
+		    end
+		  end
+		end
+
+		Foo.new.bar + Foo::Bar.new.bar
+		`, 110},
+		{`
+		class Foo
+		  def bar
+		    100
+		  end
+		end
+
+		module Baz
+		  class Bar
+		    def bar
+		      Foo.new.bar
+		    end
+		  end
+		end
+
+		Baz::Bar.new.bar
+		`, 100},
+		{`
+		module Baz
+		  class Bar
+		    class Foo
+		      def bar
+				100
+		      end
+		    end
+		  end
+		end
+
+		Baz::Bar::Foo.new.bar
+		`, 100},
+		{`
+		module Baz
+		  class Foo
+		    def bar
+		      100
+		    end
+		  end
+
+		  class Bar
+		    def bar
+		      Foo.new.bar
+		    end
+		  end
+		end
+
+		Baz::Bar.new.bar
+		`, 100},
+		{`
+		module Baz
+		  class Bar
+		    def bar
+		      Foo.new.bar
+		    end
+
+		    class Foo
+		      def bar
+				100
+		      end
+		    end
+		  end
+		end
+
+		Baz::Bar.new.bar
+		`, 100},
+		{`
+		module Foo
+		  class Bar
+		    def bar
+		      10
+		    end
+		  end
+		end
+
+		module Baz
+		  class Bar < Foo::Bar
+		    def foo
+		      100
+		    end
+		  end
+		end
+
+		b = Baz::Bar.new
+		b.foo + b.bar
+		`, 110},
+		{`
+		module A
+		  class B
+		    class C
+		      class D
+		        def e
+		          10
+		        end
+		      end
+		    end
+		  end
+		end
+
+		A::B::C::D.new.e
+		`, 10},
+		{`
+		class Foo
+		  def self.bar
+		    10
+		  end
+		end
+
+		Object::Foo.bar
+		`, 10},
+		{`
+		Foo = 10
+
+		Object::Foo
+		`, 10},
+		{`
+		class X
+		  Bar = 100
+		end
+
+		module Foo
+		  Bar = 10
+
+		  class Baz < X
+			def self.result
+			  Bar
+			end
+		  end
+		end
+
+		Foo::Baz.result`, 10},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestConstantDefinition(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		PI = 3
+		PI
+		`, 3},
+		{`
+		class Foo
+		  VERSION = "1.0"
+		end
+
+		Foo::VERSION
+		`, "1.0"},
+		{`
+		class Foo
+		  VERSION = "1.0"
+
+		  def version
+		    VERSION
+		  end
+		end
+
+		Foo.new.version
+		`, "1.0"},
+		{`
+		PI = 3
+
+		class Foo
+		  def pi
+		    PI
+		  end
+		end
+
+		Foo.new.pi
+		`, 3},
+		// Class's constant shadows the top level one
+		{`
+		PI = 3
+
+		class Foo
+		  PI = 4
+
+		  def pi
+		    PI
+		  end
+		end
+
+		Foo.new.pi
+		`, 4},
+		{`
+		PI = 3
+
+		class Foo
+		  PI = 4
+		end
+
+		PI
+		`, 3},
+		{`
+		class Foo
+		  NAME = "foo"
+		end
+
+		class Bar < Foo
+		  def name
+		    NAME
+		  end
+		end
+
+		Bar.new.name
+		`, "foo"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestConstantReassignment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+		warning  string
+	}{
+		{`Foo = 10
+		Foo = 100
+		Foo
+		`, 100, "warning: already initialized constant Foo"},
+		{`class Foo; end
+		Foo = 100
+		Foo
+		`, 100, "warning: already initialized constant Foo"},
+		{`module Foo; end
+		Foo = 100
+		Foo
+		`, 100, "warning: already initialized constant Foo"},
+		{`class Foo
+		  Bar = 10
+		  Bar = 100
+		end
+		Foo::Bar
+		`, 100, "warning: already initialized constant Bar"},
+		// Class's constant with the same name as the top level one isn't a reassignment
+		{`Bar = 10
+		class Foo
+		  Bar = 100
+		end
+		Foo::Bar + Bar
+		`, 110, ""},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		var warnings bytes.Buffer
+		v.warningOutput = &warnings
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+
+		if !strings.Contains(warnings.String(), tt.warning) || (tt.warning == "" && warnings.Len() > 0) {
+			t.Errorf("At case %d expect warning to contain %q. got: %q", i, tt.warning, warnings.String())
+		}
+
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestConstantLookup(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		// Superclasses are searched in order
+		{`
+		class A
+		  NAME = "a"
+		end
+
+		class B < A
+		end
+
+		class C < B
+		  def name
+		    NAME
+		  end
+
+		  def self.name
+		    NAME
+		  end
+		end
+
+		C.new.name + C.name
+		`, "aa"},
+		{`
+		class A
+		  NAME = "a"
+		end
+
+		class B < A
+		  NAME = "b"
+		end
+
+		class C < B
+		  def name
+		    NAME
+		  end
+		end
+
+		C.new.name
+		`, "b"},
+		// A nested class still searches its superclass
+		{`
+		class A
+		  NAME = "a"
+		end
+
+		module M
+		  class B < A
+		    def name
+		      NAME
+		    end
+		  end
+		end
+
+		M::B.new.name
+		`, "a"},
+		// Enclosing scopes are searched before superclasses
+		{`
+		class A
+		  NAME = "a"
+		end
+
+		module M
+		  NAME = "m"
+
+		  class B < A
+		    def name
+		      NAME
+		    end
+		  end
+		end
+
+		M::B.new.name
+		`, "m"},
+		// Top-level constants are searched at last
+		{`
+		NAME = "top"
+
+		class A
+		end
+
+		module M
+		  class B < A
+		    def name
+		      NAME
+		    end
+		  end
+		end
+
+		M::B.new.name
+		`, "top"},
+		{`
+		NAME = "top"
+
+		class A
+		  NAME = "a"
+		end
+
+		class B < A
+		  def name
+		    NAME
+		  end
+		end
+
+		B.new.name
+		`, "a"},
+		// Constants are looked up from the class defining the method, not self's class
+		{`
+		class A
+		  NAME = "a"
+
+		  def name
+		    NAME
+		  end
+		end
+
+		class B < A
+		  NAME = "b"
+		end
+
+		B.new.name
+		`, "a"},
+		{`
+		module Named
+		  NAME = "named"
+
+		  def name
+		    NAME
+		  end
+		end
+
+		class Foo
+		  include Named
+		end
+
+		Foo.new.name
+		`, "named"},
+		{`
+		class Foo
+		  NAMES = ["a", "b"]
+
+		  def names
+		    [1, 2].map do |i|
+		      NAMES[i - 1]
+		    end
+		  end
+		end
+
+		Foo.new.names.to_s
+		`, `["a", "b"]`},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestConstantLookupFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		// Constants of subclasses are not visible from superclasses
+		{`
+		class A
+		  def name
+		    NAME
+		  end
+		end
+
+		class B < A
+		  NAME = "b"
+		end
+
+		B.new.name
+		`, "NameError: uninitialized constant NAME", 4},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 2)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestPrimitiveType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`100.class.name
+			`,
+			"Integer",
+		},
+		{
+			`Integer.name
+			`,
+			"Integer",
 		},
 		{
 			`"123".class.name
@@ -472,61 +1755,1325 @@ func TestPrimitiveType(t *testing.T) {
 	}
 }
 
-func TestRequireRelative(t *testing.T) {
-	input := `
-	require_relative("../test_fixtures/require_test/foo")
-
-	fifty = Foo.bar(5)
-
-	Foo.baz do |hundred|
-	  hundred + fifty + Bar.baz
-	end
-	`
-
-	v := initTestVM()
-	evaluated := v.testEval(t, input, getFilename())
-	checkExpected(t, 0, evaluated, 160)
-	v.checkCFP(t, 0, 0)
-	v.checkSP(t, 0, 1)
-}
+func TestClassMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`100.class == Integer`, true},
+		{`1.5.class == Float`, true},
+		{`"123".class == String`, true},
+		{`:foo.class == Symbol`, true},
+		{`false.class == Boolean`, true},
+		{`nil.class == Null`, true},
+		{`[1].class == Array`, true},
+		{`{ a: 1 }.class == Hash`, true},
+		{`(1..2).class == Range`, true},
+		{`Integer.class == Class`, true},
+		{`Class.class == Class`, true},
+		{`
+		class Foo; end
+		class Bar < Foo; end
+
+		Bar.new.class == Bar
+		`, true},
+		{`
+		class Foo; end
+		class Bar < Foo; end
+
+		Bar.new.class == Foo
+		`, false},
+		{`
+		class Foo; end
+
+		Foo.class == Class
+		`, true},
+		{`
+		module Foo; end
+
+		Foo.class == Class
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestClassMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`1.class(1)`, "ArgumentError: Expect 0 argument. got: 1", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRequireRelative(t *testing.T) {
+	input := `
+	require_relative("../test_fixtures/require_test/foo")
+
+	fifty = Foo.bar(5)
+
+	Foo.baz do |hundred|
+	  hundred + fifty + Bar.baz
+	end
+	`
+
+	v := initTestVM()
+	evaluated := v.testEval(t, input, getFilename())
+	checkExpected(t, 0, evaluated, 160)
+	v.checkCFP(t, 0, 0)
+	v.checkSP(t, 0, 1)
+}
+
+func TestRequireSuccess(t *testing.T) {
+	input := `
+	require "file"
+
+	File.extname("foo.rb")
+	`
+	v := initTestVM()
+	evaluated := v.testEval(t, input, getFilename())
+	checkExpected(t, 0, evaluated, ".rb")
+	v.checkCFP(t, 0, 0)
+	v.checkSP(t, 0, 1)
+}
+
+func TestRequireFail(t *testing.T) {
+	input := `require "bar"`
+	expected := `InternalError: Can't require "bar"`
+
+	v := initTestVM()
+	evaluated := v.testEval(t, input, getFilename())
+	checkError(t, 0, evaluated, expected, getFilename(), 1)
+	v.checkCFP(t, 0, 1)
+	v.checkSP(t, 0, 1)
+}
+
+func TestGeneralIsNilMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`123.nil?`, false},
+		{`"Hello World".nil?`, false},
+		{`(2..10).nil?`, false},
+		{`{ a: 1, b: "2", c: ["Goby", 123] }.nil?`, false},
+		{`[1, 2, 3, 4, 5].nil?`, false},
+		{`true.nil?`, false},
+		{`String.nil?`, false},
+		{`nil.nil?`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestGeneralIsNilMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`123.nil?("Hello")`, "ArgumentError: Expect 0 argument. got: 1", 1},
+		{`"Fail".nil?("Hello")`, "ArgumentError: Expect 0 argument. got: 1", 1},
+		{`[1, 2, 3].nil?("Hello")`, "ArgumentError: Expect 0 argument. got: 1", 1},
+		{`{ a: 1, b: 2, c: 3 }.nil?("Hello")`, "ArgumentError: Expect 0 argument. got: 1", 1},
+		{`(1..10).nil?("Hello")`, "ArgumentError: Expect 0 argument. got: 1", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRespondToMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1.respond_to?(:to_s)`, true},
+		{`1.respond_to?("to_s")`, true},
+		{`1.respond_to?(:foo)`, false},
+		{`"a".respond_to?(:upcase)`, true},
+		{`nil.respond_to?(:nil?)`, true},
+		{`String.respond_to?(:new)`, true},
+		{`String.respond_to?(:upcase)`, false},
+		{`
+		class Foo
+		  def bar; end
+		end
+
+		class Baz < Foo
+		  def self.qux; end
+		end
+
+		b = Baz.new
+		b.respond_to?(:bar).to_s + Baz.respond_to?(:qux).to_s + b.respond_to?(:qux).to_s
+		`, "truetruefalse"},
+		// Method is not invoked
+		{`
+		class Foo
+		  def bar
+		    raise "called"
+		  end
+		end
+
+		Foo.new.respond_to?(:bar)
+		`, true},
+		{`
+		class Foo; end
+
+		f = Foo.new
+		a = f.respond_to?(:bar)
+
+		class Foo
+		  def bar; end
+		end
+
+		a.to_s + f.respond_to?(:bar).to_s
+		`, "falsetrue"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRespondToMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`1.respond_to?`, "ArgumentError: Expect at least 1 args for method 'respond_to?'. got: 0", 1},
+		{`1.respond_to?(:to_s, :to_i)`, "ArgumentError: Expect at most 1 args for method 'respond_to?'. got: 2", 1},
+		{`1.respond_to?(1)`, "TypeError: Expect argument to be String or Symbol. got: Integer", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSendMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1.send("+", 2)`, 3},
+		{`"a".send(:upcase)`, "A"},
+		{`"a".public_send(:upcase)`, "A"},
+		{`[1, 2].send(:map) do |i| i * 2 end.to_s`, "[2, 4]"},
+		{`
+		class Foo
+		  def initialize(x)
+		    @x = x
+		  end
+
+		  def bar(a, b: 1)
+		    @x + a + b
+		  end
+		end
+
+		Foo.send(:new, 100).send(:bar, 10, b: 1)
+		`, 111},
+		{`
+		class Foo
+		  def each_twice
+		    yield(1)
+		    yield(2)
+		  end
+		end
+
+		sum = 0
+		Foo.new.send(:each_twice) do |i|
+		  sum = sum + i
+		end
+		sum
+		`, 3},
+		{`
+		class Foo
+		  def bar
+		    10
+		  end
+		end
+
+		Foo.new.send(:bar) do
+		  20
+		end
+		`, 10},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSendMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`1.send`, "ArgumentError: Expect at least 1 args for method 'send'. got: 0", 1},
+		{`1.public_send`, "ArgumentError: Expect at least 1 args for method 'public_send'. got: 0", 1},
+		{`1.send(1)`, "TypeError: Expect argument to be String or Symbol. got: Integer", 1},
+		{`1.send(:foo)`, "UndefinedMethodError: Undefined Method 'foo' for 1", 1},
+		{`1.public_send(:foo)`, "UndefinedMethodError: Undefined Method 'foo' for 1", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSleepMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`sleep(0)`, 0},
+		{`sleep(0.01)`, 0.01},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSleepMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`sleep`, "ArgumentError: Expect at least 1 args for method 'sleep'. got: 0", 1},
+		{`sleep("1")`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`sleep(-1)`, "ArgumentError: Expect time interval not to be negative. got: -1", 1},
+		{`sleep(-0.5)`, "ArgumentError: Expect time interval not to be negative. got: -0.5", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestClassGeneralComparisonOperation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Integer == 123`, false},
+		{`Integer == "123"`, false},
+		{`Integer == "124"`, false},
+		{`Integer == (1..3)`, false},
+		{`Integer == { a: 1, b: 2 }`, false},
+		{`Integer == [1, "String", true, 2..5]`, false},
+		{`Integer == Integer`, true},
+		{`Integer == String`, false},
+		{`123.class == Integer`, true},
+		{`Integer == Object`, false},
+		{`Integer.superclass == Object`, true},
+		{`123.class.superclass == Object`, true},
+		{`Integer != 123`, true},
+		{`Integer != "123"`, true},
+		{`Integer != "124"`, true},
+		{`Integer != (1..3)`, true},
+		{`Integer != { a: 1, b: 2 }`, true},
+		{`Integer != [1, "String", true, 2..5]`, true},
+		{`Integer != Integer`, false},
+		{`Integer != String`, true},
+		{`123.class != Integer`, false},
+		{`Integer != Object`, true},
+		{`Integer.superclass != Object`, false},
+		{`123.class.superclass != Object`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestNotEqualOperator(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1 != 2`, true},
+		{`1 != 1`, false},
+		{`1 != "a"`, true},
+		{`"a" != 1`, true},
+		{`"a" != "a"`, false},
+		{`true != false`, true},
+		{`true != 1`, true},
+		{`nil != false`, true},
+		{`[1, 2] != [1, 2]`, false},
+		{`[1, 2] != 1`, true},
+		{`{ a: 1 } != { a: 1 }`, false},
+		{`
+		class Foo
+		end
+
+		f = Foo.new
+		f != f
+		`, false},
+		// != negates the result of user-defined ==
+		{`
+		class Point
+		  attr_reader :x
+
+		  def initialize(x)
+		    @x = x
+		  end
+
+		  def ==(other)
+		    @x == other.x
+		  end
+		end
+
+		Point.new(1) != Point.new(1)
+		`, false},
+		{`
+		class Point
+		  attr_reader :x
+
+		  def initialize(x)
+		    @x = x
+		  end
+
+		  def ==(other)
+		    @x == other.x
+		  end
+		end
+
+		Point.new(1) != Point.new(2)
+		`, true},
+		{`
+		class Foo
+		  def ==(other)
+		    nil
+		  end
+		end
+
+		Foo.new != 1
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestEqualOperatorOnInstances(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		end
+
+		f = Foo.new
+		f == f
+		`, true},
+		// Instances are compared by identity by default, even if they have the same content
+		{`
+		class Foo
+		end
+
+		Foo.new == Foo.new
+		`, false},
+		{`
+		class Foo
+		end
+
+		Foo.new != Foo.new
+		`, true},
+		{`
+		class Point
+		  attr_reader :x
+
+		  def initialize(x)
+		    @x = x
+		  end
+
+		  def ==(other)
+		    other.is_a?(Point) && @x == other.x
+		  end
+		end
+
+		[Point.new(1) == Point.new(1), Point.new(1) == Point.new(2), Point.new(1) == 1].to_s
+		`, "[true, false, false]"},
+		// Calling super falls back to identity
+		{`
+		class Foo
+		  def ==(other)
+		    super(other)
+		  end
+		end
+
+		f = Foo.new
+		[f == f, f == Foo.new].to_s
+		`, "[true, false]"},
+		// Instances referencing each other don't make the comparison recurse
+		{`
+		class Node
+		  attr_accessor :other
+		end
+
+		a = Node.new
+		b = Node.new
+		a.other = b
+		b.other = a
+		[a == b, a == b.other].to_s
+		`, "[false, true]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestEqualAndHashMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		end
+
+		f = Foo.new
+		[f.equal?(f), f.equal?(Foo.new)].to_s
+		`, "[true, false]"},
+		// equal? ignores a custom ==
+		{`
+		class Foo
+		  def ==(other)
+		    true
+		  end
+		end
+
+		[Foo.new == Foo.new, Foo.new.equal?(Foo.new)].to_s
+		`, "[true, false]"},
+		{`
+		a = "foo"
+		[a.equal?(a), a.equal?("foo")].to_s
+		`, "[true, false]"},
+		{`"foo".hash == "foo".hash`, true},
+		{`"foo".hash == "bar".hash`, false},
+		{`:foo.hash == :foo.hash`, true},
+		{`1.hash == 1.hash`, true},
+		{`[1, "a"].hash == [1, "a"].hash`, true},
+		{`{ a: 1 }.hash == { a: 1 }.hash`, true},
+		{`"1".hash == 1.hash`, false},
+		{`1.hash.class.name`, "Integer"},
+		{`
+		class Foo
+		end
+
+		f = Foo.new
+		[f.hash == f.hash, f.hash == Foo.new.hash].to_s
+		`, "[true, false]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestEqualAndHashMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`1.equal?`, "ArgumentError: Expect at least 1 args for method 'equal?'. got: 0", 1},
+		{`1.equal?(1, 2)`, "ArgumentError: Expect at most 1 args for method 'equal?'. got: 2", 1},
+		{`1.hash(1)`, "ArgumentError: Expect 0 argument. got: 1", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestComparisonBySpaceshipOperator(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Version
+		  attr_reader :number
+
+		  def initialize(number)
+		    @number = number
+		  end
+
+		  def <=>(other)
+		    return nil unless other.is_a?(Version)
+		    @number <=> other.number
+		  end
+		end
+		a = Version.new(1)
+		b = Version.new(2)
+		[a < b, a <= b, a > b, a >= b, a < a, a <= a, a >= a].to_s
+		`, "[true, true, false, false, false, true, true]"},
+		{`
+		class Version
+		  attr_reader :number
+
+		  def initialize(number)
+		    @number = number
+		  end
+
+		  def <=>(other)
+		    return nil unless other.is_a?(Version)
+		    @number <=> other.number
+		  end
+		end
+		Version.new(2) <=> Version.new(1)
+		`, 1},
+		// The general <=> returns 0 for equal objects and nil for others
+		{`
+		class Foo
+		end
+
+		f = Foo.new
+		f <=> f
+		`, 0},
+		{`
+		class Foo
+		end
+
+		Foo.new <=> Foo.new
+		`, nil},
+		{`
+		class Point
+		  attr_reader :x
+
+		  def initialize(x)
+		    @x = x
+		  end
+
+		  def ==(other)
+		    @x == other.x
+		  end
+		end
+
+		Point.new(1) <=> Point.new(1)
+		`, 0},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestComparisonBySpaceshipOperatorFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`class Foo
+		end
+
+		Foo.new < Foo.new
+		`, "ArgumentError: Comparison of Foo with Foo failed", 4},
+		{`
+		class Version
+		  attr_reader :number
+
+		  def initialize(number)
+		    @number = number
+		  end
+
+		  def <=>(other)
+		    return nil unless other.is_a?(Version)
+		    @number <=> other.number
+		  end
+		end
+		Version.new(1) >= 1
+		`, "ArgumentError: Comparison of Version with Integer failed", 14},
+		{`class Foo
+		end
+
+		Foo.new.send("<=")
+		`, "ArgumentError: Expect 1 arguments. got: 0", 4},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestGeneralAssignmentByOperation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`a = 123;    a ||= 456;                  a;`, 123},
+		{`a = 123;    a ||= true;                 a;`, 123},
+		{`a = "Goby"; a ||= "Fish";               a;`, "Goby"},
+		{`a = (1..3); a ||= [1, 2, 3];          a.to_s;`, "(1..3)"},
+		{`a = false;  a ||= 123;                  a;`, 123},
+		{`a = nil;    a ||= { b: 1 };             a["b"];`, 1},
+		{`a = false;  a ||= false;                a;`, false},
+		{`a = nil;    a ||= false;                a;`, false},
+		{`a = false;  a ||= nil;                  a;`, nil},
+		{`a = nil;    a ||= nil;                  a;`, nil},
+		{`a = false;  a ||= nil || false;         a;`, false},
+		{`a = false;  a ||= false || nil;         a;`, nil},
+		{`a = false;  a ||= true && false || nil; a;`, nil},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestGeneralIsAMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`123.is_a?(Integer)`, true},
+		{`123.is_a?(Object)`, true},
+		{`123.is_a?(String)`, false},
+		{`123.is_a?(Range)`, false},
+		{`"Hello World".is_a?(String)`, true},
+		{`"Hello World".is_a?(Object)`, true},
+		{`"Hello World".is_a?(Boolean)`, false},
+		{`"Hello World".is_a?(Array)`, false},
+		{`(2..10).is_a?(Range)`, true},
+		{`(2..10).is_a?(Object)`, true},
+		{`(2..10).is_a?(Null)`, false},
+		{`(2..10).is_a?(Hash)`, false},
+		{`{ a: 1, b: "2", c: ["Goby", 123] }.is_a?(Hash)`, true},
+		{`{ a: 1, b: "2", c: ["Goby", 123] }.is_a?(Object)`, true},
+		{`{ a: 1, b: "2", c: ["Goby", 123] }.is_a?(Class)`, false},
+		{`{ a: 1, b: "2", c: ["Goby", 123] }.is_a?(Array)`, false},
+		{`[1, 2, 3, 4, 5].is_a?(Array)`, true},
+		{`[1, 2, 3, 4, 5].is_a?(Object)`, true},
+		{`[1, 2, 3, 4, 5].is_a?(Null)`, false},
+		{`[1, 2, 3, 4, 5].is_a?(String)`, false},
+		{`true.is_a?(Boolean)`, true},
+		{`true.is_a?(Object)`, true},
+		{`true.is_a?(Array)`, false},
+		{`true.is_a?(Integer)`, false},
+		{`String.is_a?(Class)`, true},
+		{`String.is_a?(String)`, false},
+		{`String.is_a?(Array)`, false},
+		{`nil.is_a?(Null)`, true},
+		{`nil.is_a?(Object)`, true},
+		{`nil.is_a?(String)`, false},
+		{`nil.is_a?(Range)`, false},
+		{`123.kind_of?(Integer)`, true},
+		{`123.kind_of?(Object)`, true},
+		{`123.kind_of?(String)`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestGeneralIsAMethodWithInheritance(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`
+		class Animal; end
+		class Dog < Animal; end
+
+		Dog.new.is_a?(Dog)
+		`, true},
+		{`
+		class Animal; end
+		class Dog < Animal; end
+
+		Dog.new.is_a?(Animal)
+		`, true},
+		{`
+		class Animal; end
+		class Dog < Animal; end
+		class Puppy < Dog; end
+
+		Puppy.new.kind_of?(Animal)
+		`, true},
+		{`
+		class Animal; end
+		class Dog < Animal; end
+
+		Animal.new.is_a?(Dog)
+		`, false},
+		{`
+		class Animal; end
+		class Car; end
+
+		Car.new.kind_of?(Animal)
+		`, false},
+		{`
+		module Walkable; end
+
+		class Animal
+		  include Walkable
+		end
+
+		class Dog < Animal; end
+
+		Dog.new.is_a?(Walkable)
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestPutsAndPrintMethod(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedOutput string
+	}{
+		{`puts("foo", "bar")`, "foo\nbar\n"},
+		{`puts "Hello #{1 + 2}"`, "Hello 3\n"},
+		{`puts(1, [1, "a"], nil)`, "1\n1\na\n\n"},
+		{`puts([1, [2, [3, "b"]], 4])`, "1\n2\n3\nb\n4\n"},
+		{`puts([])`, "\n"},
+		{`puts([1, []], [[]])`, "1\n\n\n"},
+		{`
+		a = [1]
+		a.push(a)
+		puts(a)
+		`, "1\n[...]\n"},
+		{`puts({ a: [1, 2] })`, "{ a: [1, 2] }\n"},
+		{`print([1, [2]], "!")`, "[1, [2]]!"},
+		{`puts("#{[1, 2]}")`, "[1, 2]\n"},
+		{`print("foo", "bar")`, "foobar"},
+		{`print 1, 2.5`, "12.5"},
+		{`print()`, ""},
+		{`
+		class Foo
+		  def to_s
+		    "I'm Foo"
+		  end
+		end
+
+		puts(Foo.new)
+		print(Foo.new, "!")
+		`, "I'm Foo\nI'm Foo!"},
+		{`
+		class Foo; end
+		puts(Foo.new)
+		`, "<Instance of: Foo>\n"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		var evaluated Object
+		output := captureStdout(t, func() {
+			evaluated = v.testEval(t, tt.input, getFilename())
+		})
+
+		if output != tt.expectedOutput {
+			t.Fatalf("At test case %d: expect output to be %q. got: %q", i, tt.expectedOutput, output)
+		}
+		checkExpected(t, i, evaluated, nil)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestPMethod(t *testing.T) {
+	tests := []struct {
+		input          string
+		expected       interface{}
+		expectedOutput string
+	}{
+		{`p("foo")`, "foo", "\"foo\"\n"},
+		{`p "a\nb"`, "a\nb", "\"a\\nb\"\n"},
+		{`p(1) + 1`, 2, "1\n"},
+		{`p(nil)`, nil, "nil\n"},
+		{`p()`, nil, ""},
+		{`p(:foo, 1.5).to_s`, "[:foo, 1.5]", ":foo\n1.5\n"},
+		{`p([1, "a", nil]).length`, 3, "[1, \"a\", nil]\n"},
+		{`p({ a: "b" })[:a]`, "b", "{ a: \"b\" }\n"},
+		// A custom to_s isn't used
+		{`
+		class Foo
+		  def to_s
+		    "I'm Foo"
+		  end
+		end
+
+		p(Foo.new).class.name
+		`, "Foo", "<Instance of: Foo>\n"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		var evaluated Object
+		output := captureStdout(t, func() {
+			evaluated = v.testEval(t, tt.input, getFilename())
+		})
+
+		if output != tt.expectedOutput {
+			t.Fatalf("At test case %d: expect output to be %q. got: %q", i, tt.expectedOutput, output)
+		}
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestToSMethodOverride(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo; end
+		Foo.new.to_s
+		`, "<Instance of: Foo>"},
+		{`
+		class Foo
+		  def initialize(name)
+		    @name = name
+		  end
+
+		  def to_s
+		    "Foo(" + @name + ")"
+		  end
+		end
+
+		"I'm #{Foo.new("bar")}"
+		`, "I'm Foo(bar)"},
+		{`
+		class Integer
+		  def to_s
+		    "int"
+		  end
+		end
+
+		"#{1}"
+		`, "int"},
+		{`1.to_s`, "1"},
+		{`true.to_s`, "true"},
+		{`"foo".to_s`, "foo"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestPutsMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`class Foo
+		  def to_s
+		    bar
+		  end
+		end
+
+		puts(Foo.new)
+		`, "UndefinedMethodError: Undefined Method 'bar' for <Instance of: Foo>", 3},
+		{`class Foo
+		  def to_s
+		    bar
+		  end
+		end
+
+		puts([1, [Foo.new]])
+		`, "UndefinedMethodError: Undefined Method 'bar' for <Instance of: Foo>", 3},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		// The error is raised inside to_s method's frame
+		v.checkCFP(t, i, 2)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRaiseMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		begin
+		  raise "Oops"
+		rescue => e
+		  e.class.name
+		end
+		`, "RuntimeError"},
+		{`
+		begin
+		  raise ArgumentError, "Bad argument"
+		rescue => e
+		  e.message
+		end
+		`, "ArgumentError: Bad argument. At " + getFilename() + ":3"},
+		{`
+		def foo
+		  raise "Oops"
+		end
+
+		def bar
+		  foo
+		  10
+		end
+
+		begin
+		  bar
+		rescue => e
+		  e.message
+		end
+		`, "RuntimeError: Oops. At " + getFilename() + ":3"},
+		{`
+		begin
+		  begin
+		    10 / 0
+		  rescue => e
+		    raise e
+		  end
+		rescue => err
+		  err.message
+		end
+		`, "ZeroDivisionError: Divided by 0. At " + getFilename() + ":4"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRaiseMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`raise`, "RuntimeError: unhandled error", 1},
+		{`raise "Oops"`, "RuntimeError: Oops", 1},
+		{`raise ArgumentError`, "ArgumentError: unhandled error", 1},
+		{`raise TypeError, "Bad type"`, "TypeError: Bad type", 1},
+		{`raise String`, "TypeError: Expect argument to be an error class. got: String", 1},
+		{`raise 1`, "TypeError: Expect argument to be an error class, String or error. got: Integer", 1},
+		{`raise ArgumentError, 1`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`raise "Oops", "Oops"`, "ArgumentError: Expect 1 arguments. got: 2", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRaiseMethodInMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`def foo
+		  raise "Oops"
+		end
+
+		foo
+		`, "RuntimeError: Oops", 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		// The error is raised inside foo method's frame
+		v.checkCFP(t, i, 2)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func captureStdout(t *testing.T, fn func()) string {
+	r, w, err := os.Pipe()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = stdout
+	w.Close()
+
+	output, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(output)
+}
+
+func TestBlockGivenMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`block_given?`, false},
+		{`
+		def foo
+		  block_given?
+		end
+
+		foo
+		`, false},
+		{`
+		def foo
+		  block_given?
+		end
+
+		foo do
+		end
+		`, true},
+		{`
+		def foo
+		  if block_given?
+		    yield
+		  else
+		    "no block"
+		  end
+		end
+
+		foo
+		`, "no block"},
+		{`
+		def foo
+		  if block_given?
+		    yield
+		  else
+		    "no block"
+		  end
+		end
+
+		foo do
+		  "block"
+		end
+		`, "block"},
+		// Outer method's block shouldn't leak into inner method
+		{`
+		def inner
+		  block_given?
+		end
+
+		def outer
+		  inner
+		end
+
+		outer do
+		end
+		`, false},
+		// A block inside the method isn't the block given to the method
+		{`
+		def foo
+		  r = nil
+		  [1].each do |i|
+		    r = block_given?
+		  end
+		  r
+		end
+
+		foo
+		`, false},
+		{`
+		def foo
+		  r = nil
+		  [1].each do |i|
+		    r = block_given?
+		  end
+		  r
+		end
+
+		foo do
+		end
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestLoopMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		i = 0
+		loop do
+		  i += 1
+		  break if i == 5
+		end
+		i
+		`, 5},
+		{`
+		i = 0
+		loop do
+		  i += 1
+		  break i * 10 if i == 3
+		end
+		`, 30},
+		{`
+		i = 0
+		sum = 0
+		loop do
+		  i += 1
+		  next if i.even?
+		  break if i > 5
+		  sum += i
+		end
+		sum
+		`, 9},
+		{`
+		def foo
+		  loop do
+		    yield
+		  end
+		end
 
-func TestRequireSuccess(t *testing.T) {
-	input := `
-	require "file"
+		foo do
+		  break 10
+		end
+		`, 10},
+		// Returns an Enumerator that never ends without a block
+		{`loop.class.name`, "Enumerator"},
+	}
 
-	File.extname("foo.rb")
-	`
-	v := initTestVM()
-	evaluated := v.testEval(t, input, getFilename())
-	checkExpected(t, 0, evaluated, ".rb")
-	v.checkCFP(t, 0, 0)
-	v.checkSP(t, 0, 1)
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
 }
 
-func TestRequireFail(t *testing.T) {
-	input := `require "bar"`
-	expected := `InternalError: Can't require "bar"`
+func TestLoopMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`loop(1) do
+		  break
+		end`, "ArgumentError: Expect 0 argument. got: 1", 1},
+	}
 
-	v := initTestVM()
-	evaluated := v.testEval(t, input, getFilename())
-	checkError(t, 0, evaluated, expected, getFilename(), 1)
-	v.checkCFP(t, 0, 1)
-	v.checkSP(t, 0, 1)
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
 }
 
-func TestGeneralIsNilMethod(t *testing.T) {
+func TestTapAndThenMethods(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected bool
+		expected interface{}
 	}{
-		{`123.nil?`, false},
-		{`"Hello World".nil?`, false},
-		{`(2..10).nil?`, false},
-		{`{ a: 1, b: "2", c: ["Goby", 123] }.nil?`, false},
-		{`[1, 2, 3, 4, 5].nil?`, false},
-		{`true.nil?`, false},
-		{`String.nil?`, false},
-		{`nil.nil?`, true},
+		{`[1, 2].tap { |a| a.push(3) }.to_s`, "[1, 2, 3]"},
+		{`5.tap { |i| i * 100 }`, 5},
+		{`
+		seen = nil
+		r = "goby".tap do |s|
+		  seen = s
+		end
+		seen + r
+		`, "gobygoby"},
+		{`3.then { |i| i * 2 }`, 6},
+		{`"goby".then { |s| s.upcase }`, "GOBY"},
+		{`3.yield_self { |i| i + 1 }`, 4},
+		{`nil.then { |n| n.nil? }`, true},
+		{`
+		begin
+		  1.tap { |i| raise("boom") }
+		rescue => e
+		  e.class.name
+		end
+		`, "RuntimeError"},
 	}
 
 	for i, tt := range tests {
@@ -538,13 +3085,11 @@ func TestGeneralIsNilMethod(t *testing.T) {
 	}
 }
 
-func TestGeneralIsNilMethodFail(t *testing.T) {
+func TestTapAndThenMethodsFail(t *testing.T) {
 	testsFail := []errorTestCase{
-		{`123.nil?("Hello")`, "ArgumentError: Expect 0 argument. got: 1", 1},
-		{`"Fail".nil?("Hello")`, "ArgumentError: Expect 0 argument. got: 1", 1},
-		{`[1, 2, 3].nil?("Hello")`, "ArgumentError: Expect 0 argument. got: 1", 1},
-		{`{ a: 1, b: 2, c: 3 }.nil?("Hello")`, "ArgumentError: Expect 0 argument. got: 1", 1},
-		{`(1..10).nil?("Hello")`, "ArgumentError: Expect 0 argument. got: 1", 1},
+		{`1.tap`, "InternalError: Can't yield without a block", 1},
+		{`1.then`, "InternalError: Can't yield without a block", 1},
+		{`1.tap(2) { |i| i }`, "ArgumentError: Expect 0 argument. got: 1", 1},
 	}
 
 	for i, tt := range testsFail {
@@ -556,35 +3101,43 @@ func TestGeneralIsNilMethodFail(t *testing.T) {
 	}
 }
 
-func TestClassGeneralComparisonOperation(t *testing.T) {
+func TestLoopMethodFailInBlock(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`loop do
+		  foo
+		end`, "UndefinedMethodError: Undefined Method 'foo' for <Instance of: Object>", 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		// The error is raised inside the block's frame, which stays above its block frame
+		v.checkCFP(t, i, 3)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFreezeMethod(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected interface{}
 	}{
-		{`Integer == 123`, false},
-		{`Integer == "123"`, false},
-		{`Integer == "124"`, false},
-		{`Integer == (1..3)`, false},
-		{`Integer == { a: 1, b: 2 }`, false},
-		{`Integer == [1, "String", true, 2..5]`, false},
-		{`Integer == Integer`, true},
-		{`Integer == String`, false},
-		{`123.class == Integer`, true},
-		{`Integer == Object`, false},
-		{`Integer.superclass == Object`, true},
-		{`123.class.superclass == Object`, true},
-		{`Integer != 123`, true},
-		{`Integer != "123"`, true},
-		{`Integer != "124"`, true},
-		{`Integer != (1..3)`, true},
-		{`Integer != { a: 1, b: 2 }`, true},
-		{`Integer != [1, "String", true, 2..5]`, true},
-		{`Integer != Integer`, false},
-		{`Integer != String`, true},
-		{`123.class != Integer`, false},
-		{`Integer != Object`, true},
-		{`Integer.superclass != Object`, false},
-		{`123.class.superclass != Object`, false},
+		{`Object.new.frozen?`, false},
+		{`Object.new.freeze.frozen?`, true},
+		{`"foo".freeze.frozen?`, true},
+		{`
+		class Foo
+		  attr_reader :bar
+
+		  def initialize
+		    @bar = 10
+		  end
+		end
+
+		f = Foo.new.freeze
+		f.bar + f.instance_variable_get("@bar")
+		`, 20},
 	}
 
 	for i, tt := range tests {
@@ -596,24 +3149,109 @@ func TestClassGeneralComparisonOperation(t *testing.T) {
 	}
 }
 
-func TestGeneralAssignmentByOperation(t *testing.T) {
+func TestFreezeMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Object.new.freeze(1)`, "ArgumentError: Expect 0 argument. got: 1", 1},
+		{`
+		class Foo
+		  attr_writer :bar
+		end
+
+		Foo.new.freeze.bar = 1
+		`, "FrozenError: Can't modify frozen Foo: <Instance of: Foo>", 6},
+		{`Object.new.freeze.instance_variable_set("@bar", 1)`, "FrozenError: Can't modify frozen Object: <Instance of: Object>", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFreezeMethodFailInMethod(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`
+		class Foo
+		  def set
+		    @bar = 1
+		  end
+		end
+
+		Foo.new.freeze.set
+		`, "FrozenError: Can't modify frozen Foo: <Instance of: Foo>", 4},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		// The error is raised inside the method's frame
+		v.checkCFP(t, i, 2)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDupMethod(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected interface{}
 	}{
-		{`a = 123;    a ||= 456;                  a;`, 123},
-		{`a = 123;    a ||= true;                 a;`, 123},
-		{`a = "Goby"; a ||= "Fish";               a;`, "Goby"},
-		{`a = (1..3); a ||= [1, 2, 3];          a.to_s;`, "(1..3)"},
-		{`a = false;  a ||= 123;                  a;`, 123},
-		{`a = nil;    a ||= { b: 1 };             a["b"];`, 1},
-		{`a = false;  a ||= false;                a;`, false},
-		{`a = nil;    a ||= false;                a;`, false},
-		{`a = false;  a ||= nil;                  a;`, nil},
-		{`a = nil;    a ||= nil;                  a;`, nil},
-		{`a = false;  a ||= nil || false;         a;`, false},
-		{`a = false;  a ||= false || nil;         a;`, nil},
-		{`a = false;  a ||= true && false || nil; a;`, nil},
+		{`
+		class Foo
+		  attr_accessor :bar
+
+		  def initialize
+		    @bar = 1
+		  end
+		end
+
+		f = Foo.new
+		d = f.dup
+		d.bar = 2
+		f.bar.to_s + d.bar.to_s + d.class.name
+		`, "12Foo"},
+		{`
+		class Foo
+		  attr_reader :bar
+
+		  def initialize
+		    @bar = [1]
+		  end
+		end
+
+		f = Foo.new
+		f.dup.bar.push(2)
+		f.bar.to_s
+		`, "[1, 2]"},
+		{`
+		a = [[1], 2]
+		b = a.dup
+		b.push(3)
+		b[0].push(4)
+		a.to_s + b.to_s
+		`, "[[1, 4], 2][[1, 4], 2, 3]"},
+		{`
+		h = { a: 1 }
+		c = h.dup
+		c["b"] = 2
+		h.to_s + c.to_s
+		`, "{ a: 1 }{ a: 1, b: 2 }"},
+		{`"foo".dup`, "foo"},
+		{`1.dup`, 1},
+		{`nil.dup`, nil},
+		{`[1].freeze.dup.frozen?`, false},
+		{`[1].freeze.clone.frozen?`, true},
+		{`[1].clone.frozen?`, false},
+		{`Object.new.freeze.clone.frozen?`, true},
+		{`
+		a = [1].freeze
+		b = a.dup
+		b.push(2)
+		b.to_s
+		`, "[1, 2]"},
 	}
 
 	for i, tt := range tests {
@@ -625,49 +3263,22 @@ func TestGeneralAssignmentByOperation(t *testing.T) {
 	}
 }
 
-func TestGeneralIsAMethod(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected bool
-	}{
-		{`123.is_a?(Integer)`, true},
-		{`123.is_a?(Object)`, true},
-		{`123.is_a?(String)`, false},
-		{`123.is_a?(Range)`, false},
-		{`"Hello World".is_a?(String)`, true},
-		{`"Hello World".is_a?(Object)`, true},
-		{`"Hello World".is_a?(Boolean)`, false},
-		{`"Hello World".is_a?(Array)`, false},
-		{`(2..10).is_a?(Range)`, true},
-		{`(2..10).is_a?(Object)`, true},
-		{`(2..10).is_a?(Null)`, false},
-		{`(2..10).is_a?(Hash)`, false},
-		{`{ a: 1, b: "2", c: ["Goby", 123] }.is_a?(Hash)`, true},
-		{`{ a: 1, b: "2", c: ["Goby", 123] }.is_a?(Object)`, true},
-		{`{ a: 1, b: "2", c: ["Goby", 123] }.is_a?(Class)`, false},
-		{`{ a: 1, b: "2", c: ["Goby", 123] }.is_a?(Array)`, false},
-		{`[1, 2, 3, 4, 5].is_a?(Array)`, true},
-		{`[1, 2, 3, 4, 5].is_a?(Object)`, true},
-		{`[1, 2, 3, 4, 5].is_a?(Null)`, false},
-		{`[1, 2, 3, 4, 5].is_a?(String)`, false},
-		{`true.is_a?(Boolean)`, true},
-		{`true.is_a?(Object)`, true},
-		{`true.is_a?(Array)`, false},
-		{`true.is_a?(Integer)`, false},
-		{`String.is_a?(Class)`, true},
-		{`String.is_a?(String)`, false},
-		{`String.is_a?(Array)`, false},
-		{`nil.is_a?(Null)`, true},
-		{`nil.is_a?(Object)`, true},
-		{`nil.is_a?(String)`, false},
-		{`nil.is_a?(Range)`, false},
+func TestDupMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1].dup(1)`, "ArgumentError: Expect 0 argument. got: 1", 1},
+		{`Object.dup`, "UnsupportedMethodError: Unsupported Method #dup for Object", 1},
+		{`Object.clone`, "UnsupportedMethodError: Unsupported Method #clone for Object", 1},
+		{`
+		a = [1].freeze.clone
+		a.push(2)
+		`, "FrozenError: Can't modify frozen Array: [1]", 3},
 	}
 
-	for i, tt := range tests {
+	for i, tt := range testsFail {
 		v := initTestVM()
 		evaluated := v.testEval(t, tt.input, getFilename())
-		checkExpected(t, i, evaluated, tt.expected)
-		v.checkCFP(t, i, 0)
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
 		v.checkSP(t, i, 1)
 	}
 }
@@ -680,6 +3291,8 @@ func TestGeneralIsAMethodFail(t *testing.T) {
 		{`123.is_a?(Integer, String)`, "ArgumentError: Expect 1 argument. got: 2", 1},
 		{`123.is_a?(true)`, "TypeError: Expect argument to be Class. got: Boolean", 1},
 		{`Class.is_a?(true)`, "TypeError: Expect argument to be Class. got: Boolean", 1},
+		{`123.kind_of?`, "ArgumentError: Expect 1 argument. got: 0", 1},
+		{`123.kind_of?(123)`, "TypeError: Expect argument to be Class. got: Integer", 1},
 	}
 
 	for i, tt := range testsFail {
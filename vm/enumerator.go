@@ -0,0 +1,287 @@
+package vm
+
+import "fmt"
+
+// initEnumeratorObject returns an enumerator over the values of given method called on source.
+// A negative size means the enumerator never ends.
+func (vm *VM) initEnumeratorObject(source Object, methodName string, size int, valueAt func(i int) Object) *EnumeratorObject {
+	return &EnumeratorObject{
+		baseObj:    &baseObj{class: vm.topLevelClass(enumeratorClass)},
+		source:     source,
+		methodName: methodName,
+		size:       size,
+		valueAt:    valueAt,
+	}
+}
+
+func (vm *VM) initEnumeratorClass() *RClass {
+	ec := vm.initializeClass(enumeratorClass, false)
+	ec.setBuiltInMethods(builtinEnumeratorInstanceMethods(), false)
+	ec.setBuiltInMethods(builtInEnumeratorClassMethods(), true)
+	return ec
+}
+
+// EnumeratorObject is returned by iterating methods called without a block, like `Integer#times` and `loop`.
+// It produces the values lazily, so it can be driven by `each` or `map` later, or step by step with `next`.
+//
+// ```ruby
+// e = 3.times
+// e.next                 # => 0
+// e.next                 # => 1
+// e.map { |i| i * 2 }    # => [0, 2, 4]
+// ```
+//
+// - `Enumerator.new` is not supported.
+type EnumeratorObject struct {
+	*baseObj
+	source     Object
+	methodName string
+	size       int
+	valueAt    func(i int) Object
+	position   int
+}
+
+// Polymorphic helper functions -----------------------------------------
+func (e *EnumeratorObject) toString() string {
+	return fmt.Sprintf("#<Enumerator: %s:%s>", e.source.toString(), e.methodName)
+}
+
+func (e *EnumeratorObject) toJSON() string {
+	return e.toString()
+}
+
+func (e *EnumeratorObject) infinite() bool {
+	return e.size < 0
+}
+
+// iterate yields the block with every value from the beginning and returns the block's results
+func (e *EnumeratorObject) iterate(t *thread, blockFrame *callFrame) []Object {
+	results := []Object{}
+
+	for i := 0; e.infinite() || i < e.size; i++ {
+		results = append(results, t.builtInMethodYield(blockFrame, e.valueAt(i)).Target)
+	}
+
+	return results
+}
+
+func builtInEnumeratorClassMethods() []*BuiltInMethodObject {
+	return []*BuiltInMethodObject{
+		{
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.unsupportedMethodError("#new", receiver)
+				}
+			},
+		},
+	}
+}
+
+func builtinEnumeratorInstanceMethods() []*BuiltInMethodObject {
+	return []*BuiltInMethodObject{
+		{
+			// Yields the block with every value from the beginning, and returns the object the enumerator is created from.
+			// It doesn't change the position of `next`.
+			//
+			// ```ruby
+			// 3.times.each { |i| puts(i) } # prints 0, 1, 2 and returns 3
+			// ```
+			//
+			// @return [Object]
+			Name: "each",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					e := receiver.(*EnumeratorObject)
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
+					}
+
+					e.iterate(t, blockFrame)
+
+					return e.source
+				}
+			},
+		},
+		{
+			// Yields the block with every value from the beginning, and returns an array of the block's results.
+			//
+			// ```ruby
+			// 5.times.map { |i| i * 2 } # => [0, 2, 4, 6, 8]
+			// ```
+			//
+			// @return [Array]
+			Name: "map",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					e := receiver.(*EnumeratorObject)
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
+					}
+
+					return t.vm.initArrayObject(e.iterate(t, blockFrame))
+				}
+			},
+		},
+		{
+			// Returns the next value and moves the position forward.
+			// Raises StopIteration when there's no more value.
+			//
+			// ```ruby
+			// e = 2.times
+			// e.next # => 0
+			// e.next # => 1
+			// e.next # => StopIteration: iteration reached an end
+			// ```
+			//
+			// @return [Object]
+			Name: "next",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					e := receiver.(*EnumeratorObject)
+
+					if !e.infinite() && e.position >= e.size {
+						return t.vm.initErrorObject(StopIteration, "iteration reached an end")
+					}
+
+					value := e.valueAt(e.position)
+					e.position++
+
+					return value
+				}
+			},
+		},
+		{
+			// Returns the next value without moving the position forward.
+			// Raises StopIteration when there's no more value.
+			//
+			// ```ruby
+			// e = 2.times
+			// e.peek # => 0
+			// e.next # => 0
+			// e.peek # => 1
+			// ```
+			//
+			// @return [Object]
+			Name: "peek",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					e := receiver.(*EnumeratorObject)
+
+					if !e.infinite() && e.position >= e.size {
+						return t.vm.initErrorObject(StopIteration, "iteration reached an end")
+					}
+
+					return e.valueAt(e.position)
+				}
+			},
+		},
+		{
+			// Moves the position of `next` back to the beginning and returns self.
+			//
+			// ```ruby
+			// e = 2.times
+			// e.next   # => 0
+			// e.rewind
+			// e.next   # => 0
+			// ```
+			//
+			// @return [Enumerator]
+			Name: "rewind",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					e := receiver.(*EnumeratorObject)
+					e.position = 0
+
+					return e
+				}
+			},
+		},
+		{
+			// Returns the number of values, or nil if the enumerator never ends.
+			//
+			// ```ruby
+			// 3.times.size # => 3
+			// loop.size    # => nil
+			// ```
+			//
+			// @return [Integer]
+			Name: "size",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					e := receiver.(*EnumeratorObject)
+
+					if e.infinite() {
+						return NULL
+					}
+
+					return t.vm.initIntegerObject(e.size)
+				}
+			},
+		},
+		{
+			// Returns an array of all values. An enumerator that never ends can't be converted.
+			//
+			// ```ruby
+			// 3.times.to_a # => [0, 1, 2]
+			// ```
+			//
+			// @return [Array]
+			Name: "to_a",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					e := receiver.(*EnumeratorObject)
+
+					if e.infinite() {
+						return t.vm.initErrorObject(ArgumentError, "Can't convert an infinite enumerator into an array")
+					}
+
+					values := []Object{}
+
+					for i := 0; i < e.size; i++ {
+						values = append(values, e.valueAt(i))
+					}
+
+					return t.vm.initArrayObject(values)
+				}
+			},
+		},
+		{
+			// Returns a string describing the enumerator.
+			//
+			// ```ruby
+			// 3.times.to_s # => "#<Enumerator: 3:times>"
+			// ```
+			//
+			// @return [String]
+			Name: "to_s",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.toString())
+				}
+			},
+		},
+	}
+}
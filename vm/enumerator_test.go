@@ -0,0 +1,164 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestEnumeratorClassSuperclass(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`Enumerator.class.name`, "Class"},
+		{`Enumerator.superclass.name`, "Object"},
+		{`3.times.class.name`, "Enumerator"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestEnumeratorNextMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`3.times.next`, 0},
+		{`
+		e = 3.times
+		e.next
+		e.next
+		`, 1},
+		{`
+		e = 3.times
+		e.next
+		e.peek
+		`, 1},
+		{`
+		e = 3.times
+		e.peek
+		e.next
+		`, 0},
+		{`
+		e = 2.times
+		e.next
+		e.next
+		e.rewind
+		e.next
+		`, 0},
+		{`
+		e = 1.times
+		e.next
+		begin
+		  e.next
+		rescue => err
+		  err.class.name
+		end
+		`, "StopIteration"},
+		{`
+		e = loop
+		e.next
+		e.next
+		`, nil},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestEnumeratorNextMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`0.times.next`, "StopIteration: iteration reached an end", 1},
+		{`
+		e = 2.times
+		e.next
+		e.next
+		e.next
+		`, "StopIteration: iteration reached an end", 5},
+		{`0.times.peek`, "StopIteration: iteration reached an end", 1},
+		{`3.times.next(1)`, "ArgumentError: Expect 0 argument. got: 1", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestEnumeratorIteration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`5.times.map { |i| i * 2 }.to_s`, "[0, 2, 4, 6, 8]"},
+		{`0.times.map { |i| i * 2 }.to_s`, "[]"},
+		{`
+		sum = 0
+		4.times.each do |i|
+		  sum += i
+		end
+		sum
+		`, 6},
+		{`3.times.each { |i| i }`, 3},
+		// `each` and `map` don't depend on the position of `next`
+		{`
+		e = 3.times
+		e.next
+		e.map { |i| i }.to_s
+		`, "[0, 1, 2]"},
+		{`
+		e = 3.times
+		e.next
+		e.next
+		`, 1},
+		{`
+		i = 0
+		loop.each do
+		  i += 1
+		  break i * 10 if i == 3
+		end
+		`, 30},
+		{`3.times.to_a.to_s`, "[0, 1, 2]"},
+		{`3.times.size`, 3},
+		{`loop.size`, nil},
+		{`3.times.to_s`, "#<Enumerator: 3:times>"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestEnumeratorIterationFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`3.times.each`, "InternalError: Can't yield without a block", 1},
+		{`3.times.map`, "InternalError: Can't yield without a block", 1},
+		{`loop.to_a`, "ArgumentError: Can't convert an infinite enumerator into an array", 1},
+		{`Enumerator.new`, "UnsupportedMethodError: Unsupported Method #new for Enumerator", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
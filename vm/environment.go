@@ -1,5 +1,7 @@
 package vm
 
+import "sync"
+
 func newEnvironment() *environment {
 	s := make(map[string]Object)
 	return &environment{store: s, outer: nil}
@@ -22,3 +24,40 @@ func (e *environment) set(name string, val Object) Object {
 	e.store[name] = val
 	return val
 }
+
+// copy returns a new environment with the same variables, their values are shared with the original one
+func (e *environment) copy() *environment {
+	if e == nil {
+		return nil
+	}
+
+	s := make(map[string]Object, len(e.store))
+
+	for name, val := range e.store {
+		s[name] = val
+	}
+
+	return &environment{store: s, outer: e.outer}
+}
+
+// globalVariableTable stores global variables like `$config`, which are shared by every scope and thread
+type globalVariableTable struct {
+	store map[string]Object
+	sync.RWMutex
+}
+
+func (g *globalVariableTable) get(name string) (Object, bool) {
+	g.RLock()
+	defer g.RUnlock()
+
+	obj, ok := g.store[name]
+	return obj, ok
+}
+
+func (g *globalVariableTable) set(name string, val Object) Object {
+	g.Lock()
+	defer g.Unlock()
+
+	g.store[name] = val
+	return val
+}
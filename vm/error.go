@@ -19,8 +19,18 @@ const (
 	UnsupportedMethodError = "UnsupportedMethodError"
 	// ConstantAlreadyInitializedError means user re-declares twice
 	ConstantAlreadyInitializedError = "ConstantAlreadyInitializedError"
+	// ZeroDivisionError is for an Integer being divided by zero
+	ZeroDivisionError = "ZeroDivisionError"
+	// RuntimeError is the default error type raised by `raise`
+	RuntimeError = "RuntimeError"
+	// FrozenError is for modifying a frozen object
+	FrozenError = "FrozenError"
+	// StopIteration is for calling `next` on an exhausted enumerator
+	StopIteration = "StopIteration"
 )
 
+var errorTypes = []string{InternalError, ArgumentError, NameError, TypeError, UndefinedMethodError, UnsupportedMethodError, ConstantAlreadyInitializedError, ZeroDivisionError, RuntimeError, FrozenError, StopIteration}
+
 func (vm *VM) initErrorObject(errorType, format string, args ...interface{}) *Error {
 	errClass := vm.objectClass.getClassConstant(errorType)
 
@@ -43,10 +53,9 @@ func (vm *VM) initErrorObject(errorType, format string, args ...interface{}) *Er
 }
 
 func (vm *VM) initErrorClasses() {
-	errTypes := []string{InternalError, ArgumentError, NameError, TypeError, UndefinedMethodError, UnsupportedMethodError, ConstantAlreadyInitializedError}
-
-	for _, errType := range errTypes {
+	for _, errType := range errorTypes {
 		c := vm.initializeClass(errType, false)
+		c.setBuiltInMethods(builtinErrorInstanceMethods(), false)
 		vm.objectClass.setClassConstant(c)
 	}
 }
@@ -58,6 +67,9 @@ const (
 	WrongNumberOfArgumentFormat = "Expect %d arguments. got: %d"
 	WrongArgumentTypeFormat     = "Expect argument to be %s. got: %s"
 	CantYieldWithoutBlockFormat = "Can't yield without a block"
+	DividedByZeroFormat         = "Divided by 0"
+	CantModifyFrozenFormat      = "Can't modify frozen %s: %s"
+	UnhandledErrorFormat        = "unhandled error"
 )
 
 // Error class is actually a special struct to hold internal error types with messages.
@@ -73,10 +85,16 @@ const (
 // * `TypeError`: a type-related error
 // * `UndefinedMethodError`: undefined-method error
 // * `UnsupportedMethodError`: intentionally unsupported-method error
+// * `ZeroDivisionError`: an Integer is divided by zero
+// * `RuntimeError`: default error type raised by `raise`
+// * `FrozenError`: a frozen object is modified
+// * `StopIteration`: `next` is called on an exhausted enumerator
 //
 type Error struct {
 	*baseObj
 	Message string
+	// rescued means the error has been rescued by `rescue`, so it's an ordinary object and won't stop the program
+	rescued bool
 }
 
 // Polymorphic helper functions -----------------------------------------
@@ -87,3 +105,37 @@ func (e *Error) toString() string {
 func (e *Error) toJSON() string {
 	return e.toString()
 }
+
+func isErrorClass(c *RClass) bool {
+	for _, errType := range errorTypes {
+		if c.Name == errType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func builtinErrorInstanceMethods() []*BuiltInMethodObject {
+	return []*BuiltInMethodObject{
+		{
+			// Returns the error message, which contains error type and where the error is raised.
+			//
+			// ```ruby
+			// begin
+			//   10 / 0
+			// rescue => e
+			//   e.message # => "ZeroDivisionError: Divided by 0. At foo.gb:2"
+			// end
+			// ```
+			//
+			// @return [String]
+			Name: "message",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.(*Error).Message)
+				}
+			},
+		},
+	}
+}
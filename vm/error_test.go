@@ -18,6 +18,18 @@ func TestErrorLineNumber(t *testing.T) {
 		 a.z
 		`, "UndefinedMethodError: Undefined Method 'bar=' for <Instance of: Foo>",
 			5},
+		{`a = 1
+		b = 2
+		c = a +
+		  "3"
+		`, "TypeError: Expect argument to be Numeric. got: String", 3},
+		{`class Foo
+		end
+
+		Foo.new.bar(
+		  1
+		)
+		`, "UndefinedMethodError: Undefined Method 'bar' for <Instance of: Foo>", 4},
 	}
 
 	for i, tt := range tests {
@@ -71,7 +83,6 @@ func TestUnsupportedMethodError(t *testing.T) {
 	tests := []errorTestCase{
 		{`String.new`, "UnsupportedMethodError: Unsupported Method #new for String", 1},
 		{`Integer.new`, "UnsupportedMethodError: Unsupported Method #new for Integer", 1},
-		{`Hash.new`, "UnsupportedMethodError: Unsupported Method #new for Hash", 1},
 		{`Array.new`, "UnsupportedMethodError: Unsupported Method #new for Array", 1},
 		{`Boolean.new`, "UnsupportedMethodError: Unsupported Method #new for Boolean", 1},
 		{`Null.new`, "UnsupportedMethodError: Unsupported Method #new for Null", 1},
@@ -116,6 +127,34 @@ func TestArgumentError(t *testing.T) {
 		`,
 			"ArgumentError: Expect at most 2 args for method 'foo'. got: 3",
 			4},
+		{`def foo(x, *y, z)
+		end
+
+		foo(1)
+		`,
+			"ArgumentError: Expect at least 2 args for method 'foo'. got: 1",
+			4},
+		{`def foo(a:, b: 1)
+		end
+
+		foo(b: 2)
+		`,
+			"ArgumentError: Missing keyword argument 'a' for method 'foo'",
+			4},
+		{`def foo(a:, b: 1)
+		end
+
+		foo(a: 1, c: 2)
+		`,
+			"ArgumentError: Unknown keyword argument 'c' for method 'foo'",
+			4},
+		{`def foo(a, b: 1)
+		end
+
+		foo(1, 2, b: 3)
+		`,
+			"ArgumentError: Expect at most 1 args for method 'foo'. got: 2",
+			4},
 		{`"1234567890".include? "123", Class`,
 			"ArgumentError: Expect 1 argument. got=2",
 			1},
@@ -133,22 +172,164 @@ func TestArgumentError(t *testing.T) {
 	}
 }
 
-func TestConstantAlreadyInitializedError(t *testing.T) {
+func TestBuiltinMethodArgumentError(t *testing.T) {
 	tests := []errorTestCase{
-		{`Foo = 10
-		Foo = 100
-		`, "ConstantAlreadyInitializedError: Constant Foo already been initialized. Can't assign value to a constant twice.",
+		{`1.send("+")`, "ArgumentError: Expect at least 1 args for method '+'. got: 0", 1},
+		{`1.send("+", 1, 2)`, "ArgumentError: Expect at most 1 args for method '+'. got: 2", 1},
+		{`1.5.send("<=>")`, "ArgumentError: Expect at least 1 args for method '<=>'. got: 0", 1},
+		{`"a".send("==")`, "ArgumentError: Expect at least 1 args for method '=='. got: 0", 1},
+		{`:a.send("!=")`, "ArgumentError: Expect at least 1 args for method '!='. got: 0", 1},
+		{`[1, 2].at`, "ArgumentError: Expect at least 1 args for method 'at'. got: 0", 1},
+		{`(1..2).include?`, "ArgumentError: Expect at least 1 args for method 'include?'. got: 0", 1},
+		{`instance_variable_get`, "ArgumentError: Expect at least 1 args for method 'instance_variable_get'. got: 0", 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestUndefinedSuperMethodError(t *testing.T) {
+	tests := []errorTestCase{
+		{`class Foo
+		  def bar
+		    super
+		  end
+		end
+
+		Foo.new.bar
+		`, "UndefinedMethodError: Undefined super method 'bar' for <Instance of: Foo>",
+			3},
+		{`class Foo
+		  def self.bar
+		    super(1)
+		  end
+		end
+
+		Foo.bar
+		`, "UndefinedMethodError: Undefined super method 'bar' for Foo",
+			3},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		// The error is raised inside method's frame
+		v.checkCFP(t, i, 2)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSuperOutsideMethodError(t *testing.T) {
+	tests := []errorTestCase{
+		{`super
+		`, "InternalError: super called outside of method",
+			1},
+		{`a = 1
+		super(a)
+		`, "InternalError: super called outside of method",
 			2},
-		{`class Foo; end
-		Foo = 100
-		`, "ConstantAlreadyInitializedError: Constant Foo already been initialized. Can't assign value to a constant twice.",
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestYieldWithoutBlockError(t *testing.T) {
+	tests := []errorTestCase{
+		{`def foo
+		  yield
+		end
+
+		foo
+		`, "InternalError: Can't yield without a block",
 			2},
-		{`module Foo; end
-		Foo = 100
-		`, "ConstantAlreadyInitializedError: Constant Foo already been initialized. Can't assign value to a constant twice.",
+		{`def foo(x)
+		  yield(x, 1)
+		end
+
+		foo(10)
+		`, "InternalError: Can't yield without a block",
 			2},
 	}
 
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		// The error is raised inside method's frame
+		v.checkCFP(t, i, 2)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSuperclassMismatchError(t *testing.T) {
+	tests := []errorTestCase{
+		{`class Foo; end
+		class Bar < Foo; end
+		class Foo < Bar; end
+		`, "TypeError: superclass mismatch for class Foo",
+			3},
+		{`class Foo; end
+		class Bar; end
+		class Baz < Foo; end
+		class Baz < Bar; end
+		`, "TypeError: superclass mismatch for class Baz",
+			4},
+		{`module M; end
+		class Foo; end
+		class Bar < Foo
+		  include M
+		end
+		class Bar < Object; end
+		`, "TypeError: superclass mismatch for class Bar",
+			6},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStatementModifierFail(t *testing.T) {
+	tests := []errorTestCase{
+		{`x = 10 if foo`, "UndefinedMethodError: Undefined Method 'foo' for <Instance of: Object>", 1},
+		{`x = 10 unless 1 + "a"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestErrorInRescueBlock(t *testing.T) {
+	tests := []errorTestCase{
+		{`begin
+		  1 / 0
+		rescue
+		  foo
+		end
+		`, "UndefinedMethodError: Undefined Method 'foo' for <Instance of: Object>", 4},
+	}
+
 	for i, tt := range tests {
 		v := initTestVM()
 		evaluated := v.testEval(t, tt.input, getFilename())
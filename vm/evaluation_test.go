@@ -1,6 +1,7 @@
 package vm
 
 import (
+	"github.com/goby-lang/goby/compiler/bytecode"
 	"os"
 	"testing"
 )
@@ -94,6 +95,42 @@ func TestComment(t *testing.T) {
 	v.checkSP(t, 0, 1)
 }
 
+func TestSemicolonSeparatedStatementsEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`a = 1; b = 2; a + b`, 3},
+		{`a = 5; -a`, -5},
+		{`a = [1, 2]; [3]; a.length`, 2},
+		{`a = 1;; b = 2;; a + b`, 3},
+		{`;a = 1; a;`, 1},
+		{`def foo(x); x * 2; end; foo(3)`, 6},
+		{`class Foo; def bar; 10; end; end; Foo.new.bar`, 10},
+		{`i = 0; while i < 3; i += 1; end; i`, 3},
+		{`i = 0; while i < 3 do; i += 1; end; i`, 3},
+		{`while false; end; 4`, 4},
+		{`
+		i = 0
+		while i < 3;
+		  i += 1
+		end
+		i
+		`, 3},
+		{`if 1 > 2; 1; else; 2; end`, 2},
+		{`case 2; when 1; "one"; when 2; "two"; end`, "two"},
+		{`[1, 2].map do |x|; x * 2; end.to_s`, "[2, 4]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestMethodCall(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -297,6 +334,47 @@ func TestMethodCall(t *testing.T) {
 
 		Foo.new.bar
 		`, nil},
+		{`
+		class Foo
+		  def helper(x)
+		    [x, x * 2]
+		  end
+
+		  def bar(y)
+		    helper(y).last + helper(y + 1).first
+		  end
+		end
+
+		Foo.new.bar(5)
+		`, 16},
+		{`
+		class Foo
+		  def helper(x)
+		    x * 10
+		  end
+
+		  def bar
+		    [1, 2].map do |i|
+		      helper(i)
+		    end
+		  end
+		end
+
+		Foo.new.bar.to_s
+		`, "[10, 20]"},
+		{`
+		class Foo
+		  def self.helper(x)
+		    x + 1
+		  end
+
+		  def self.bar
+		    helper(helper(1))
+		  end
+		end
+
+		Foo.bar
+		`, 3},
 	}
 
 	for i, tt := range tests {
@@ -313,6 +391,261 @@ func TestMethodCall(t *testing.T) {
 	}
 }
 
+func TestMethodCallWithDefaultArgument(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		def greet(name, greeting = "Hello")
+		  greeting + " " + name
+		end
+
+		greet("Goby")
+		`, "Hello Goby"},
+		{`
+		def greet(name, greeting = "Hello")
+		  greeting + " " + name
+		end
+
+		greet("Goby", "Hi")
+		`, "Hi Goby"},
+		// Default value can reference earlier parameters
+		{`
+		def foo(a, b = a)
+		  a + b
+		end
+
+		foo(1)
+		`, 2},
+		{`
+		def foo(a, b = a)
+		  a + b
+		end
+
+		foo(1, 5)
+		`, 6},
+		{`
+		def foo(a = 1, b = a + 1)
+		  a * 10 + b
+		end
+
+		foo
+		`, 12},
+		{`
+		def foo(a = 1, b = a + 1)
+		  a * 10 + b
+		end
+
+		foo(5)
+		`, 56},
+		// Default value is evaluated in method's scope
+		{`
+		class Foo
+		  def initialize
+		    @x = 10
+		  end
+
+		  def bar(y = @x * 2)
+		    y
+		  end
+		end
+
+		Foo.new.bar
+		`, 20},
+		// Default value is evaluated every time the method is called
+		{`
+		def foo(a = [])
+		  a.push(1)
+		  a.length
+		end
+
+		foo
+		foo
+		`, 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodCallWithSplatArgument(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		def sum(*nums)
+		  total = 0
+		  nums.each do |n|
+		    total = total + n
+		  end
+		  total
+		end
+
+		sum(1, 2, 3, 4)
+		`, 10},
+		{`
+		def foo(*args)
+		  args
+		end
+
+		foo.to_s
+		`, "[]"},
+		{`
+		def foo(a, *rest)
+		  [a, rest]
+		end
+
+		foo(1, 2, 3).to_s
+		`, "[1, [2, 3]]"},
+		// Parameters after splat parameter take the last arguments
+		{`
+		def foo(a, *rest, z)
+		  [a, rest, z]
+		end
+
+		foo(1, 2).to_s
+		`, "[1, [], 2]"},
+		{`
+		def foo(a, *rest, z)
+		  [a, rest, z]
+		end
+
+		foo(1, 2, 3, 4).to_s
+		`, "[1, [2, 3], 4]"},
+		{`
+		def foo(*rest, y, z)
+		  [rest, y, z]
+		end
+
+		foo(1, 2, 3).to_s
+		`, "[[1], 2, 3]"},
+		// Optioned parameters take arguments before splat parameter does
+		{`
+		def foo(a, b = 10, *rest, z)
+		  [a, b, rest, z]
+		end
+
+		foo(1, 2).to_s
+		`, "[1, 10, [], 2]"},
+		{`
+		def foo(a, b = 10, *rest, z)
+		  [a, b, rest, z]
+		end
+
+		foo(1, 2, 3, 4, 5).to_s
+		`, "[1, 2, [3, 4], 5]"},
+		{`
+		class Foo
+		  def bar(*args)
+		    args.length
+		  end
+		end
+
+		Foo.new.bar(1, "a", :b)
+		`, 3},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodCallWithKeywordArgument(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		def connect(host:, port: 80)
+		  host + ":" + port.to_s
+		end
+
+		connect(host: "localhost", port: 9000)
+		`, "localhost:9000"},
+		{`
+		def connect(host:, port: 80)
+		  host + ":" + port.to_s
+		end
+
+		connect(host: "localhost")
+		`, "localhost:80"},
+		// Keyword arguments are assigned by name
+		{`
+		def connect(host:, port: 80)
+		  host + ":" + port.to_s
+		end
+
+		connect(port: 3000, host: "localhost")
+		`, "localhost:3000"},
+		{`
+		def connect(host:, port: 80)
+		  host + ":" + port.to_s
+		end
+
+		connect host: "localhost"
+		`, "localhost:80"},
+		// Mixed with positional parameters
+		{`
+		def request(path, verb = "GET", verbose: false)
+		  [path, verb, verbose].to_s
+		end
+
+		request("/", verbose: true)
+		`, `["/", "GET", true]`},
+		{`
+		def request(path, verb = "GET", verbose: false)
+		  [path, verb, verbose].to_s
+		end
+
+		request("/", "POST")
+		`, `["/", "POST", false]`},
+		{`
+		def request(path, *rest, verbose: false)
+		  [path, rest, verbose].to_s
+		end
+
+		request("/", 1, 2, verbose: true)
+		`, `["/", [1, 2], true]`},
+		// Hash is treated as positional argument if it's required
+		{`
+		def foo(h, verbose: false)
+		  h[:a]
+		end
+
+		foo({ a: 1 })
+		`, 1},
+		// Default value can reference other keyword arguments
+		{`
+		class Foo
+		  def bar(a:, b: a * 2)
+		    a + b
+		  end
+		end
+
+		Foo.new.bar(a: 2)
+		`, 6},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestMethodCallWithBlockArgument(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -464,6 +797,74 @@ func TestMethodCallWithBlockArgument(t *testing.T) {
 	}
 }
 
+func TestMethodCallWithBraceBlock(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  def each
+		    yield 1
+		    yield 2
+		  end
+		end
+
+		sum = 0
+		Foo.new.each { |x| sum = sum + x }
+		sum
+		`, 3},
+		{`
+		def foo(x)
+		  yield(x, 10)
+		end
+
+		foo(5) { |a, b| a * b }
+		`, 50},
+		{`
+		sum = 0
+		[1, 2, 3].each { |i|
+		  sum += i
+		}
+		sum
+		`, 6},
+		{`
+		[1, 2, 3].map { |i| { a: i } }.last[:a]
+		`, 3},
+		{`
+		def foo
+		  yield
+		end
+
+		foo() { "no params" }
+		`, "no params"},
+		{`
+		def foo
+		  yield(1)
+		end
+
+		a = foo { |i| i + 1 }
+		b = foo { 10 }
+		a + b
+		`, 12},
+		{`
+		def foo
+		  yield
+		end
+
+		foo { "a".upcase }
+		`, "A"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestMethodCallWithNestedBlock(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -570,34 +971,167 @@ func TestMethodCallWithNestedBlock(t *testing.T) {
 	}
 }
 
-func TestMethodCallWithoutParens(t *testing.T) {
+func TestTailRecursiveMethodCall(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected int
+		expected interface{}
 	}{
 		{`
-		def foo(x)
-		  x
+		def count(n, acc)
+		  if n == 0
+		    return acc
+		  end
+
+		  count(n - 1, acc + 1)
 		end
 
-		a = foo 10
-		a
-		`, 10},
-		{
-			`
-			class Foo
-			  def bar
-			    10
-			  end
+		count(100000, 0)
+		`, 100000},
+		{`
+		def fact(n, acc)
+		  if n == 0
+		    acc
+		  else
+		    fact(n - 1, acc * n)
+		  end
+		end
 
-			  def baz(x)
-			    x + 100
-			  end
+		fact(20, 1)
+		`, 2432902008176640000},
+		{`
+		def down(n)
+		  case n
+		  when 0
+		    "done"
+		  else
+		    return down(n - 1)
+		  end
+		end
 
-			  def foo
-			    x = baz bar
-			    x
-			  end
+		down(100000)
+		`, "done"},
+		{`
+		class Counter
+		  def initialize
+		    @calls = 0
+		  end
+
+		  def run(n)
+		    @calls += 1
+
+		    if n > 0
+		      run(n - 1)
+		    else
+		      @calls
+		    end
+		  end
+		end
+
+		Counter.new.run(100000)
+		`, 100001},
+		// Blocks created in a replaced frame still see their own locals
+		{`
+		def collect(n, results)
+		  if n == 0
+		    return results
+		  end
+
+		  results.push([1].map do |x|
+		    x + n
+		  end[0])
+		  collect(n - 1, results)
+		end
+
+		collect(3, []).to_s
+		`, "[4, 3, 2]"},
+		// Calls that aren't in tail position are evaluated as usual
+		{`
+		def fib(n)
+		  if n < 2
+		    n
+		  else
+		    fib(n - 1) + fib(n - 2)
+		  end
+		end
+
+		fib(15)
+		`, 610},
+		{`
+		def boom(n)
+		  if n == 0
+		    raise(ArgumentError, "boom")
+		  end
+
+		  boom(n - 1)
+		end
+
+		begin
+		  boom(100000)
+		rescue => e
+		  e.class.name
+		end
+		`, "ArgumentError"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestTailRecursiveMethodCallReusesCallFrames(t *testing.T) {
+	input := `
+	def count(n)
+	  if n == 0
+	    return 0
+	  end
+
+	  count(n - 1)
+	end
+
+	count(10000)
+	`
+
+	v := initTestVM()
+	evaluated := v.testEval(t, input, getFilename())
+	checkExpected(t, 0, evaluated, 0)
+
+	if l := len(v.mainThread.callFrameStack.callFrames); l > 10 {
+		t.Fatalf("Expect tail calls not to grow the call frames. got: %d", l)
+	}
+}
+
+func TestMethodCallWithoutParens(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{`
+		def foo(x)
+		  x
+		end
+
+		a = foo 10
+		a
+		`, 10},
+		{
+			`
+			class Foo
+			  def bar
+			    10
+			  end
+
+			  def baz(x)
+			    x + 100
+			  end
+
+			  def foo
+			    x = baz bar
+			    x
+			  end
 			end
 
 			Foo.new.foo
@@ -862,6 +1396,48 @@ func TestClassMethodCall(t *testing.T) {
 				end
 			end
 
+			class Bar < Foo; end
+
+			class Bar < Foo
+				def self.bar
+					foo + 1
+				end
+			end
+			Bar.bar
+			`,
+			11,
+		},
+		{
+			`
+			module M
+				def m
+					1
+				end
+			end
+
+			class Foo; end
+
+			class Bar < Foo
+				include M
+			end
+
+			class Bar < Foo
+				def self.bar
+					new.m + 10
+				end
+			end
+			Bar.bar
+			`,
+			11,
+		},
+		{
+			`
+			class Foo
+				def self.foo
+					10
+				end
+			end
+
 			class Bar < Foo
 				def self.foo
 					100
@@ -1026,6 +1602,29 @@ func TestBangPrefixMethodCall(t *testing.T) {
 		{"!!true", true},
 		{"!!false", false},
 		{"!!5", true},
+		{"!nil", true},
+		{"!!nil", false},
+		{"!0", false},
+		{"!!0", true},
+		{`!"s"`, false},
+		{`!""`, false},
+		{`!!"s"`, true},
+		{"!1.5", false},
+		{"![]", false},
+		{"!{}", false},
+		{"!:foo", false},
+		{"!String", false},
+		{`
+		class Foo
+		end
+
+		!Foo.new
+		`, false},
+		{`
+		a = nil
+		b = 1
+		!!a == !!b
+		`, false},
 	}
 
 	for i, tt := range tests {
@@ -1046,6 +1645,36 @@ func TestMinusPrefixMethodCall(t *testing.T) {
 		{"-10", -10},
 		{"-(-10)", 10},
 		{"-(-5)", 5},
+		{"3 - -2", 5},
+		{"3 - 2", 1},
+		{"3-2", 1},
+		{"-2 * 3", -6},
+		{"2 * -3", -6},
+		{`
+		a = 5
+		-a
+		`, -5},
+		{`
+		a = -5
+		-a
+		`, 5},
+		// Binary operator when there's an expression on the left side
+		{`
+		a = 10
+		a -5
+		`, 5},
+		{`
+		a = 10
+		a - -5
+		`, 15},
+		{"[1, -2][1]", -2},
+		{`
+		def foo
+		  10
+		end
+
+		-foo
+		`, -10},
 	}
 
 	for i, tt := range tests {
@@ -1057,6 +1686,21 @@ func TestMinusPrefixMethodCall(t *testing.T) {
 	}
 }
 
+func TestMinusPrefixMethodCallFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`-"foo"`, "UndefinedMethodError: Undefined Method '-@' for foo", 1},
+		{`-nil`, "UndefinedMethodError: Undefined Method '-@' for nil", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestSelfExpressionEvaluation(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -1108,6 +1752,60 @@ func TestSelfExpressionEvaluation(t *testing.T) {
 			Foo.new.class_name
 			`,
 			"Foo"},
+		// self in a class method is the class
+		{
+			`
+			class Foo
+				def self.me
+					self
+				end
+			end
+
+			Foo.me.name
+			`,
+			"Foo"},
+		{
+			`
+			class Foo
+				attr_reader :x
+
+				def initialize(x)
+					@x = x
+				end
+
+				def self.create(x)
+					self.new(x)
+				end
+			end
+
+			foo = Foo.create(3)
+			foo.class.name + foo.x.to_s
+			`,
+			"Foo3"},
+		{
+			`
+			class Foo
+				def self.create
+					self.new
+				end
+			end
+
+			class Bar < Foo; end
+
+			Bar.create.class.name
+			`,
+			"Bar"},
+		{
+			`
+			module Foo
+				def self.me
+					self.name
+				end
+			end
+
+			Foo.me
+			`,
+			"Foo"},
 	}
 
 	for i, tt := range tests {
@@ -1197,6 +1895,74 @@ func TestInstanceVariableEvaluation(t *testing.T) {
 	}
 }
 
+func TestGlobalVariableEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`$foo`, nil},
+		{`
+		$foo = 10
+		$foo
+		`, 10},
+		// A global set inside a method is visible at top level afterward
+		{`
+		def setup
+		  $config = "debug"
+		end
+
+		setup
+		$config
+		`, "debug"},
+		{`
+		class Foo
+		  def self.bump
+		    $count += 1
+		  end
+
+		  def bump
+		    $count += 1
+		  end
+		end
+
+		$count = 0
+		Foo.bump
+		Foo.new.bump
+		$count
+		`, 2},
+		{`
+		$sum = 0
+		[1, 2, 3].each do |i|
+		  $sum += i
+		end
+		$sum
+		`, 6},
+		{`
+		$a, $b = 1, 2
+		$a + $b
+		`, 3},
+		{`
+		$x ||= 5
+		$x ||= 10
+		$x
+		`, 5},
+		// Globals are separated from local variables
+		{`
+		foo = 1
+		$foo = 2
+		foo
+		`, 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestAssignmentEvaluation(t *testing.T) {
 	tests := []struct {
 		input         string
@@ -1240,6 +2006,30 @@ func TestAssignmentEvaluation(t *testing.T) {
 		`, 110},
 		{`a = b = 10; a`, 10},
 		{`a = b = c = 10; a`, 10},
+		// Assignment returns the assigned value
+		{`a = b = c = 1`, 1},
+		{`
+		a = b = c = 1
+		a + b * 10 + c * 100
+		`, 111},
+		{`
+		x = (y = 5) + 1
+		x * 10 + y
+		`, 65},
+		{`
+		def compute
+		  3
+		end
+
+		if (n = compute)
+		  n + 1
+		end
+		`, 4},
+		{`
+		arr = []
+		v = arr[0] = 7
+		v + arr[0]
+		`, 14},
 		{`
 		i = 100
 		a = b = i + 10
@@ -1318,6 +2108,16 @@ func TestAssignmentEvaluation(t *testing.T) {
 		h[:foo]
 		`, 0},
 		{`
+		h = { foo: 2 }
+		h[:foo] *= 3
+		h[:foo]
+		`, 6},
+		{`
+		a = [1, 6]
+		a[1] /= 2
+		a[1]
+		`, 3},
+		{`
 		h = {}
 		h[:foo] ||= 2
 		h[:foo]
@@ -1342,7 +2142,29 @@ func TestAssignmentByOperationEvaluation(t *testing.T) {
 		{"a = 5; a -= 10; a;", -5},
 		{"a = 5; a += 2 * 3 + 5; a;", 16},
 		{"a = 5; a -= 2 * 3 + 5; a;", -6},
+		{"a = 5; a *= 2; a;", 10},
+		{"a = 5; a *= 2 + 1; a;", 15},
+		{"a = 10; a /= 2; a;", 5},
+		{"a = 10; a /= 2 + 3; a;", 2},
+		{"a = 1.5; a *= 2; a;", 3.0},
 		{"a = false; a ||= true; a;", true},
+		{`
+		class Counter
+		  def initialize
+		    @count = 2
+		  end
+
+		  def count
+		    @count += 3
+		    @count *= 4
+		    @count /= 5
+		    @count -= 1
+		    @count
+		  end
+		end
+
+		Counter.new.count
+		`, 3},
 	}
 
 	for i, tt := range tests {
@@ -1468,6 +2290,26 @@ func TestIfExpressionEvaluation(t *testing.T) {
 
 			d + 1
 		`, 9},
+		// Only the branch whose condition matches first should be evaluated
+		{`
+			evaluated = []
+			a = 5
+			r = if a > 10
+			  evaluated.push(1)
+			  1
+			elsif a > 3
+			  evaluated.push(2)
+			  2
+			elsif a > 1
+			  evaluated.push(3)
+			  3
+			else
+			  evaluated.push(4)
+			  4
+			end
+
+			evaluated.to_s + r.to_s
+		`, "[2]2"},
 		{`
 			if false
 			  if true
@@ -1511,6 +2353,21 @@ func TestIfExpressionEvaluation(t *testing.T) {
 			  14
 			end
 		`, 11},
+		{`if 10 > 5 then 1 else 2 end`, 1},
+		{`if 10 < 5 then 1 else 2 end`, 2},
+		{`if 10 < 5 then 1 elsif 10 > 5 then 3 else 2 end`, 3},
+		{`if 10 < 5 then 1 end`, nil},
+		{`
+		x = if 10 > 5 then "yes" else "no" end
+		x
+		`, "yes"},
+		{`
+		if 10 > 5 then
+		  1
+		else
+		  2
+		end
+		`, 1},
 	}
 
 	for i, tt := range tests {
@@ -1522,35 +2379,543 @@ func TestIfExpressionEvaluation(t *testing.T) {
 	}
 }
 
-func TestClassInheritance(t *testing.T) {
-	input := `
-		class Bar
-		end
-
-		class Foo < Bar
-		  def self.add
-		    10
-		  end
-		end
-
-		Foo.superclass.name
-	`
-	v := initTestVM()
-	evaluated := v.testEval(t, input, getFilename())
-
-	testStringObject(t, 0, evaluated, "Bar")
-	v.checkCFP(t, 0, 0)
-	v.checkSP(t, 0, 1)
-}
-
-func TestMultiVarAssignment(t *testing.T) {
+func TestUnlessExpressionEvaluation(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected interface{}
 	}{
-		{`
-		a, b = [1, 2]
-		a
+		{"unless true; 10 end", nil},
+		{"unless false; 10 end", 10},
+		{"unless nil; 10 end", 10},
+		{"unless 1 > 2; 10 else 20 end", 10},
+		{"unless 1 < 2; 10 else 20 end", 20},
+		{"unless 1 > 2 then 10 else 20 end", 10},
+		{`
+		unless true
+		  x = 1
+		end
+
+		x
+		`, nil},
+		{`
+		x = 0
+		unless false
+		  x = 1
+		end
+		x
+		`, 1},
+		{`
+		a = unless true
+		end
+		a
+		`, nil},
+		{`
+		x = 10 unless true
+		`, nil},
+		{`
+		x = 10 unless false
+		`, 10},
+		{`
+		x = 1
+		x = 10 unless x > 0
+		x
+		`, 1},
+		{`
+		def foo(x)
+		  x * 2 unless x > 3
+		end
+
+		foo(2)
+		`, 4},
+		{`
+		def foo(x)
+		  x * 2 unless x > 3
+		end
+
+		foo(5)
+		`, nil},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStatementModifierEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`x = 10 if true`, 10},
+		{`x = 10 if false`, nil},
+		{`
+		x = 1
+		x = 10 if x > 5
+		x
+		`, 1},
+		{`
+		def foo(x)
+		  return 0 if x > 10
+		  x * 2
+		end
+
+		foo(20) + foo(2)
+		`, 4},
+		{`
+		def foo(x)
+		  x * 2 if x > 3
+		end
+
+		foo(2)
+		`, nil},
+		{`
+		i = 0
+		sum = 0
+		while i < 10 do
+		  i = i + 1
+		  next if i < 5
+		  break unless i < 8
+		  sum = sum + i
+		end
+		sum
+		`, 18},
+		{`
+		x = 0
+		[1, 2, 3, 4].each do |i|
+		  x = x + i if i.even?
+		end
+		x
+		`, 6},
+		{`x = 10 if true unless false`, 10},
+		{`x = 10 if true unless true`, nil},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestTernaryExpressionEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`true ? 1 : 2`, 1},
+		{`false ? 1 : 2`, 2},
+		{`nil ? 1 : 2`, 2},
+		{`0 ? 1 : 2`, 1},
+		{`1 > 2 ? "a" : "b"`, "b"},
+		{`x = 1 < 2 ? 10 : 20`, 10},
+		{`true || false ? 1 : 2`, 1},
+		{`true ? false ? 1 : 2 : 3`, 2},
+		{`
+		x = 3
+		x == 1 ? "one" : x == 2 ? "two" : x == 3 ? "three" : "many"
+		`, "three"},
+		{`
+		def foo(x)
+		  x.even? ? x / 2 : x * 3 + 1
+		end
+
+		foo(4) + foo(3)
+		`, 12},
+		// Branches not taken should not be evaluated
+		{`true ? 1 : foo`, 1},
+		{`false ? foo : 2`, 2},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestCaseExpressionEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		case 1
+		when 1
+		  "one"
+		when 2
+		  "two"
+		end
+		`, "one"},
+		{`
+		case 2
+		when 1
+		  "one"
+		when 2
+		  "two"
+		end
+		`, "two"},
+		{`
+		case 3
+		when 1
+		  "one"
+		when 2
+		  "two"
+		end
+		`, nil},
+		{`
+		case 3
+		when 1
+		  "one"
+		else
+		  "other"
+		end
+		`, "other"},
+		{`
+		case 3
+		when 1, 2
+		  "small"
+		when 3, 4
+		  "big"
+		end
+		`, "big"},
+		{`case "b"; when "a" then 1; when "b" then 2; end`, 2},
+		{`case :foo; when :bar then 1; when :foo then 2; end`, 2},
+		{`
+		x = case 1 + 1
+		when 2
+		end
+		x
+		`, nil},
+		{`
+		def foo(x)
+		  case x
+		  when 1
+		    10
+		  else
+		    20
+		  end
+		end
+
+		foo(1) + foo(2)
+		`, 30},
+		// Only the matched branch is evaluated
+		{`
+		case 1
+		when 1
+		  10
+		when bar
+		  baz
+		else
+		  baz
+		end
+		`, 10},
+		{`
+		count = 0
+		[1, 2, 3].each do |i|
+		  case i
+		  when 2
+		    count = count + 10
+		  else
+		    count = count + 1
+		  end
+		end
+		count
+		`, 12},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestClassInheritance(t *testing.T) {
+	input := `
+		class Bar
+		end
+
+		class Foo < Bar
+		  def self.add
+		    10
+		  end
+		end
+
+		Foo.superclass.name
+	`
+	v := initTestVM()
+	evaluated := v.testEval(t, input, getFilename())
+
+	testStringObject(t, 0, evaluated, "Bar")
+	v.checkCFP(t, 0, 0)
+	v.checkSP(t, 0, 1)
+}
+
+func TestSuperKeyword(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  def bar(x, y)
+		    x + y
+		  end
+		end
+
+		class Baz < Foo
+		  def bar(x, y)
+		    super(x * 10, y) + 1
+		  end
+		end
+
+		Baz.new.bar(1, 2)
+		`, 13},
+		{`
+		class Foo
+		  def bar(x)
+		    x * 2
+		  end
+		end
+
+		class Baz < Foo
+		  def bar(x)
+		    super + 1
+		  end
+		end
+
+		Baz.new.bar(5)
+		`, 11},
+		{`
+		class Foo
+		  def initialize(name)
+		    @name = name
+		  end
+
+		  def name
+		    @name
+		  end
+		end
+
+		class Baz < Foo
+		  def initialize(name)
+		    super("Baz " + name)
+		  end
+		end
+
+		Baz.new("Goby").name
+		`, "Baz Goby"},
+		{`
+		class Foo
+		  def self.bar
+		    "Foo"
+		  end
+		end
+
+		class Baz < Foo
+		  def self.bar
+		    super + "Baz"
+		  end
+		end
+
+		Baz.bar
+		`, "FooBaz"},
+		{`
+		class Foo
+		  def bar
+		    "Foo"
+		  end
+		end
+
+		class Baz < Foo
+		  def bar
+		    "Baz" + super
+		  end
+		end
+
+		class Qux < Baz
+		  def bar
+		    "Qux" + super
+		  end
+		end
+
+		Qux.new.bar
+		`, "QuxBazFoo"},
+		{`
+		module Greet
+		  def hello
+		    "Hello"
+		  end
+		end
+
+		class Foo
+		  include Greet
+
+		  def hello
+		    super + " from Foo"
+		  end
+		end
+
+		Foo.new.hello
+		`, "Hello from Foo"},
+		{`
+		class Foo
+		  def bar(x)
+		    x + 1
+		  end
+		end
+
+		class Baz < Foo
+		  def bar(x)
+		    result = 0
+
+		    [1].each do |i|
+		      result = super(x + i)
+		    end
+
+		    result
+		  end
+		end
+
+		Baz.new.bar(1)
+		`, 3},
+		{`
+		class Foo
+		  def bar
+		    yield(10)
+		  end
+		end
+
+		class Baz < Foo
+		  def bar
+		    super
+		  end
+		end
+
+		Baz.new.bar do |x|
+		  x * 2
+		end
+		`, 20},
+		{`
+		class Foo
+		  def to_s
+		    "Foo: " + super
+		  end
+		end
+
+		Foo.new.to_s
+		`, "Foo: <Instance of: Foo>"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestBareSuperForwardsOriginalArguments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  def bar(x, y)
+		    x - y
+		  end
+		end
+
+		class Baz < Foo
+		  def bar(x, y)
+		    x = 100
+		    y = 50
+		    super
+		  end
+		end
+
+		Baz.new.bar(10, 3)
+		`, 7},
+		{`
+		class Foo
+		  def bar(x, y = 5)
+		    x + y
+		  end
+		end
+
+		class Baz < Foo
+		  def bar(x, y = 100)
+		    super
+		  end
+		end
+
+		Baz.new.bar(1)
+		`, 6},
+		{`
+		class Foo
+		  def bar(x, y = 5)
+		    x + y
+		  end
+		end
+
+		class Baz < Foo
+		  def bar(x, y = 100)
+		    super
+		  end
+		end
+
+		Baz.new.bar(1, 2)
+		`, 3},
+		{`
+		class Foo
+		  def bar(s)
+		    s + "!"
+		  end
+		end
+
+		class Baz < Foo
+		  def bar(s)
+		    s = "changed"
+		    [1].each do |i|
+		      s = "changed again"
+		    end
+		    super
+		  end
+		end
+
+		Baz.new.bar("original")
+		`, "original!"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMultiVarAssignment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		a, b = [1, 2]
+		a
 		`,
 			1},
 		{`
@@ -1605,6 +2970,43 @@ func TestMultiVarAssignment(t *testing.T) {
 		d
 		`, nil},
 		{`
+		a, b = 1, 2
+		a + b * 10
+		`, 21},
+		{`
+		a, b, c = 1, 2
+		c
+		`, nil},
+		{`
+		a, b = 1, 2, 3
+		b
+		`, 2},
+		{`
+		a, b = 1
+		a
+		`, 1},
+		{`
+		a, b = 1
+		b
+		`, nil},
+		{`
+		a, b = 1, 2
+		a, b = b, a
+		a * 10 + b
+		`, 21},
+		{`
+		@a, b = "x", "y"
+		@a + b
+		`, "xy"},
+		{`
+		def foo
+		  10
+		end
+
+		a, b = foo, foo + 1
+		a + b
+		`, 21},
+		{`
 		arr = [1, 2, 3]
 		@a, @b, c = arr
 		@a
@@ -1709,3 +3111,176 @@ func TestRemoveUnusedExpression(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestBeginRescueExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		a = begin
+		  1 / 0
+		rescue
+		  10
+		end
+		a
+		`, 10},
+		{`
+		a = begin
+		  1 + 1
+		rescue
+		  10
+		end
+		a
+		`, 2},
+		{`
+		begin
+		  1 / 0
+		rescue => e
+		  e.class.name
+		end
+		`, "ZeroDivisionError"},
+		{`
+		begin
+		  foo
+		rescue => e
+		  e.message
+		end
+		`, "UndefinedMethodError: Undefined Method 'foo' for <Instance of: Object>. At " + getFilename() + ":3"},
+		{`
+		def foo
+		  bar(1)
+		end
+
+		def bar(x)
+		  x / 0
+		end
+
+		begin
+		  foo
+		rescue => e
+		  e.class.name
+		end
+		`, "ZeroDivisionError"},
+		{`
+		begin
+		  begin
+		    1 / 0
+		  rescue
+		    2 / 0
+		  end
+		rescue => e
+		  "outer"
+		end
+		`, "outer"},
+		{`
+		sum = 0
+		[1, 0, 2].each do |i|
+		  sum = sum + begin
+		    10 / i
+		  rescue
+		    100
+		  end
+		end
+		sum
+		`, 115},
+		{`
+		begin
+		rescue
+		end
+		`, nil},
+		// Errors raised in blocks yielded by builtin methods
+		{`
+		begin
+		  [1, 2].map do |x|
+		    raise "y"
+		  end
+		rescue => e
+		  "rescued"
+		end
+		`, "rescued"},
+		{`
+		begin
+		  3.times do |i|
+		    1 / 0
+		  end
+		rescue => e
+		  e.class.name
+		end
+		`, "ZeroDivisionError"},
+		{`
+		count = 0
+		begin
+		  5.times do |i|
+		    count += 1
+		    raise "stop" if i == 1
+		  end
+		rescue
+		end
+		count
+		`, 2},
+		{`
+		result = begin
+		  [[1, 2], [3]].map do |a|
+		    a.each do |x|
+		      raise "inner" if x == 3
+		    end
+		  end
+		rescue => e
+		  "rescued"
+		end
+		result
+		`, "rescued"},
+		{`
+		def foo
+		  [1].each do |x|
+		    x / 0
+		  end
+		  "not here"
+		end
+
+		begin
+		  foo
+		rescue => e
+		  e.class.name
+		end
+		`, "ZeroDivisionError"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSendToNilReceiver(t *testing.T) {
+	v := initTestVM()
+	th := v.mainThread
+
+	// Normally a receiver can't be nil, so we need to build the broken state by hand
+	is := &instructionSet{filename: "test.gb"}
+	i := is.define(0, builtInActions[bytecode.Send], "foo", 0)
+	cf := newCallFrame(is)
+	cf.pc = 1
+	th.callFrameStack.push(cf)
+	th.stack.push(&Pointer{})
+
+	i.action.operation(th, cf, i.Params...)
+
+	err, ok := th.stack.top().Target.(*Error)
+
+	if !ok {
+		t.Fatalf("Expect an error. got: %v", th.stack.top().Target)
+	}
+
+	expected := "InternalError: Can't send method 'foo' to a nil receiver. At test.gb:1"
+
+	if err.Message != expected {
+		t.Fatalf("Expect error message to be %q. got: %q", expected, err.Message)
+	}
+
+	v.checkSP(t, 0, 1)
+}
@@ -48,7 +48,8 @@ func builtinFileClassMethods() []*BuiltInMethodObject {
 			// ```
 			// @param filepath [String]
 			// @return [String]
-			Name: "basename",
+			Name:  "basename",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					filename := args[0].(*StringObject).value
@@ -104,7 +105,8 @@ func builtinFileClassMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			Name: "exist",
+			Name:  "exist",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					filename := args[0].(*StringObject).value
@@ -126,7 +128,8 @@ func builtinFileClassMethods() []*BuiltInMethodObject {
 			// ```
 			// @param filename [String]
 			// @return [String]
-			Name: "extname",
+			Name:  "extname",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					filename := args[0].(*StringObject).value
@@ -221,7 +224,8 @@ func builtinFileClassMethods() []*BuiltInMethodObject {
 			// ```
 			// @param filename [String]
 			// @return [Integer]
-			Name: "size",
+			Name:  "size",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					filename := args[0].(*StringObject).value
@@ -246,7 +250,8 @@ func builtinFileClassMethods() []*BuiltInMethodObject {
 			// ```
 			// @param filepath [String]
 			// @return [Array]
-			Name: "split",
+			Name:  "split",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					filename := args[0].(*StringObject).value
@@ -321,7 +326,8 @@ func builtinFileInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			Name: "write",
+			Name:  "write",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					file := receiver.(*FileObject).File
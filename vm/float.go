@@ -0,0 +1,582 @@
+package vm
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+func (vm *VM) initFloatObject(value float64) *FloatObject {
+	return &FloatObject{
+		baseObj: &baseObj{class: vm.topLevelClass(floatClass)},
+		value:   value,
+	}
+}
+
+func (vm *VM) initFloatClass() *RClass {
+	fc := vm.initializeClass(floatClass, false)
+	fc.setBuiltInMethods(builtinFloatInstanceMethods(), false)
+	fc.setBuiltInMethods(builtInFloatClassMethods(), true)
+	return fc
+}
+
+// FloatObject represents an inexact real number using the native architecture's double-precision floating point
+// representation.
+//
+// ```ruby
+// 1.5 + 1   # => 2.5
+// 3.0 * 1.5 # => 4.5
+// 1.0 == 1  # => true
+// ```
+//
+// - `Float.new` is not supported.
+type FloatObject struct {
+	*baseObj
+	value float64
+}
+
+func (f *FloatObject) Value() interface{} {
+	return f.value
+}
+
+// Polymorphic helper functions -----------------------------------------
+func (f *FloatObject) toString() string {
+	switch {
+	case math.IsNaN(f.value):
+		return "NaN"
+	case math.IsInf(f.value, 1):
+		return "Infinity"
+	case math.IsInf(f.value, -1):
+		return "-Infinity"
+	}
+
+	s := strconv.FormatFloat(f.value, 'f', -1, 64)
+
+	// Always keep the decimal point so `1.0` won't be shown as an Integer
+	if !strings.Contains(s, ".") {
+		s = s + ".0"
+	}
+
+	return s
+}
+
+func (f *FloatObject) toJSON() string {
+	return f.toString()
+}
+
+// toInteger returns the integer part of given value as an Integer, or a BigInteger if it's too large.
+// NaN and infinity can't be converted, so it returns an error for them.
+func (f *FloatObject) toInteger(t *thread, value float64) Object {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return t.vm.initErrorObject(ArgumentError, "Can't convert %s into Integer", t.vm.initFloatObject(value).toString())
+	}
+
+	if float64(minInt) <= value && value < float64(maxInt) {
+		return t.vm.initIntegerObject(int(value))
+	}
+
+	b, _ := big.NewFloat(value).Int(nil)
+	return t.vm.initIntegerFromBigInt(b)
+}
+
+// roundFloat rounds value to given decimal digits, which can be negative.
+// Halves are rounded away from zero like Ruby does.
+func roundFloat(value float64, digits int) float64 {
+	p := math.Pow10(digits)
+
+	switch {
+	case p == 0:
+		return 0
+	case math.IsInf(value*p, 0):
+		// There are too many digits to change the value
+		return value
+	}
+
+	return math.Round(value*p) / p
+}
+
+func (f *FloatObject) equal(e *FloatObject) bool {
+	return f.value == e.value
+}
+
+// equalTo returns if self represents the same number as given object, which can be an Integer or a Float
+func (f *FloatObject) equalTo(right Object) bool {
+	switch right := right.(type) {
+	case *FloatObject:
+		return f.value == right.value
+	case *IntegerObject:
+		return f.value == float64(right.value)
+	case *BigIntegerObject:
+		return f.value == right.floatValue()
+	default:
+		return false
+	}
+}
+
+// arithmeticOperation applies the operation to self and an Integer or a Float, the result is always a Float
+func (f *FloatObject) arithmeticOperation(t *thread, right Object, operation func(float64, float64) float64) Object {
+	var rightValue float64
+
+	switch right := right.(type) {
+	case *FloatObject:
+		rightValue = right.value
+	case *IntegerObject:
+		rightValue = float64(right.value)
+	case *BigIntegerObject:
+		rightValue = right.floatValue()
+	default:
+		return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, numericName, right.Class().Name)
+	}
+
+	return t.vm.initFloatObject(operation(f.value, rightValue))
+}
+
+// numericComparison compares self with an Integer or a Float and returns the result as a Boolean object
+func (f *FloatObject) numericComparison(t *thread, right Object, operation func(float64, float64) bool) Object {
+	var rightValue float64
+
+	switch right := right.(type) {
+	case *FloatObject:
+		rightValue = right.value
+	case *IntegerObject:
+		rightValue = float64(right.value)
+	case *BigIntegerObject:
+		rightValue = right.floatValue()
+	default:
+		return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, numericName, right.Class().Name)
+	}
+
+	return toBooleanObject(operation(f.value, rightValue))
+}
+
+func builtInFloatClassMethods() []*BuiltInMethodObject {
+	return []*BuiltInMethodObject{
+		{
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.unsupportedMethodError("#new", receiver)
+				}
+			},
+		},
+	}
+}
+
+func builtinFloatInstanceMethods() []*BuiltInMethodObject {
+	return []*BuiltInMethodObject{
+		{
+			// Returns the sum of self and another Numeric.
+			//
+			// ```Ruby
+			// 1.5 + 2   # => 3.5
+			// 1.5 + 2.5 # => 4.0
+			// ```
+			// @return [Float]
+			Name:  "+",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					operation := func(leftValue float64, rightValue float64) float64 {
+						return leftValue + rightValue
+					}
+
+					return receiver.(*FloatObject).arithmeticOperation(t, args[0], operation)
+				}
+			},
+		},
+		{
+			// Returns the modulo between self and another Numeric.
+			//
+			// ```Ruby
+			// 5.5 % 2 # => 1.5
+			// ```
+			// @return [Float]
+			Name:  "%",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					operation := func(leftValue float64, rightValue float64) float64 {
+						return math.Mod(leftValue, rightValue)
+					}
+
+					return receiver.(*FloatObject).arithmeticOperation(t, args[0], operation)
+				}
+			},
+		},
+		{
+			// Returns the subtraction of another Numeric from self.
+			//
+			// ```Ruby
+			// 5.5 - 2   # => 3.5
+			// 5.5 - 0.5 # => 5.0
+			// ```
+			// @return [Float]
+			Name:  "-",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					operation := func(leftValue float64, rightValue float64) float64 {
+						return leftValue - rightValue
+					}
+
+					return receiver.(*FloatObject).arithmeticOperation(t, args[0], operation)
+				}
+			},
+		},
+		{
+			// Returns the negation of self, which is called by the unary minus.
+			//
+			// ```Ruby
+			// -(1.5)  # => -1.5
+			// a = -2.5
+			// -a      # => 2.5
+			// ```
+			// @return [Float]
+			Name: "-@",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initFloatObject(-receiver.(*FloatObject).value)
+				}
+			},
+		},
+		{
+			// Returns self multiplying another Numeric.
+			//
+			// ```Ruby
+			// 2.5 * 2   # => 5.0
+			// 2.5 * 0.5 # => 1.25
+			// ```
+			// @return [Float]
+			Name:  "*",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					operation := func(leftValue float64, rightValue float64) float64 {
+						return leftValue * rightValue
+					}
+
+					return receiver.(*FloatObject).arithmeticOperation(t, args[0], operation)
+				}
+			},
+		},
+		{
+			// Returns self raised to the power of another Numeric.
+			//
+			// ```Ruby
+			// 1.5 ** 2 # => 2.25
+			// ```
+			// @return [Float]
+			Name:  "**",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					operation := func(leftValue float64, rightValue float64) float64 {
+						return math.Pow(leftValue, rightValue)
+					}
+
+					return receiver.(*FloatObject).arithmeticOperation(t, args[0], operation)
+				}
+			},
+		},
+		{
+			// Returns self divided by another Numeric.
+			//
+			// ```Ruby
+			// 7.5 / 2.5 # => 3.0
+			// 7.5 / 3   # => 2.5
+			// ```
+			// @return [Float]
+			Name:  "/",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					operation := func(leftValue float64, rightValue float64) float64 {
+						return leftValue / rightValue
+					}
+
+					return receiver.(*FloatObject).arithmeticOperation(t, args[0], operation)
+				}
+			},
+		},
+		{
+			// Returns if self is larger than another Numeric.
+			//
+			// ```Ruby
+			// 1.5 > 1   # => true
+			// 1.5 > 1.5 # => false
+			// ```
+			// @return [Boolean]
+			Name:  ">",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					operation := func(leftValue float64, rightValue float64) bool {
+						return leftValue > rightValue
+					}
+
+					return receiver.(*FloatObject).numericComparison(t, args[0], operation)
+				}
+			},
+		},
+		{
+			// Returns if self is larger than or equals to another Numeric.
+			//
+			// ```Ruby
+			// 1.5 >= 1.5 # => true
+			// 1.0 >= 1   # => true
+			// ```
+			// @return [Boolean]
+			Name:  ">=",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					operation := func(leftValue float64, rightValue float64) bool {
+						return leftValue >= rightValue
+					}
+
+					return receiver.(*FloatObject).numericComparison(t, args[0], operation)
+				}
+			},
+		},
+		{
+			// Returns if self is smaller than another Numeric.
+			//
+			// ```Ruby
+			// 1.5 < 2   # => true
+			// 1.5 < 1.5 # => false
+			// ```
+			// @return [Boolean]
+			Name:  "<",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					operation := func(leftValue float64, rightValue float64) bool {
+						return leftValue < rightValue
+					}
+
+					return receiver.(*FloatObject).numericComparison(t, args[0], operation)
+				}
+			},
+		},
+		{
+			// Returns if self is smaller than or equals to another Numeric.
+			//
+			// ```Ruby
+			// 1.5 <= 1.5 # => true
+			// 1.0 <= 1   # => true
+			// ```
+			// @return [Boolean]
+			Name:  "<=",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					operation := func(leftValue float64, rightValue float64) bool {
+						return leftValue <= rightValue
+					}
+
+					return receiver.(*FloatObject).numericComparison(t, args[0], operation)
+				}
+			},
+		},
+		{
+			// Returns 1 if self is larger than the incoming Numeric, -1 if smaller. Otherwise 0.
+			//
+			// ```Ruby
+			// 1.5 <=> 3   # => -1
+			// 1.0 <=> 1   # => 0
+			// 3.5 <=> 1.5 # => 1
+			// ```
+			// @return [Integer]
+			Name:  "<=>",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					var rightValue float64
+
+					leftValue := receiver.(*FloatObject).value
+
+					switch right := args[0].(type) {
+					case *FloatObject:
+						rightValue = right.value
+					case *IntegerObject:
+						rightValue = float64(right.value)
+					case *BigIntegerObject:
+						rightValue = right.floatValue()
+					default:
+						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, numericName, args[0].Class().Name)
+					}
+
+					if leftValue < rightValue {
+						return t.vm.initIntegerObject(-1)
+					}
+					if leftValue > rightValue {
+						return t.vm.initIntegerObject(1)
+					}
+
+					return t.vm.initIntegerObject(0)
+				}
+			},
+		},
+		{
+			// Returns if self is equal to another Numeric.
+			//
+			// ```Ruby
+			// 1.0 == 1   # => true
+			// 1.5 == 1.5 # => true
+			// 1.5 == "1" # => false
+			// ```
+			// @return [Boolean]
+			Name:  "==",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*FloatObject).equalTo(args[0]))
+				}
+			},
+		},
+		{
+			// Returns if self is not equal to another Numeric.
+			//
+			// ```Ruby
+			// 1.0 != 1   # => false
+			// 1.5 != 2.5 # => true
+			// ```
+			// @return [Boolean]
+			Name:  "!=",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver.(*FloatObject).equalTo(args[0]) {
+						return FALSE
+					}
+
+					return TRUE
+				}
+			},
+		},
+		{
+			// Returns the smallest Integer greater than or equal to self.
+			//
+			// ```Ruby
+			// 3.2.ceil    # => 4
+			// (-3.7).ceil # => -3
+			// 3.0.ceil    # => 3
+			// ```
+			// @return [Integer]
+			Name: "ceil",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					f := receiver.(*FloatObject)
+					return f.toInteger(t, math.Ceil(f.value))
+				}
+			},
+		},
+		{
+			// Returns the largest Integer less than or equal to self.
+			//
+			// ```Ruby
+			// 3.7.floor    # => 3
+			// (-3.2).floor # => -4
+			// 3.0.floor    # => 3
+			// ```
+			// @return [Integer]
+			Name: "floor",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					f := receiver.(*FloatObject)
+					return f.toInteger(t, math.Floor(f.value))
+				}
+			},
+		},
+		{
+			// Rounds self to the given decimal digits, which defaults to 0.
+			// Halves are rounded away from zero. The result is a Float if the digits is positive, otherwise an Integer.
+			//
+			// ```Ruby
+			// 3.7.round         # => 4
+			// 2.5.round         # => 3
+			// (-2.5).round      # => -3
+			// 3.14159.round(2)  # => 3.14
+			// 1234.5.round(-2)  # => 1200
+			// ```
+			// @param digits [Integer]
+			// @return [Numeric]
+			Name: "round",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					digits, err := roundDigits(t, args)
+
+					if err != nil {
+						return err
+					}
+
+					f := receiver.(*FloatObject)
+					rounded := roundFloat(f.value, digits)
+
+					if digits > 0 {
+						return t.vm.initFloatObject(rounded)
+					}
+
+					return f.toInteger(t, rounded)
+				}
+			},
+		},
+		{
+			// Returns the Integer part of self, the decimal part is truncated.
+			//
+			// ```Ruby
+			// 3.7.to_i    # => 3
+			// (-3.7).to_i # => -3
+			// ```
+			// @return [Integer]
+			Name: "to_i",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					f := receiver.(*FloatObject)
+					return f.toInteger(t, f.value)
+				}
+			},
+		},
+		{
+			// Returns a `String` representation of self.
+			//
+			// ```Ruby
+			// 3.14.to_s # => "3.14"
+			// 1.0.to_s  # => "1.0"
+			// ```
+			// @return [String]
+			Name: "to_s",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.toString())
+				}
+			},
+		},
+		{
+			// Returns if self is zero.
+			//
+			// ```Ruby
+			// 0.0.zero?    # => true
+			// (-0.0).zero? # => true
+			// 0.1.zero?    # => false
+			// ```
+			// @return [Boolean]
+			Name: "zero?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					f := receiver.(*FloatObject)
+					return toBooleanObject(f.value == 0)
+				}
+			},
+		},
+	}
+}
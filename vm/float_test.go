@@ -0,0 +1,214 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestFloatClassSuperclass(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`Float.class.name`, "Class"},
+		{`Float.superclass.name`, "Object"},
+		{`1.5.class.name`, "Float"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFloatArithmeticOperation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`3.5`, 3.5},
+		{`-3.5`, -3.5},
+		{`-(-3.5)`, 3.5},
+		{`1.5 - -2.5`, 4.0},
+		{`1.5 + 2.5`, 4.0},
+		{`2 + 3.5`, 5.5},
+		{`3.5 + 2`, 5.5},
+		{`5.5 - 2`, 3.5},
+		{`5 - 0.5`, 4.5},
+		{`2.5 * 2`, 5.0},
+		{`2 * 2.5`, 5.0},
+		{`7.5 / 2.5`, 3.0},
+		{`7 / 2.0`, 3.5},
+		{`7 / 2`, 3},
+		{`5.5 % 2`, 1.5},
+		{`1.5 ** 2`, 2.25},
+		{`2 ** 0.5 > 1.41`, true},
+		{`(1.5 + 0.5) * 2`, 4.0},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFloatArithmeticOperationFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`1.5 + "p"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1.5 - "m"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1.5 ** "p"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1.5 / "t"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1.5 > "m"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1.5 >= "m"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1.5 < "m"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1.5 <= "m"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1.5 <=> "m"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFloatComparison(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1.5 > 1`, true},
+		{`1.5 > 1.5`, false},
+		{`1.5 >= 1.5`, true},
+		{`1 < 1.5`, true},
+		{`1.5 <= 1`, false},
+		{`1.0 == 1`, true},
+		{`1 == 1.0`, true},
+		{`1.5 == 1.5`, true},
+		{`1.5 == "1.5"`, false},
+		{`1.5 != 2.5`, true},
+		{`1 != 1.0`, false},
+		{`1.5 <=> 3`, -1},
+		{`1.0 <=> 1`, 0},
+		{`3.5 <=> 1.5`, 1},
+		{`3 <=> 1.5`, 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFloatConversion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`3.7.to_i`, 3},
+		{`(-3.7).to_i`, -3},
+		{`3.14.to_s`, "3.14"},
+		{`1.0.to_s`, "1.0"},
+		{`(0.5 + 0.5).to_s`, "1.0"},
+		{`(1 / 0.0).to_s`, "Infinity"},
+		{`(-1.0 / 0).to_s`, "-Infinity"},
+		{`1.to_s`, "1"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFloatRounding(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`3.7.round`, 4},
+		{`3.2.round`, 3},
+		{`2.5.round`, 3},
+		{`0.5.round`, 1},
+		{`(-2.5).round`, -3},
+		{`(-3.5).round`, -4},
+		{`(-0.5).round`, -1},
+		{`(-3.2).round`, -3},
+		{`3.14159.round(2)`, 3.14},
+		{`(-3.14159).round(3)`, -3.142},
+		{`1.5.round(0)`, 2},
+		{`1234.5.round(-2)`, 1200},
+		{`1250.0.round(-2)`, 1300},
+		{`1.5.round(400)`, 1.5},
+		{`1.5.round(-400)`, 0},
+		{`3.7.floor`, 3},
+		{`(-3.2).floor`, -4},
+		{`3.0.floor`, 3},
+		{`3.2.ceil`, 4},
+		{`(-3.7).ceil`, -3},
+		{`3.0.ceil`, 3},
+		{`3.7.round.class.name`, "Integer"},
+		{`3.14159.round(2).class.name`, "Float"},
+		{`(10.0 ** 30).floor.class.name`, "BigInteger"},
+		{`(10.0 ** 30).to_i.class.name`, "BigInteger"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFloatRoundingFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`1.5.round("1")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`1.5.round(1, 2)`, "ArgumentError: Expect 0 or 1 argument. got: 2", 1},
+		{`1.5.floor(1)`, "ArgumentError: Expect 0 argument. got: 1", 1},
+		{`(1 / 0.0).ceil`, "ArgumentError: Can't convert Infinity into Integer", 1},
+		{`(-1 / 0.0).to_i`, "ArgumentError: Can't convert -Infinity into Integer", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFloatZeroMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`0.0.zero?`, true},
+		{`(-0.0).zero?`, true},
+		{`0.1.zero?`, false},
+		{`(0.5 - 0.5).zero?`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
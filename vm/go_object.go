@@ -43,7 +43,8 @@ func builtinGoClassMethods() []*BuiltInMethodObject {
 func builtinGoInstanceMethods() []*BuiltInMethodObject {
 	return []*BuiltInMethodObject{
 		{
-			Name: "go_func",
+			Name:  "go_func",
+			Arity: &Arity{1, -1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					s, ok := args[0].(*StringObject)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -32,7 +33,10 @@ func (vm *VM) initHashClass() *RClass {
 // Underscore `_` can also be used within the key.
 // String literal like "mickey mouse" cannot be used as a hash key.
 // The internal key is actually a String and **not a Symbol** for now (TBD).
-// Thus only a String object or a string literal should be used when referencing with `[ ]`.
+// A String or a Symbol can be used when referencing with `[ ]`, a Symbol references the key of its name.
+// This is the only place a Symbol and a String are treated the same, `h[:a]` and `h["a"]` are always one entry.
+// Other objects can also be keys with `[]=`, they're looked up by their `hash` and `==` methods.
+// So a class overriding `==` should also override `hash` to return the same Integer for equal objects.
 //
 // ```ruby
 // a = { balthazar1: 100 } # valid
@@ -40,19 +44,47 @@ func (vm *VM) initHashClass() *RClass {
 // x = 'balthazar1'
 //
 // a["balthazar1"]  # => 100
+// a[:balthazar1]   # => 100
 // a[x]             # => 100
 // a[balthazar1]    # => error
+//
+// a[:melchior2] = 1
+// a["melchior2"] = 2
+// a[:melchior2]    # => 2
 // ```
 //
 // - **value:** String literal and objects (Integer, String, Array, Hash, nil, etc) can be used.
 //
+// `Hash.new` creates an empty hash with a default value, which is returned for missing keys instead of `nil`.
+// With a block, the block's result is returned instead. Reading a missing key doesn't add it to the hash.
+//
+// ```ruby
+// counts = Hash.new(0)
+// counts["a"] += 1 # => 1
+// counts["b"]      # => 0
+// counts.keys      # => ["a"]
+//
+// h = Hash.new { |hash, key| key * 2 }
+// h["a"]           # => "aa"
+// ```
+//
 // **Note:**
 // - The order of key-value pairs are **not** preserved.
 // - Operator `=>` is not supported.
-// - `Hash.new` is not supported.
 type HashObject struct {
 	*baseObj
 	Pairs map[string]Object
+	// objectKeys keeps the keys other than Strings and Symbols by their names in Pairs
+	objectKeys map[string]Object
+	// Default is returned for missing keys, nil means no default value
+	Default Object
+	// defaultBlock is yielded with the hash and the missing key when it's given to `Hash.new`
+	defaultBlock *callFrame
+}
+
+// hashKeyObject is implemented by objects that can be used to reference a hash's value
+type hashKeyObject interface {
+	hashKey() string
 }
 
 func (h *HashObject) Value() interface{} {
@@ -67,9 +99,9 @@ func (h *HashObject) toString() string {
 	for _, key := range h.sortedKeys() {
 		// TODO: Improve this conditional statement
 		if _, isString := h.Pairs[key].(*StringObject); isString {
-			pairs = append(pairs, fmt.Sprintf("%s: \"%s\"", key, h.Pairs[key].toString()))
+			pairs = append(pairs, fmt.Sprintf("%s: \"%s\"", h.keyString(key), h.Pairs[key].toString()))
 		} else {
-			pairs = append(pairs, fmt.Sprintf("%s: %s", key, h.Pairs[key].toString()))
+			pairs = append(pairs, fmt.Sprintf("%s: %s", h.keyString(key), h.Pairs[key].toString()))
 		}
 	}
 
@@ -87,7 +119,7 @@ func (h *HashObject) toJSON() string {
 	out.WriteString("{")
 
 	for key, value := range pairs {
-		values = append(values, generateJSONFromPair(key, value))
+		values = append(values, generateJSONFromPair(h.keyString(key), value))
 	}
 
 	out.WriteString(strings.Join(values, ","))
@@ -116,13 +148,151 @@ func (h *HashObject) copy() Object {
 	}
 
 	newHash := &HashObject{
-		baseObj: &baseObj{class: h.class},
-		Pairs:   elems,
+		baseObj:      &baseObj{class: h.class},
+		Pairs:        elems,
+		Default:      h.Default,
+		defaultBlock: h.defaultBlock,
+	}
+
+	for name, key := range h.objectKeys {
+		newHash.setPair(name, key, elems[name])
 	}
 
 	return newHash
 }
 
+// objectKeyName returns the name in Pairs for a key other than a String or a Symbol.
+// Keys with the same `hash` value are numbered by index without gaps, and the NUL character
+// keeps the names from conflicting with String keys.
+func objectKeyName(hash string, index int) string {
+	return fmt.Sprintf("\x00%s:%d", hash, index)
+}
+
+// keyName returns the name of given key in Pairs and whether the hash has the key.
+// Strings and Symbols are named by their values. Other objects are found by their `hash` method
+// and compared with the keys of the same `hash` value by `==`. If the hash doesn't have the key,
+// the returned name is an unused one for setting the key.
+func (h *HashObject) keyName(t *thread, key Object) (string, bool, *Error) {
+	if k, ok := key.(hashKeyObject); ok {
+		name := k.hashKey()
+		_, found := h.Pairs[name]
+		return name, found, nil
+	}
+
+	result := t.sendMethod(key, "hash")
+
+	if err, ok := result.(*Error); ok {
+		return "", false, err
+	}
+
+	var hash string
+
+	switch result := result.(type) {
+	case *IntegerObject, *BigIntegerObject:
+		hash = result.toString()
+	default:
+		return "", false, t.vm.initErrorObject(TypeError, "Expect %s#hash to return Integer. got: %s", key.Class().Name, result.Class().Name)
+	}
+
+	for i := 0; ; i++ {
+		name := objectKeyName(hash, i)
+		k, ok := h.objectKeys[name]
+
+		if !ok {
+			return name, false, nil
+		}
+
+		result := t.sendMethod(key, "==", k)
+
+		if err, ok := result.(*Error); ok {
+			return "", false, err
+		}
+
+		if isTruthy(result) {
+			return name, true, nil
+		}
+	}
+}
+
+// setPair sets the value with the name returned by keyName. An existing key object is kept.
+func (h *HashObject) setPair(name string, key, value Object) {
+	if _, ok := key.(hashKeyObject); !ok {
+		if h.objectKeys == nil {
+			h.objectKeys = map[string]Object{}
+		}
+
+		if _, ok := h.objectKeys[name]; !ok {
+			h.objectKeys[name] = key
+		}
+	}
+
+	h.Pairs[name] = value
+}
+
+// deletePair deletes the pair with the name returned by keyName
+func (h *HashObject) deletePair(name string) {
+	delete(h.Pairs, name)
+
+	if _, ok := h.objectKeys[name]; !ok {
+		return
+	}
+
+	delete(h.objectKeys, name)
+
+	// Move the last key of the same hash value into the deleted one's place, so the indexes stay without gaps
+	sep := strings.LastIndex(name, ":")
+	prefix := name[:sep+1]
+	index, _ := strconv.Atoi(name[sep+1:])
+	last := index
+
+	for {
+		if _, ok := h.objectKeys[prefix+strconv.Itoa(last+1)]; !ok {
+			break
+		}
+
+		last++
+	}
+
+	if last != index {
+		lastName := prefix + strconv.Itoa(last)
+		h.Pairs[name], h.objectKeys[name] = h.Pairs[lastName], h.objectKeys[lastName]
+		delete(h.Pairs, lastName)
+		delete(h.objectKeys, lastName)
+	}
+}
+
+// keyObject returns the key object of given name in Pairs, which is a String unless the key is another object
+func (h *HashObject) keyObject(vm *VM, name string) Object {
+	if key, ok := h.objectKeys[name]; ok {
+		return key
+	}
+
+	return vm.initStringObject(name)
+}
+
+// keyString returns the string representation of given name's key
+func (h *HashObject) keyString(name string) string {
+	if key, ok := h.objectKeys[name]; ok {
+		return key.toString()
+	}
+
+	return name
+}
+
+// defaultValue returns the value of a missing key, which is the default block's result or the default value.
+// The default block isn't given to the method reading the key, so an error raised in the block is returned as the result.
+func (h *HashObject) defaultValue(t *thread, key Object) Object {
+	if h.defaultBlock != nil {
+		return t.yieldBlock(h.defaultBlock, h, key).Target
+	}
+
+	if h.Default != nil {
+		return h.Default
+	}
+
+	return NULL
+}
+
 // Other helper functions ----------------------------------------------
 func generateJSONFromPair(key string, v Object) string {
 	var data string
@@ -139,10 +309,41 @@ func generateJSONFromPair(key string, v Object) string {
 func builtInHashClassMethods() []*BuiltInMethodObject {
 	return []*BuiltInMethodObject{
 		{
-			Name: "new",
+			// Returns an empty hash with the default value of missing keys, which is nil by default.
+			// With a block, the block is called with the hash and the missing key, and its result is returned instead.
+			// A missing key is added only when the block assigns it.
+			//
+			// ```ruby
+			// h = Hash.new(0)
+			// h["a"]        # => 0
+			// h["b"] += 1   # => 1
+			// h.to_s        # => "{ b: 1 }"
+			//
+			// h = Hash.new do |hash, key|
+			//   hash[key] = key + "!"
+			// end
+			// h["a"]        # => "a!"
+			// h.length      # => 1
+			// ```
+			//
+			// @param default value [Object]
+			// @return [Hash]
+			Name:  "new",
+			Arity: &Arity{0, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					return t.unsupportedMethodError("#new", receiver)
+					if len(args) == 1 && blockFrame != nil {
+						return t.vm.initErrorObject(ArgumentError, "Expect either a default value or a block. got both")
+					}
+
+					h := t.vm.initHashObject(map[string]Object{})
+					h.defaultBlock = blockFrame
+
+					if len(args) == 1 {
+						h.Default = args[0]
+					}
+
+					return h
 				}
 			},
 		},
@@ -153,7 +354,7 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 	return []*BuiltInMethodObject{
 		{
 			// Retrieves the value (object) that corresponds to the key specified.
-			// Returns `nil` when specifying a nonexistent key.
+			// Returns `nil`, or the default value given to `Hash.new`, when specifying a nonexistent key.
 			//
 			// ```Ruby
 			// h = { a: 1, b: "2", c: [1, 2, 3], d: { k: 'v' } }
@@ -161,6 +362,8 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 			// h['b'] #=> "2"
 			// h['c'] #=> [1, 2, 3]
 			// h['d'] #=> { k: 'v' }
+			// h['e'] #=> nil
+			// Hash.new(0)['e'] #=> 0
 			// ```
 			//
 			// @return [Object]
@@ -172,26 +375,23 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 						return t.vm.initErrorObject(ArgumentError, "Expect 1 argument. got: %d", len(args))
 					}
 
-					i := args[0]
-					key, ok := i.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, i.Class().Name)
-					}
-
 					h := receiver.(*HashObject)
 
 					if len(h.Pairs) == 0 {
-						return NULL
+						return h.defaultValue(t, args[0])
 					}
 
-					value, ok := h.Pairs[key.value]
+					name, found, err := h.keyName(t, args[0])
 
-					if !ok {
-						return NULL
+					if err != nil {
+						return err
 					}
 
-					return value
+					if !found {
+						return h.defaultValue(t, args[0])
+					}
+
+					return h.Pairs[name]
 				}
 			},
 		},
@@ -211,6 +411,9 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 			Name: "[]=",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver.isFrozen() {
+						return t.frozenError(receiver)
+					}
 
 					// First arg is index
 					// Second arg is assigned value
@@ -218,15 +421,14 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 						return t.vm.initErrorObject(ArgumentError, "Expect 2 arguments. got: %d", len(args))
 					}
 
-					k := args[0]
-					key, ok := k.(*StringObject)
+					h := receiver.(*HashObject)
+					name, _, err := h.keyName(t, args[0])
 
-					if !ok {
-						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, k.Class().Name)
+					if err != nil {
+						return err
 					}
 
-					h := receiver.(*HashObject)
-					h.Pairs[key.value] = args[1]
+					h.setPair(name, args[0], args[1])
 
 					return args[1]
 				}
@@ -252,6 +454,42 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 				}
 			},
 		},
+		{
+			// Loop through each key-value pair of the hash with given block frame, and returns the hash itself.
+			// Pairs are yielded in the alphabetical order of their keys rather than in insertion order,
+			// because the pairs are stored in a Go map which doesn't keep any order.
+			//
+			// ```Ruby
+			// h = { b: "2", a: 1 }
+			// h.each do |k, v|
+			//   puts(k + ": " + v.to_s)
+			// end
+			// # => a: 1
+			// # => b: 2
+			// ```
+			//
+			// @return [Hash]
+			Name: "each",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
+					}
+
+					h := receiver.(*HashObject)
+
+					for _, k := range h.sortedKeys() {
+						t.builtInMethodYield(blockFrame, h.keyObject(t.vm, k), h.Pairs[k])
+					}
+
+					return h
+				}
+			},
+		},
 		{
 			// Loop through keys of the hash with given block frame. It also returns array of
 			// keys in alphabetical order.
@@ -284,7 +522,7 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 					var arrOfKeys []Object
 
 					for _, k := range keys {
-						obj := t.vm.initStringObject(k)
+						obj := h.keyObject(t.vm, k)
 						arrOfKeys = append(arrOfKeys, obj)
 						t.builtInMethodYield(blockFrame, obj)
 					}
@@ -350,10 +588,7 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					if h.length() == 0 {
-						return TRUE
-					}
-					return FALSE
+					return toBooleanObject(h.length() == 0)
 				}
 			},
 		},
@@ -376,10 +611,7 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 					c := args[0]
 					compare, ok := c.(*HashObject)
 
-					if ok && reflect.DeepEqual(h, compare) {
-						return TRUE
-					}
-					return FALSE
+					return toBooleanObject(ok && reflect.DeepEqual(h, compare))
 				}
 			},
 		},
@@ -395,61 +627,49 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 			Name: "delete",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver.isFrozen() {
+						return t.frozenError(receiver)
+					}
+
 					if len(args) != 1 {
 						return t.vm.initErrorObject(ArgumentError, "Expect 1 argument. got: %d", len(args))
 					}
 
 					h := receiver.(*HashObject)
-					d := args[0]
-					deleteKey, ok := d.(*StringObject)
+					name, found, err := h.keyName(t, args[0])
 
-					if !ok {
-						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, d.Class().Name)
+					if err != nil {
+						return err
 					}
 
-					deleteKeyValue := deleteKey.value
-					if _, ok := h.Pairs[deleteKeyValue]; ok {
-						delete(h.Pairs, deleteKeyValue)
+					if found {
+						h.deletePair(name)
 					}
 					return h
 				}
 			},
 		},
 		{
-			// Returns true if the key exist in the hash. Currently, it can only input string
-			// type object.
+			// Returns true if the key exist in the hash. The key can be a String or a Symbol.
 			//
 			// ```Ruby
 			// h = { a: 1, b: "2", c: [1, 2, 3], d: { k: "v" } }
 			// h.has_key?("a") # => true
 			// h.has_key?("e") # => false
-			// # TODO: Support Symbol Type Key Input
 			// h.has_key?(:b)  # => true
 			// h.has_key?(:f)  # => false
 			// ```
 			//
 			// @return [Boolean]
 			Name: "has_key?",
-			Fn: func(receiver Object) builtinMethodBody {
-				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					if len(args) != 1 {
-						return t.vm.initErrorObject(ArgumentError, "Expect 1 argument. got: %d", len(args))
-					}
-
-					h := receiver.(*HashObject)
-					i := args[0]
-					input, ok := i.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, i.Class().Name)
-					}
-
-					if _, ok := h.Pairs[input.value]; ok {
-						return TRUE
-					}
-					return FALSE
-				}
-			},
+			Fn:   hasKey,
+		},
+		{
+			// Same as `has_key?`.
+			//
+			// @return [Boolean]
+			Name: "key?",
+			Fn:   hasKey,
 		},
 		{
 			// Returns true if the value exist in the hash.
@@ -483,14 +703,14 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			// Returns an array of keys (in arbitrary order)
+			// Returns an array of keys in alphabetical order (not in insertion order).
 			//
 			// ```Ruby
-			// { a: 1, b: "2", c: [3, true, "Hello"] }.keys
-			// # =>  ["c", "b", "a"] or ["b", "a", "c"] ... etc
+			// { c: 1, a: "2", b: [3, true, "Hello"] }.keys
+			// # => ["a", "b", "c"]
 			// ```
 			//
-			// @return [Boolean]
+			// @return [Array]
 			Name: "keys",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
@@ -499,9 +719,9 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					var keys []Object
-					for k := range h.Pairs {
-						keys = append(keys, t.vm.initStringObject(k))
+					keys := []Object{}
+					for _, k := range h.sortedKeys() {
+						keys = append(keys, h.keyObject(t.vm, k))
 					}
 					return t.vm.initArrayObject(keys)
 				}
@@ -545,6 +765,10 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 			Name: "map_values",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver.isFrozen() {
+						return t.frozenError(receiver)
+					}
+
 					if len(args) != 0 {
 						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
 					}
@@ -580,10 +804,7 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					result := make(map[string]Object)
-					for k, v := range h.Pairs {
-						result[k] = v
-					}
+					result := h.copy().(*HashObject)
 
 					for _, obj := range args {
 						hashObj, ok := obj.(*HashObject)
@@ -591,11 +812,25 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 							return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, hashClass, obj.Class().Name)
 						}
 						for k, v := range hashObj.Pairs {
-							result[k] = v
+							key, ok := hashObj.objectKeys[k]
+
+							if !ok {
+								result.Pairs[k] = v
+								continue
+							}
+
+							// Names of object keys differ between hashes, so they're looked up again
+							name, _, err := result.keyName(t, key)
+
+							if err != nil {
+								return err
+							}
+
+							result.setPair(name, key, v)
 						}
 					}
 
-					return t.vm.initHashObject(result)
+					return result
 				}
 			},
 		},
@@ -625,7 +860,7 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 					sortedKeys := h.sortedKeys()
 					var keys []Object
 					for _, k := range sortedKeys {
-						keys = append(keys, t.vm.initStringObject(k))
+						keys = append(keys, h.keyObject(t.vm, k))
 					}
 					return t.vm.initArrayObject(keys)
 				}
@@ -671,14 +906,14 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 					if sorted {
 						for _, k := range h.sortedKeys() {
 							var pairArr []Object
-							pairArr = append(pairArr, t.vm.initStringObject(k))
+							pairArr = append(pairArr, h.keyObject(t.vm, k))
 							pairArr = append(pairArr, h.Pairs[k])
 							resultArr = append(resultArr, t.vm.initArrayObject(pairArr))
 						}
 					} else {
 						for k, v := range h.Pairs {
 							var pairArr []Object
-							pairArr = append(pairArr, t.vm.initStringObject(k))
+							pairArr = append(pairArr, h.keyObject(t.vm, k))
 							pairArr = append(pairArr, v)
 							resultArr = append(resultArr, t.vm.initArrayObject(pairArr))
 						}
@@ -758,24 +993,24 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					resultHash := make(map[string]Object)
+					resultHash := t.vm.initHashObject(make(map[string]Object))
 					for k, v := range h.Pairs {
 						result := t.builtInMethodYield(blockFrame, v)
-						resultHash[k] = result.Target
+						resultHash.setPair(k, h.keyObject(t.vm, k), result.Target)
 					}
-					return t.vm.initHashObject(resultHash)
+					return resultHash
 				}
 			},
 		},
 		{
-			// Returns an array of values (in arbitrary order)
+			// Returns an array of values in the alphabetical order of their keys (not in insertion order).
 			//
 			// ```Ruby
-			// { a: 1, b: "2", c: [3, true, "Hello"] }.keys
-			// # =>  [1, "2", [3, true, "Hello"]] or ["2", [3, true, "Hello"], 1] ... etc
+			// { c: 1, a: "2", b: [3, true, "Hello"] }.values
+			// # => ["2", [3, true, "Hello"], 1]
 			// ```
 			//
-			// @return [Boolean]
+			// @return [Array]
 			Name: "values",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
@@ -784,13 +1019,31 @@ func builtinHashInstanceMethods() []*BuiltInMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					var keys []Object
-					for _, v := range h.Pairs {
-						keys = append(keys, v)
+					values := []Object{}
+					for _, k := range h.sortedKeys() {
+						values = append(values, h.Pairs[k])
 					}
-					return t.vm.initArrayObject(keys)
+					return t.vm.initArrayObject(values)
 				}
 			},
 		},
 	}
 }
+
+// hasKey is the implementation of `has_key?` and `key?`
+func hasKey(receiver Object) builtinMethodBody {
+	return func(t *thread, args []Object, blockFrame *callFrame) Object {
+		if len(args) != 1 {
+			return t.vm.initErrorObject(ArgumentError, "Expect 1 argument. got: %d", len(args))
+		}
+
+		h := receiver.(*HashObject)
+		_, found, err := h.keyName(t, args[0])
+
+		if err != nil {
+			return err
+		}
+
+		return toBooleanObject(found)
+	}
+}
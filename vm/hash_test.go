@@ -76,6 +76,15 @@ func TestHashAccessOperation(t *testing.T) {
 		{`
 			{ bar: "foo" }["bar"]
 		`, "foo"},
+		{`
+			{ "bar" => "foo" }["bar"]
+		`, "foo"},
+		{`
+			{ "foo bar" => 1, baz: 2 }["foo bar"]
+		`, 1},
+		{`
+			{ "foo" => 1 }["bar"]
+		`, nil},
 		{`
 			{ foo: 2, bar: "foo" }[:foo]
 		`, 2},
@@ -116,6 +125,17 @@ func TestHashAccessOperation(t *testing.T) {
 			h["foo"] = h["bar"] * h["baz"]
 			h["foo"]
 		`, 50},
+		// A Symbol and a String with the same name reference the same key
+		{`
+			h = {}
+			h[:a] = 1
+			h["a"] = 2
+			h.length.to_s + h[:a].to_s + h["a"].to_s
+		`, "122"},
+		{`
+			h = { "a" => 1 }
+			h[:a]
+		`, 1},
 	}
 
 	for i, tt := range tests {
@@ -130,8 +150,24 @@ func TestHashAccessOperation(t *testing.T) {
 func TestHashAccessOperationFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ a: 1, b: 2 }[]`, "ArgumentError: Expect 1 argument. got: 0", 1},
-		{`{ a: 1, b: 2 }[true]`, "TypeError: Expect argument to be String. got: Boolean", 1},
-		{`{ a: 1, b: 2 }[true] = 1`, "TypeError: Expect argument to be String. got: Boolean", 1},
+		{`
+		class Foo
+		  def hash
+		    "1"
+		  end
+		end
+
+		{ a: 1 }[Foo.new]
+		`, "TypeError: Expect Foo#hash to return Integer. got: String", 8},
+		{`
+		class Foo
+		  def hash
+		    nil
+		  end
+		end
+
+		{ a: 1 }[Foo.new] = 1
+		`, "TypeError: Expect Foo#hash to return Integer. got: Null", 8},
 	}
 
 	for i, tt := range testsFail {
@@ -143,6 +179,264 @@ func TestHashAccessOperationFail(t *testing.T) {
 	}
 }
 
+func TestHashObjectKeys(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		h = {}
+		h[1] = "one"
+		h[[1, 2]] = "array"
+		h[nil] = "nil"
+		[h[1], h[[1, 2]], h[nil], h[2], h.length].to_s
+		`, `["one", "array", "nil", nil, 3]`},
+		// String keys and other objects with the same content don't collide
+		{`
+		h = {}
+		h["1"] = "string"
+		h[1] = "integer"
+		[h["1"], h[1], h.length].to_s
+		`, `["string", "integer", 2]`},
+		// Keys are found by their hash and ==
+		{`
+		class Point
+		  attr_reader :x, :y
+
+		  def initialize(x, y)
+		    @x = x
+		    @y = y
+		  end
+
+		  def ==(other)
+		    other.is_a?(Point) && @x == other.x && @y == other.y
+		  end
+
+		  def hash
+		    @x.hash + @y.hash
+		  end
+		end
+
+		h = {}
+		h[Point.new(1, 2)] = "a"
+		h[Point.new(1, 2)] = "b"
+		[h[Point.new(1, 2)], h.length].to_s
+		`, `["b", 1]`},
+		// Keys with the same hash but not == are kept apart
+		{`
+		class Point
+		  attr_reader :x
+
+		  def initialize(x)
+		    @x = x
+		  end
+
+		  def ==(other)
+		    other.is_a?(Point) && @x == other.x
+		  end
+
+		  def hash
+		    1
+		  end
+		end
+
+		h = {}
+		h[Point.new(1)] = "a"
+		h[Point.new(2)] = "b"
+		h[Point.new(3)] = "c"
+		h.delete(Point.new(1))
+		[h[Point.new(1)], h[Point.new(2)], h[Point.new(3)], h.length, h.has_key?(Point.new(3))].to_s
+		`, `[nil, "b", "c", 2, true]`},
+		// Instances without custom == and hash are looked up by identity
+		{`
+		class Foo
+		end
+
+		f = Foo.new
+		h = {}
+		h[f] = 1
+		[h[f], h[Foo.new], h.keys[0] == f].to_s
+		`, "[1, nil, true]"},
+		{`
+		h = { b: "b" }
+		h[1] = "a"
+		keys = []
+		h.each do |k, v|
+		  keys.push(k.class.name)
+		end
+		keys.sort.to_s
+		`, `["Integer", "String"]`},
+		{`
+		h = {}
+		h[1] = "a"
+		other = {}
+		other[1] = "b"
+		other[2] = "c"
+		h.merge(other).to_s
+		`, `{ 1: "b", 2: "c" }`},
+		{`
+		h = {}
+		h[1] = 2
+		h.transform_values do |v|
+		  v * 10
+		end[1]
+		`, 20},
+		{`
+		h = {}
+		h[1] = "a"
+		h.to_a.to_s
+		`, `[[1, "a"]]`},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestHashNewMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Hash.new.length`, 0},
+		{`Hash.new["foo"]`, nil},
+		{`Hash.new(0)["foo"]`, 0},
+		{`Hash.new(0)[1]`, 0},
+		{`
+		h = Hash.new(0)
+		h["foo"]
+		h.length
+		`, 0},
+		{`
+		h = Hash.new(0)
+		h["foo"] = 10
+		h["foo"]
+		`, 10},
+		{`
+		counts = Hash.new(0)
+		["a", "b", "a"].each do |w|
+		  counts[w] += 1
+		end
+		counts.to_s
+		`, "{ a: 2, b: 1 }"},
+		{`
+		h = Hash.new([])
+		h["a"].push(1)
+		h["b"].to_s + h.length.to_s
+		`, "[1]0"},
+		{`
+		h = Hash.new do |hash, key|
+		  key * 2
+		end
+		h["ab"] + h.length.to_s
+		`, "abab0"},
+		{`
+		h = Hash.new do |hash, key|
+		  hash[key] = key + "!"
+		end
+		h["a"]
+		h["a"] + h.length.to_s
+		`, "a!1"},
+		{`
+		n = 10
+		h = Hash.new do |hash, key|
+		  n + key
+		end
+		h[5]
+		`, 15},
+		// The default value and block are kept by copies
+		{`Hash.new(0).dup["x"]`, 0},
+		{`Hash.new(0).clone["x"]`, 0},
+		{`Hash.new(0).merge({ a: 1 })["x"]`, 0},
+		{`
+		h = Hash.new do |hash, key|
+		  key * 2
+		end
+		h.dup["ab"]
+		`, "abab"},
+		{`
+		h = Hash.new do |hash, key|
+		  hash[key] = key + "!"
+		end
+		d = h.dup
+		d["a"]
+		d.length.to_s + h.length.to_s
+		`, "10"},
+		{`
+		h = Hash.new do |hash, key|
+		  raise "missing"
+		end
+
+		begin
+		  h["a"]
+		rescue => e
+		  e.class.name
+		end
+		`, "RuntimeError"},
+		{`
+		h = Hash.new do |hash, key|
+		  break 5
+		end
+
+		begin
+		  h["a"]
+		rescue => e
+		  e.class.name
+		end
+		`, "InternalError"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestHashNewMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Hash.new(1, 2)`, "ArgumentError: Expect at most 1 args for method 'new'. got: 2", 1},
+		{`Hash.new(0) do |h, k|
+		  1
+		end`, "ArgumentError: Expect either a default value or a block. got both", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestHashDefaultBlockFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`h = Hash.new do |h, k|
+		  raise ArgumentError, "missing " + k
+		end
+		h["a"]`, "ArgumentError: missing a", 2},
+		{`h = Hash.new do |h, k|
+		  break 5
+		end
+		h["a"]`, "InternalError: Can't break from a block whose method call has returned", 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 2)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestHashComparisonOperation(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -221,6 +515,67 @@ func TestHashClearMethodFail(t *testing.T) {
 	}
 }
 
+func TestHashEachMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+			result = []
+			{ b: "2", c: 3, a: 1 }.each do |k, v|
+			  result.push(k + "=" + v.to_s)
+			end
+			result.join(",")
+		`, "a=1,b=2,c=3"},
+		{`
+			sum = 0
+			{ a: 1, b: 2 }.each do |k, v|
+			  sum = sum + v
+			end
+			sum
+		`, 3},
+		{`
+			h = { a: 1 }
+			h.each do |k, v|
+			  # Empty Block
+			end.length
+		`, 1},
+		{`
+			count = 0
+			{}.each do |k, v|
+			  count = count + 1
+			end
+			count
+		`, 0},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestHashEachMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`{ a: 1, b: 2 }.each("Hello") do |k, v|
+		  puts(k)
+		end
+		`, "ArgumentError: Expect 0 argument. got: 1", 1},
+		{`{ a: 1, b: 2 }.each`, "InternalError: Can't yield without a block", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestHashEachKeyMethod(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -493,8 +848,29 @@ func TestHashDeleteMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ a: 1, b: "Hello", c: true }.delete`, "ArgumentError: Expect 1 argument. got: 0", 1},
 		{`{ a: 1, b: "Hello", c: true }.delete("a", "b")`, "ArgumentError: Expect 1 argument. got: 2", 1},
-		{`{ a: 1, b: "Hello", c: true }.delete(123)`, "TypeError: Expect argument to be String. got: Integer", 1},
-		{`{ a: 1, b: "Hello", c: true }.delete(true)`, "TypeError: Expect argument to be String. got: Boolean", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestHashFrozenFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`{ a: 1 }.freeze.delete("a")`, "FrozenError: Can't modify frozen Hash: { a: 1 }", 1},
+		{`
+		h = { a: 1 }.freeze
+		h["b"] = 2
+		`, "FrozenError: Can't modify frozen Hash: { a: 1 }", 3},
+		{`
+		{ a: 1 }.freeze.map_values do |v|
+		  v + 1
+		end
+		`, "FrozenError: Can't modify frozen Hash: { a: 1 }", 2},
 	}
 
 	for i, tt := range testsFail {
@@ -513,6 +889,16 @@ func TestHashHasKeyMethod(t *testing.T) {
 	}{
 		{`{ a: "Hello", b: 123, c: true }.has_key?("a")`, true},
 		{`{ a: "Hello", b: 123, c: true }.has_key?("d")`, false},
+		{`{ a: "Hello", b: 123, c: true }.has_key?(:b)`, true},
+		{`{}.has_key?(:b)`, false},
+		{`{ a: "Hello", b: 123, c: true }.key?("a")`, true},
+		{`{ a: "Hello", b: 123, c: true }.key?(:d)`, false},
+		{`{ a: 1, b: 2 }.has_key?(true)`, false},
+		{`
+		h = {}
+		h[123] = 1
+		h.has_key?(123) && h.key?(123)
+		`, true},
 	}
 
 	for i, tt := range tests {
@@ -528,8 +914,7 @@ func TestHashHasKeyMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ a: 1, b: 2 }.has_key?`, "ArgumentError: Expect 1 argument. got: 0", 1},
 		{`{ a: 1, b: 2 }.has_key?(true, { hello: "World" })`, "ArgumentError: Expect 1 argument. got: 2", 1},
-		{`{ a: 1, b: 2 }.has_key?(true)`, "TypeError: Expect argument to be String. got: Boolean", 1},
-		{`{ a: 1, b: 2 }.has_key?(123)`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`{ a: 1, b: 2 }.key?`, "ArgumentError: Expect 1 argument. got: 0", 1},
 	}
 
 	for i, tt := range testsFail {
@@ -1244,3 +1629,23 @@ func compareJSONResult(t *testing.T, evaluated Object, exp interface{}) {
 		t.Fatalf("Expect json:\n%s \n\n got: %s", string(expected), s)
 	}
 }
+
+func TestHashKeysAndValuesMethodOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []interface{}
+	}{
+		{`{ c: 1, a: "2", b: true }.keys`, []interface{}{"a", "b", "c"}},
+		{`{ c: 1, a: "2", b: true }.values`, []interface{}{"2", true, 1}},
+		{`{}.keys`, []interface{}{}},
+		{`{}.values`, []interface{}{}},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		testArrayObject(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
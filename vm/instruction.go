@@ -29,6 +29,7 @@ type instructionSet struct {
 	instructions []*instruction
 	filename     filename
 	argTypes     []int
+	argNames     []string
 }
 
 func (is *instructionSet) define(line int, a *action, params ...interface{}) *instruction {
@@ -44,6 +45,13 @@ var builtInActions = map[operationType]*action{
 			t.stack.pop()
 		},
 	},
+	bytecode.Dup: {
+		name: bytecode.Dup,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			obj := t.stack.top()
+			t.stack.push(&Pointer{Target: obj.Target})
+		},
+	},
 	bytecode.PutObject: {
 		name: bytecode.PutObject,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
@@ -51,6 +59,13 @@ var builtInActions = map[operationType]*action{
 			t.stack.push(&Pointer{Target: object})
 		},
 	},
+	bytecode.PutFloat: {
+		name: bytecode.PutFloat,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			object := t.vm.initFloatObject(args[0].(float64))
+			t.stack.push(&Pointer{Target: object})
+		},
+	},
 	bytecode.GetConstant: {
 		name: bytecode.GetConstant,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
@@ -102,11 +117,64 @@ var builtInActions = map[operationType]*action{
 			t.stack.push(p)
 		},
 	},
+	bytecode.GetClassVariable: {
+		name: bytecode.GetClassVariable,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			variableName := args[0].(string)
+			c := classOf(cf.self)
+			v, ok := c.classVariableGet(variableName)
+
+			if !ok {
+				t.stack.push(&Pointer{Target: t.vm.initErrorObject(NameError, "uninitialized class variable %s in %s", variableName, c.Name)})
+				return
+			}
+
+			t.stack.push(&Pointer{Target: v})
+		},
+	},
+	bytecode.SetClassVariable: {
+		name: bytecode.SetClassVariable,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			variableName := args[0].(string)
+			p := t.stack.pop()
+			classOf(cf.self).classVariableSet(variableName, p.Target)
+			t.stack.push(p)
+		},
+	},
+	bytecode.GetGlobalVariable: {
+		name: bytecode.GetGlobalVariable,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			variableName := args[0].(string)
+			v, ok := t.vm.globalVariables.get(variableName)
+
+			if !ok {
+				t.stack.push(&Pointer{Target: NULL})
+				return
+			}
+
+			t.stack.push(&Pointer{Target: v})
+		},
+	},
+	bytecode.SetGlobalVariable: {
+		name: bytecode.SetGlobalVariable,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			variableName := args[0].(string)
+			p := t.stack.pop()
+			t.vm.globalVariables.set(variableName, p.Target)
+			t.stack.push(p)
+		},
+	},
 	bytecode.SetInstanceVariable: {
 		name: bytecode.SetInstanceVariable,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
 			variableName := args[0].(string)
 			p := t.stack.pop()
+
+			if cf.self.isFrozen() {
+				t.stack.push(&Pointer{Target: t.frozenError(cf.self)})
+				return
+			}
+
 			cf.self.instanceVariableSet(variableName, p.Target)
 
 			var obj Object
@@ -167,13 +235,13 @@ var builtInActions = map[operationType]*action{
 		name: bytecode.SetConstant,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
 			constName := args[0].(string)
-			c := t.vm.lookupConstant(cf, constName)
+			// Only check current scope, so a class can have its own constant with the same name as outer scope's
+			_, defined := classOf(cf.self).constants[constName]
 			v := t.stack.pop()
 
-			if c != nil {
-				err := t.vm.initErrorObject(ConstantAlreadyInitializedError, "Constant %s already been initialized. Can't assign value to a constant twice.", constName)
-				t.stack.push(&Pointer{Target: err})
-				return
+			// Reassigning a constant still works like in Ruby, but it's warned
+			if defined {
+				t.vm.warn(cf, "already initialized constant %s", constName)
 			}
 
 			cf.storeConstant(constName, v)
@@ -182,10 +250,19 @@ var builtInActions = map[operationType]*action{
 	bytecode.NewRange: {
 		name: bytecode.NewRange,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
-			rangeEnd := t.stack.pop().Target.(*IntegerObject).value
-			rangeStart := t.stack.pop().Target.(*IntegerObject).value
+			exclusive := args[0].(int) == 1
+			rangeEnd := t.stack.pop().Target
+			rangeStart := t.stack.pop().Target
+
+			// Ranges only have Integers, so BigIntegers and Floats can't be the ends
+			for _, o := range []Object{rangeStart, rangeEnd} {
+				if _, ok := o.(*IntegerObject); !ok {
+					t.returnError(TypeError, WrongArgumentTypeFormat, integerClass, o.Class().Name)
+					return
+				}
+			}
 
-			t.stack.push(&Pointer{Target: t.vm.initRangeObject(rangeStart, rangeEnd)})
+			t.stack.push(&Pointer{Target: t.vm.initRangeObject(rangeStart.(*IntegerObject).value, rangeEnd.(*IntegerObject).value, exclusive)})
 		},
 	},
 	bytecode.NewArray: {
@@ -207,11 +284,12 @@ var builtInActions = map[operationType]*action{
 		name: bytecode.ExpandArray,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
 			arrLength := args[0].(int)
-			arr, ok := t.stack.pop().Target.(*ArrayObject)
+			v := t.stack.pop().Target
+			arr, ok := v.(*ArrayObject)
 
+			// `a, b = 1` assigns 1 to a and nil to b
 			if !ok {
-				t.returnError(TypeError, "Expect stack top's value to be an Array when executing 'expandarray' instruction.")
-				return
+				arr = t.vm.initArrayObject([]Object{v})
 			}
 
 			elems := []Object{}
@@ -279,15 +357,18 @@ var builtInActions = map[operationType]*action{
 			v := t.stack.pop()
 			bool, isBool := v.Target.(*BooleanObject)
 
-			if isBool {
-				if !bool.value {
-					return
-				}
+			if isBool && !bool.value {
+				return
+			}
 
-				line := args[0].(int)
-				cf.pc = line
+			_, isNull := v.Target.(*NullObject)
+
+			if isNull {
 				return
 			}
+
+			line := args[0].(int)
+			cf.pc = line
 		},
 	},
 	bytecode.Jump: {
@@ -309,6 +390,13 @@ var builtInActions = map[operationType]*action{
 			t.stack.push(&Pointer{Target: object})
 		},
 	},
+	bytecode.PutSymbol: {
+		name: bytecode.PutSymbol,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			object := t.vm.initSymbolObject(args[0].(string))
+			t.stack.push(&Pointer{Target: object})
+		},
+	},
 	bytecode.PutNull: {
 		name: bytecode.PutNull,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
@@ -332,10 +420,12 @@ var builtInActions = map[operationType]*action{
 			v := t.stack.pop().Target
 			switch self := v.(type) {
 			case *RClass:
-				self.Methods.set(methodName, method)
+				method.owner = self
 			default:
-				self.Class().Methods.set(methodName, method)
+				method.owner = self.Class()
 			}
+
+			method.owner.setMethod(methodName, method)
 		},
 	},
 	bytecode.DefSingletonMethod: {
@@ -350,10 +440,16 @@ var builtInActions = map[operationType]*action{
 
 			switch v := v.(type) {
 			case *RClass:
-				v.SingletonClass().Methods.set(methodName, method)
+				method.owner = v.SingletonClass()
+				v.SingletonClass().setMethod(methodName, method)
+			case *IntegerObject, *SymbolObject:
+				// Integer and Symbol objects are shared, so we can't define singleton methods on them
+				err := t.vm.initErrorObject(TypeError, "can't define singleton method '%s' for %s", methodName, v.toString())
+				t.stack.push(&Pointer{Target: err})
 			default:
 				singletonClass := t.vm.createRClass(fmt.Sprintf("#<Class:#<%s:%s>>", v.Class().Name, v.id()))
-				singletonClass.Methods.set(methodName, method)
+				method.owner = singletonClass
+				singletonClass.setMethod(methodName, method)
 				singletonClass.isSingleton = true
 				v.SetSingletonClass(singletonClass)
 			}
@@ -367,27 +463,45 @@ var builtInActions = map[operationType]*action{
 
 			classPtr := cf.lookupConstant(subjectName)
 
-			if classPtr == nil {
-				class := t.vm.initializeClass(subjectName, subjectType == "module")
-				classPtr = cf.storeConstant(class.Name, class)
+			// Object isn't stored as a constant, so we need to find it explicitly for reopening it
+			if classPtr == nil && subjectName == objectClass {
+				classPtr = &Pointer{Target: t.vm.objectClass}
+			}
 
-				if len(args) >= 2 {
-					superClassName := args[1].(string)
-					superClass := t.vm.lookupConstant(cf, superClassName)
-					inheritedClass, ok := superClass.Target.(*RClass)
+			var inheritedClass *RClass
 
-					if !ok {
-						t.returnError(InternalError, "Constant %s is not a class. got=%s", superClassName, string(superClass.Target.Class().ReturnName()))
-						return
-					}
+			if len(args) >= 2 {
+				var ok bool
+				superClassName := args[1].(string)
+				superClass := t.vm.lookupConstant(cf, superClassName)
+				inheritedClass, ok = superClass.Target.(*RClass)
 
-					if inheritedClass.isModule {
-						t.returnError(InternalError, "Module inheritance is not supported: %s", inheritedClass.Name)
-						return
-					}
+				if !ok {
+					t.returnError(InternalError, "Constant %s is not a class. got=%s", superClassName, string(superClass.Target.Class().ReturnName()))
+					return
+				}
+
+				if inheritedClass.isModule {
+					t.returnError(InternalError, "Module inheritance is not supported: %s", inheritedClass.Name)
+					return
+				}
+			}
+
+			if classPtr == nil {
+				class := t.vm.initializeClass(subjectName, subjectType == "module")
+				classPtr = cf.storeConstant(class.Name, class)
 
+				if inheritedClass != nil {
 					class.inherits(inheritedClass)
 				}
+			} else if class, ok := classPtr.Target.(*RClass); ok && inheritedClass != nil && class.returnSuperClass() != inheritedClass {
+				// Reopening a class can't change its superclass, so the inheritance chain can never form a cycle.
+				// superClass may point to an included module, so the class it inherits is compared instead.
+				// Pop the superclass and self before returning the error
+				t.stack.pop()
+				t.stack.pop()
+				t.returnError(TypeError, "superclass mismatch for class %s", class.Name)
+				return
 			}
 
 			is := t.getClassIS(subjectName, cf.instructionSet.filename)
@@ -412,19 +526,51 @@ var builtInActions = map[operationType]*action{
 			receiverPr := argPr - 1
 			receiver := t.stack.Data[receiverPr].Target
 
+			// This only happens when the instructions are broken, but it's better than a Go-level panic
+			if receiver == nil {
+				t.sp = receiverPr
+				t.returnError(InternalError, "Can't send method '%s' to a nil receiver", methodName)
+				return
+			}
+
 			method = receiver.findMethod(methodName)
 
 			if method == nil {
-				err := t.vm.initErrorObject(UndefinedMethodError, "Undefined Method '%+v' for %+v", methodName, receiver.toString())
-				t.stack.set(receiverPr, &Pointer{Target: err})
-				t.sp = argPr
-				return
+				method = t.findMethodMissing(receiver)
+
+				if method == nil {
+					err := t.vm.initErrorObject(UndefinedMethodError, "Undefined Method '%+v' for %+v", methodName, receiver.toString())
+					t.stack.set(receiverPr, &Pointer{Target: err})
+					t.sp = argPr
+					return
+				}
+
+				// Insert method name as `method_missing`'s first argument
+				t.stack.push(&Pointer{Target: t.vm.initSymbolObject(methodName)})
+				name := t.stack.Data[t.sp-1]
+
+				for i := t.sp - 1; i > argPr; i-- {
+					t.stack.Data[i] = t.stack.Data[i-1]
+				}
+
+				t.stack.Data[argPr] = name
+				argCount++
 			}
 
 			blockFrame := t.retrieveBlock(cf, args)
 
+			if blockFrame != nil {
+				t.breakableBlocks = append(t.breakableBlocks, blockFrame)
+				defer t.rescueBlockBreak(blockFrame, receiverPr)
+			}
+
 			switch m := method.(type) {
 			case *MethodObject:
+				if blockFrame == nil && cf.isTailCall(receiver, m) {
+					t.evalTailCall(cf, m, receiverPr, argCount)
+					return
+				}
+
 				t.evalMethodObject(receiver, m, receiverPr, argCount, blockFrame)
 			case *BuiltInMethodObject:
 				t.evalBuiltInMethod(receiver, m, receiverPr, argCount, blockFrame)
@@ -490,6 +636,93 @@ var builtInActions = map[operationType]*action{
 			t.sp = receiverPr + 1
 		},
 	},
+	bytecode.InvokeSuper: {
+		name: bytecode.InvokeSuper,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			argCount := args[0].(int)
+			methodFrame := cf.methodFrame()
+
+			if methodFrame == nil {
+				receiverPr := t.sp - argCount - 1
+				err := t.vm.initErrorObject(InternalError, "super called outside of method")
+				t.stack.set(receiverPr, &Pointer{Target: err})
+				t.sp = receiverPr + 1
+				return
+			}
+
+			currentMethod := methodFrame.method
+
+			// Bare `super` passes the arguments current method received, even if they're reassigned
+			if len(args) > 1 {
+				for _, arg := range methodFrame.args {
+					t.stack.push(&Pointer{Target: arg})
+				}
+
+				argCount = len(methodFrame.args)
+			}
+
+			argPr := t.sp - argCount
+			receiverPr := argPr - 1
+			receiver := t.stack.Data[receiverPr].Target
+
+			var method Object
+			owner := currentMethod.owner
+
+			if owner.superClass != nil && owner.superClass != owner {
+				method = owner.superClass.lookupMethod(currentMethod.Name)
+			}
+
+			if method == nil {
+				err := t.vm.initErrorObject(UndefinedMethodError, "Undefined super method '%s' for %s", currentMethod.Name, receiver.toString())
+				t.stack.set(receiverPr, &Pointer{Target: err})
+				t.sp = argPr
+				return
+			}
+
+			// The block given to current method is passed to super method implicitly
+			blockFrame := methodFrame.blockFrame
+
+			switch m := method.(type) {
+			case *MethodObject:
+				t.evalMethodObject(receiver, m, receiverPr, argCount, blockFrame)
+			case *BuiltInMethodObject:
+				t.evalBuiltInMethod(receiver, m, receiverPr, argCount, blockFrame)
+			case *Error:
+				t.returnError(InternalError, m.toString())
+			}
+		},
+	},
+	bytecode.PushRescue: {
+		name: bytecode.PushRescue,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			cf.rescueHandlers = append(cf.rescueHandlers, &rescueHandler{pc: args[0].(int), sp: t.sp})
+		},
+	},
+	bytecode.PopRescue: {
+		name: bytecode.PopRescue,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			cf.rescueHandlers = cf.rescueHandlers[:len(cf.rescueHandlers)-1]
+		},
+	},
+	bytecode.Break: {
+		name: bytecode.Break,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			value := t.stack.pop().Target
+
+			// Blocks used as method bodies, like `define_method`, don't have a method call to stop
+			if cf.method != nil || cf.blockFrame == nil {
+				t.returnError(InternalError, "Can't break outside of a block")
+				return
+			}
+
+			if !t.isBreakable(cf.blockFrame) {
+				t.returnError(InternalError, "Can't break from a block whose method call has returned")
+				return
+			}
+
+			panic(&blockBreak{blockFrame: cf.blockFrame, value: value})
+		},
+	},
 	bytecode.Leave: {
 		name: bytecode.Leave,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
@@ -547,11 +780,7 @@ func (vm *VM) initObjectFromGoType(value interface{}) Object {
 			return vm.initStringObject(v)
 		}
 	case bool:
-		if v {
-			return TRUE
-		}
-
-		return FALSE
+		return toBooleanObject(v)
 	case []interface{}:
 		var objs []Object
 
@@ -74,6 +74,7 @@ func (it *instructionTranslator) transferInstructionSet(iss []*instructionSet, s
 	}
 
 	is.argTypes = set.ArgTypes()
+	is.argNames = set.ArgNames()
 
 	iss = append(iss, is)
 }
@@ -90,9 +91,17 @@ func (it *instructionTranslator) transferInstruction(is *instructionSet, i *byte
 	}
 
 	switch act {
-	case bytecode.PutString:
+	case bytecode.PutString, bytecode.PutSymbol:
 		params = append(params, i.Params[0])
-	case bytecode.BranchUnless, bytecode.BranchIf, bytecode.Jump:
+	case bytecode.PutFloat:
+		value, err := strconv.ParseFloat(i.Params[0], 64)
+
+		if err != nil {
+			panic(err.Error())
+		}
+
+		params = append(params, value)
+	case bytecode.BranchUnless, bytecode.BranchIf, bytecode.Jump, bytecode.PushRescue:
 		line, err := i.AnchorLine()
 
 		if err != nil {
@@ -2,6 +2,7 @@ package vm
 
 import (
 	"math"
+	"math/big"
 	"strconv"
 )
 
@@ -26,14 +27,50 @@ const (
 	f64
 )
 
+// numericName is used in error messages when an argument can be either an Integer or a Float
+const numericName = "Numeric"
+
+// maxInt and minInt are the bounds of an Integer, results out of them are promoted to BigInteger
+const (
+	maxInt = int(^uint(0) >> 1)
+	minInt = -maxInt - 1
+)
+
+// Integers between minCachedInteger and maxCachedInteger are preallocated when vm is initialized
+const (
+	minCachedInteger = -128
+	maxCachedInteger = 256
+)
+
+// initIntegerObject returns a preallocated integer object if the value is small enough.
+// Integer objects are always frozen and can't have singleton methods, so it's safe to share them.
 func (vm *VM) initIntegerObject(value int) *IntegerObject {
+	if vm.integerTable != nil && minCachedInteger <= value && value <= maxCachedInteger {
+		return vm.integerTable[value-minCachedInteger]
+	}
+
+	return vm.newIntegerObject(value)
+}
+
+// newIntegerObject always allocates a new integer object, it's used when the object's flag needs to be changed
+func (vm *VM) newIntegerObject(value int) *IntegerObject {
 	return &IntegerObject{
-		baseObj: &baseObj{class: vm.topLevelClass(integerClass)},
+		baseObj: &baseObj{class: vm.topLevelClass(integerClass), frozen: true},
 		value:   value,
 		flag:    i,
 	}
 }
 
+func (vm *VM) initIntegerTable() {
+	table := make([]*IntegerObject, maxCachedInteger-minCachedInteger+1)
+
+	for i := range table {
+		table[i] = vm.newIntegerObject(i + minCachedInteger)
+	}
+
+	vm.integerTable = table
+}
+
 func (vm *VM) initIntegerClass() *RClass {
 	ic := vm.initializeClass(integerClass, false)
 	ic.setBuiltInMethods(builtinIntegerInstanceMethods(), false)
@@ -48,6 +85,10 @@ func (vm *VM) initIntegerClass() *RClass {
 // 2 * 2 # => 4
 // ```
 //
+// Small integers are shared by the whole program, so integers are frozen like in Ruby:
+// setting their instance variables raises a FrozenError and defining their singleton methods raises a TypeError.
+// Otherwise `a = 5; a.instance_variable_set("@x", 1)` would also change every other 5.
+//
 // - `Integer.new` is not supported.
 type IntegerObject struct {
 	*baseObj
@@ -72,6 +113,212 @@ func (i *IntegerObject) equal(e *IntegerObject) bool {
 	return i.value == e.value
 }
 
+// equalTo returns if self represents the same number as given object, which can be an Integer or a Float
+func (i *IntegerObject) equalTo(right Object) bool {
+	switch right := right.(type) {
+	case *IntegerObject:
+		return i.value == right.value
+	case *BigIntegerObject:
+		return i.bigValue().Cmp(right.value) == 0
+	case *FloatObject:
+		return float64(i.value) == right.value
+	default:
+		return false
+	}
+}
+
+// arithmeticOperation applies the operation that matches right hand side's type.
+// The result is promoted to Float when right hand side is a Float. When intOperation reports an overflow,
+// the result is calculated again by bigOperation and returned as a BigInteger.
+func (i *IntegerObject) arithmeticOperation(t *thread, right Object, intOperation func(int, int) (int, bool), bigOperation func(*big.Int, *big.Int) *big.Int, floatOperation func(float64, float64) float64) Object {
+	switch right := right.(type) {
+	case *IntegerObject:
+		if result, ok := intOperation(i.value, right.value); ok {
+			return t.vm.initIntegerObject(result)
+		}
+
+		return t.vm.initIntegerFromBigInt(bigOperation(i.bigValue(), right.bigValue()))
+	case *BigIntegerObject:
+		return t.vm.initIntegerFromBigInt(bigOperation(i.bigValue(), right.value))
+	case *FloatObject:
+		return t.vm.initFloatObject(floatOperation(float64(i.value), right.value))
+	default:
+		return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, numericName, right.Class().Name)
+	}
+}
+
+// numericComparison compares self with an Integer or a Float and returns the result as a Boolean object
+func (i *IntegerObject) numericComparison(t *thread, right Object, intOperation func(int, int) bool, floatOperation func(float64, float64) bool) Object {
+	var result bool
+
+	switch right := right.(type) {
+	case *IntegerObject:
+		result = intOperation(i.value, right.value)
+	case *BigIntegerObject:
+		// Comparing the result of Cmp with 0 is the same as comparing the two numbers
+		result = intOperation(i.bigValue().Cmp(right.value), 0)
+	case *FloatObject:
+		result = floatOperation(float64(i.value), right.value)
+	default:
+		return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, numericName, right.Class().Name)
+	}
+
+	return toBooleanObject(result)
+}
+
+// bigValue returns self's value as a big.Int
+func (i *IntegerObject) bigValue() *big.Int {
+	return big.NewInt(int64(i.value))
+}
+
+// Other helper functions ----------------------------------------------
+
+// addInt, subInt, mulInt and powInt do the calculation and return false when the result overflows
+func addInt(left, right int) (int, bool) {
+	result := left + right
+	return result, (result > left) == (right > 0)
+}
+
+func subInt(left, right int) (int, bool) {
+	result := left - right
+	return result, (result < left) == (right > 0)
+}
+
+func mulInt(left, right int) (int, bool) {
+	if left == 0 || right == 0 {
+		return 0, true
+	}
+
+	if (left == -1 && right == minInt) || (right == -1 && left == minInt) {
+		return 0, false
+	}
+
+	result := left * right
+	return result, result/right == left
+}
+
+// powInt calculates the power by squaring, the exponent should not be negative
+func powInt(base, exponent int) (int, bool) {
+	result := 1
+	ok := true
+
+	for exponent > 0 {
+		if exponent&1 == 1 {
+			if result, ok = mulInt(result, base); !ok {
+				return 0, false
+			}
+		}
+
+		exponent >>= 1
+
+		if exponent > 0 {
+			if base, ok = mulInt(base, base); !ok {
+				return 0, false
+			}
+		}
+	}
+
+	return result, true
+}
+
+// maxPowerBits limits the size of the results of `**`, larger powers would take too much memory and time
+const maxPowerBits = 1 << 24
+
+// checkPowerSize returns an ArgumentError if base ** exponent would have more than maxPowerBits bits.
+// The exponent should not be negative, and bases 0, 1 and -1 can have any exponent.
+func checkPowerSize(t *thread, base, exponent *big.Int) *Error {
+	if base.BitLen() <= 1 {
+		return nil
+	}
+
+	bits := new(big.Int).Mul(big.NewInt(int64(base.BitLen())), exponent)
+
+	if bits.Cmp(big.NewInt(maxPowerBits)) > 0 {
+		return t.vm.initErrorObject(ArgumentError, "Exponent is too large. got: %s", exponent.String())
+	}
+
+	return nil
+}
+
+// gcdInt calculates the greatest common divisor by Euclid's algorithm, the result is never negative.
+// It returns false when the result overflows, which only happens when it's -minInt.
+func gcdInt(left, right int) (int, bool) {
+	for right != 0 {
+		left, right = right, left%right
+	}
+
+	if left == minInt {
+		return 0, false
+	}
+
+	if left < 0 {
+		left = -left
+	}
+
+	return left, true
+}
+
+// radixArgument returns the radix passed to methods like `Integer#to_s` and `String#to_i`.
+// The radix defaults to 10 and must be between 2 and 36.
+func radixArgument(t *thread, args []Object) (int, *Error) {
+	switch len(args) {
+	case 0:
+		return 10, nil
+	case 1:
+		radix, ok := args[0].(*IntegerObject)
+
+		if !ok {
+			return 0, t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
+		}
+
+		if radix.value < 2 || radix.value > 36 {
+			return 0, t.vm.initErrorObject(ArgumentError, "Expect radix to be between 2 and 36. got: %d", radix.value)
+		}
+
+		return radix.value, nil
+	default:
+		return 0, t.vm.initErrorObject(ArgumentError, "Expect 0 or 1 argument. got: %d", len(args))
+	}
+}
+
+// roundInteger rounds value to a negative number of decimal digits, halves are rounded away from zero.
+// Powers of 10 with more digits than value always round it to 0, so they're never calculated.
+func roundInteger(t *thread, value *big.Int, digits int) Object {
+	rounded := new(big.Int).Abs(value)
+
+	if digits < -len(rounded.String()) {
+		return t.vm.initIntegerObject(0)
+	}
+
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-digits)), nil)
+	half := new(big.Int).Quo(p, big.NewInt(2))
+	rounded.Add(rounded, half).Quo(rounded, p).Mul(rounded, p)
+
+	if value.Sign() < 0 {
+		rounded.Neg(rounded)
+	}
+
+	return t.vm.initIntegerFromBigInt(rounded)
+}
+
+// roundDigits returns the decimal digits passed to `Integer#round` and `Float#round`, which defaults to 0
+func roundDigits(t *thread, args []Object) (int, *Error) {
+	switch len(args) {
+	case 0:
+		return 0, nil
+	case 1:
+		digits, ok := args[0].(*IntegerObject)
+
+		if !ok {
+			return 0, t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
+		}
+
+		return digits.value, nil
+	default:
+		return 0, t.vm.initErrorObject(ArgumentError, "Expect 0 or 1 argument. got: %d", len(args))
+	}
+}
+
 func builtInIntegerClassMethods() []*BuiltInMethodObject {
 	return []*BuiltInMethodObject{
 		{
@@ -88,26 +335,27 @@ func builtInIntegerClassMethods() []*BuiltInMethodObject {
 func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 	return []*BuiltInMethodObject{
 		{
-			// Returns the sum of self and another Integer.
+			// Returns the sum of self and another Numeric.
+			// The result is a Float if the other operand is a Float, and a BigInteger if it overflows.
 			//
 			// ```Ruby
-			// 1 + 2 # => 3
+			// 1 + 2   # => 3
+			// 1 + 2.5 # => 3.5
+			// 9223372036854775807 + 1 # => 9223372036854775808
 			// ```
-			// @return [Integer]
-			Name: "+",
+			// @return [Numeric]
+			Name:  "+",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
-
-					if !ok {
-						err := t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
-						return err
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Add(leftValue, rightValue)
+					}
+					floatOperation := func(leftValue float64, rightValue float64) float64 {
+						return leftValue + rightValue
 					}
 
-					rightValue := right.value
-					return t.vm.initIntegerObject(leftValue + rightValue)
+					return receiver.(*IntegerObject).arithmeticOperation(t, args[0], addInt, bigOperation, floatOperation)
 				}
 			},
 		},
@@ -115,266 +363,320 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			// Divides left hand operand by right hand operand and returns remainder.
 			//
 			// ```Ruby
-			// 5 % 2 # => 1
+			// 5 % 2   # => 1
+			// 5 % 1.5 # => 0.5
 			// ```
-			// @return [Integer]
-			Name: "%",
+			// @return [Numeric]
+			Name:  "%",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if right, ok := args[0].(*IntegerObject); ok && right.value == 0 {
+						return t.vm.initErrorObject(ZeroDivisionError, DividedByZeroFormat)
+					}
 
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
-
-					if !ok {
-						err := t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
-						return err
+					intOperation := func(leftValue int, rightValue int) (int, bool) {
+						return leftValue % rightValue, true
+					}
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Rem(leftValue, rightValue)
+					}
+					floatOperation := func(leftValue float64, rightValue float64) float64 {
+						return math.Mod(leftValue, rightValue)
 					}
 
-					rightValue := right.value
-					return t.vm.initIntegerObject(leftValue % rightValue)
+					return receiver.(*IntegerObject).arithmeticOperation(t, args[0], intOperation, bigOperation, floatOperation)
 				}
 			},
 		},
 		{
-			// Returns the subtraction of another Integer from self.
+			// Returns the subtraction of another Numeric from self.
 			//
 			// ```Ruby
-			// 1 - 1 # => 0
+			// 1 - 1   # => 0
+			// 1 - 0.5 # => 0.5
 			// ```
-			// @return [Integer]
-			Name: "-",
+			// @return [Numeric]
+			Name:  "-",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
-
-					if !ok {
-						err := t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
-						return err
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Sub(leftValue, rightValue)
+					}
+					floatOperation := func(leftValue float64, rightValue float64) float64 {
+						return leftValue - rightValue
 					}
 
-					rightValue := right.value
-					return t.vm.initIntegerObject(leftValue - rightValue)
+					return receiver.(*IntegerObject).arithmeticOperation(t, args[0], subInt, bigOperation, floatOperation)
 				}
 			},
 		},
 		{
-			// Returns self multiplying another Integer.
+			// Returns the negation of self, which is called by the unary minus.
 			//
 			// ```Ruby
-			// 2 * 10 # => 20
+			// -(1)  # => -1
+			// a = -5
+			// -a    # => 5
 			// ```
 			// @return [Integer]
-			Name: "*",
+			Name: "-@",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					integer := receiver.(*IntegerObject)
 
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
-
-					if !ok {
-						err := t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
-						return err
+					if integer.value == minInt {
+						return t.vm.initIntegerFromBigInt(new(big.Int).Neg(integer.bigValue()))
 					}
 
-					rightValue := right.value
-					return t.vm.initIntegerObject(leftValue * rightValue)
+					return t.vm.initIntegerObject(-integer.value)
 				}
 			},
 		},
 		{
-			// Returns self squaring another Integer.
+			// Returns self multiplying another Numeric.
 			//
 			// ```Ruby
-			// 2 ** 8 # => 256
+			// 2 * 10  # => 20
+			// 2 * 1.5 # => 3.0
 			// ```
-			// @return [Integer]
-			Name: "**",
+			// @return [Numeric]
+			Name:  "*",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Mul(leftValue, rightValue)
+					}
+					floatOperation := func(leftValue float64, rightValue float64) float64 {
+						return leftValue * rightValue
+					}
 
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
+					return receiver.(*IntegerObject).arithmeticOperation(t, args[0], mulInt, bigOperation, floatOperation)
+				}
+			},
+		},
+		{
+			// Returns self squaring another Numeric.
+			// A negative Integer exponent returns a Float, and an overflowing result is promoted to BigInteger.
+			// An exponent that makes the result too large to calculate raises an ArgumentError.
+			//
+			// ```Ruby
+			// 2 ** 8   # => 256
+			// 4 ** 0.5 # => 2.0
+			// 2 ** -1  # => 0.5
+			// 10 ** 30 # => 1000000000000000000000000000000
+			// 2 ** 100000000000 # => ArgumentError: Exponent is too large. got: 100000000000
+			// ```
+			// @return [Numeric]
+			Name:  "**",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					left := receiver.(*IntegerObject)
+					leftValue := float64(left.value)
+
+					switch right := args[0].(type) {
+					case *IntegerObject:
+						if right.value < 0 {
+							return t.vm.initFloatObject(math.Pow(leftValue, float64(right.value)))
+						}
+
+						if err := checkPowerSize(t, left.bigValue(), right.bigValue()); err != nil {
+							return err
+						}
+					case *BigIntegerObject:
+						if right.value.Sign() < 0 {
+							return t.vm.initFloatObject(math.Pow(leftValue, right.floatValue()))
+						}
+
+						if err := checkPowerSize(t, left.bigValue(), right.value); err != nil {
+							return err
+						}
+					}
 
-					if !ok {
-						err := t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
-						return err
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Exp(leftValue, rightValue, nil)
+					}
+					floatOperation := func(leftValue float64, rightValue float64) float64 {
+						return math.Pow(leftValue, rightValue)
 					}
 
-					rightValue := right.value
-					result := math.Pow(float64(leftValue), float64(rightValue))
-					return t.vm.initIntegerObject(int(result))
+					return receiver.(*IntegerObject).arithmeticOperation(t, args[0], powInt, bigOperation, floatOperation)
 				}
 			},
 		},
 		{
-			// Returns self divided by another Integer.
+			// Returns self divided by another Numeric.
+			// Dividing by an Integer always returns an Integer, the remainder is dropped.
 			//
 			// ```Ruby
-			// 6 / 3 # => 2
+			// 6 / 3   # => 2
+			// 7 / 2   # => 3
+			// 7 / 2.0 # => 3.5
 			// ```
-			// @return [Integer]
-			Name: "/",
+			// @return [Numeric]
+			Name:  "/",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if right, ok := args[0].(*IntegerObject); ok && right.value == 0 {
+						return t.vm.initErrorObject(ZeroDivisionError, DividedByZeroFormat)
+					}
 
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
+					intOperation := func(leftValue int, rightValue int) (int, bool) {
+						// The only overflowing division
+						if leftValue == minInt && rightValue == -1 {
+							return 0, false
+						}
 
-					if !ok {
-						err := t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
-						return err
+						return leftValue / rightValue, true
+					}
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Quo(leftValue, rightValue)
+					}
+					floatOperation := func(leftValue float64, rightValue float64) float64 {
+						return leftValue / rightValue
 					}
 
-					rightValue := right.value
-					return t.vm.initIntegerObject(leftValue / rightValue)
+					return receiver.(*IntegerObject).arithmeticOperation(t, args[0], intOperation, bigOperation, floatOperation)
 				}
 			},
 		},
 		{
-			// Returns if self is larger than another Integer.
+			// Returns if self is larger than another Numeric.
 			//
 			// ```Ruby
 			// 10 > -1 # => true
-			// 3 > 3 # => false
+			// 3 > 3   # => false
+			// 3 > 2.5 # => true
 			// ```
 			// @return [Boolean]
-			Name: ">",
+			Name:  ">",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
-
-					if !ok {
-						err := t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
-						return err
+					intOperation := func(leftValue int, rightValue int) bool {
+						return leftValue > rightValue
 					}
-
-					rightValue := right.value
-
-					if leftValue > rightValue {
-						return TRUE
+					floatOperation := func(leftValue float64, rightValue float64) bool {
+						return leftValue > rightValue
 					}
 
-					return FALSE
+					return receiver.(*IntegerObject).numericComparison(t, args[0], intOperation, floatOperation)
 				}
 			},
 		},
 		{
-			// Returns if self is larger than or equals to another Integer.
+			// Returns if self is larger than or equals to another Numeric.
 			//
 			// ```Ruby
-			// 2 >= 1 # => true
-			// 1 >= 1 # => true
+			// 2 >= 1   # => true
+			// 1 >= 1   # => true
+			// 1 >= 1.0 # => true
 			// ```
 			// @return [Boolean]
-			Name: ">=",
+			Name:  ">=",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
-
-					if !ok {
-						err := t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
-						return err
+					intOperation := func(leftValue int, rightValue int) bool {
+						return leftValue >= rightValue
 					}
-
-					rightValue := right.value
-
-					if leftValue >= rightValue {
-						return TRUE
+					floatOperation := func(leftValue float64, rightValue float64) bool {
+						return leftValue >= rightValue
 					}
 
-					return FALSE
+					return receiver.(*IntegerObject).numericComparison(t, args[0], intOperation, floatOperation)
 				}
 			},
 		},
 		{
-			// Returns if self is smaller than another Integer.
+			// Returns if self is smaller than another Numeric.
 			//
 			// ```Ruby
-			// 1 < 3 # => true
-			// 1 < 1 # => false
+			// 1 < 3   # => true
+			// 1 < 1   # => false
+			// 1 < 1.5 # => true
 			// ```
 			// @return [Boolean]
-			Name: "<",
+			Name:  "<",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
-
-					if !ok {
-						err := t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
-						return err
+					intOperation := func(leftValue int, rightValue int) bool {
+						return leftValue < rightValue
 					}
-
-					rightValue := right.value
-
-					if leftValue < rightValue {
-						return TRUE
+					floatOperation := func(leftValue float64, rightValue float64) bool {
+						return leftValue < rightValue
 					}
 
-					return FALSE
+					return receiver.(*IntegerObject).numericComparison(t, args[0], intOperation, floatOperation)
 				}
 			},
 		},
 		{
-			// Returns if self is smaller than or equals to another Integer.
+			// Returns if self is smaller than or equals to another Numeric.
 			//
 			// ```Ruby
-			// 1 <= 3 # => true
-			// 1 <= 1 # => true
+			// 1 <= 3   # => true
+			// 1 <= 1   # => true
+			// 1 <= 0.5 # => false
 			// ```
 			// @return [Boolean]
-			Name: "<=",
+			Name:  "<=",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
-
-					if !ok {
-						err := t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
-						return err
+					intOperation := func(leftValue int, rightValue int) bool {
+						return leftValue <= rightValue
 					}
-
-					rightValue := right.value
-
-					if leftValue <= rightValue {
-						return TRUE
+					floatOperation := func(leftValue float64, rightValue float64) bool {
+						return leftValue <= rightValue
 					}
 
-					return FALSE
+					return receiver.(*IntegerObject).numericComparison(t, args[0], intOperation, floatOperation)
 				}
 			},
 		},
 		{
-			// Returns 1 if self is larger than the incoming Integer, -1 if smaller. Otherwise 0.
+			// Returns 1 if self is larger than the incoming Numeric, -1 if smaller. Otherwise 0.
 			//
 			// ```Ruby
-			// 1 <=> 3 # => -1
-			// 1 <=> 1 # => 0
-			// 3 <=> 1 # => 1
+			// 1 <=> 3   # => -1
+			// 1 <=> 1   # => 0
+			// 3 <=> 1   # => 1
+			// 1 <=> 1.5 # => -1
 			// ```
 			// @return [Integer]
-			Name: "<=>",
+			Name:  "<=>",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
-
-					if !ok {
-						err := t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
-						return err
+					var leftValue, rightValue float64
+
+					left := receiver.(*IntegerObject)
+
+					switch right := args[0].(type) {
+					case *IntegerObject:
+						if left.value < right.value {
+							return t.vm.initIntegerObject(-1)
+						}
+						if left.value > right.value {
+							return t.vm.initIntegerObject(1)
+						}
+
+						return t.vm.initIntegerObject(0)
+					case *BigIntegerObject:
+						return t.vm.initIntegerObject(left.bigValue().Cmp(right.value))
+					case *FloatObject:
+						leftValue = float64(left.value)
+						rightValue = right.value
+					default:
+						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, numericName, args[0].Class().Name)
 					}
 
-					rightValue := right.value
-
 					if leftValue < rightValue {
 						return t.vm.initIntegerObject(-1)
 					}
@@ -387,60 +689,139 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			// Returns if self is equal to another Integer.
+			// Returns if self is equal to another Numeric.
+			// An Integer equals to a Float when they represent the same number.
 			//
 			// ```Ruby
-			// 1 == 3 # => false
-			// 1 == 1 # => true
+			// 1 == 3   # => false
+			// 1 == 1   # => true
+			// 1 == 1.0 # => true
+			// 1 == "1" # => false
 			// ```
 			// @return [Boolean]
-			Name: "==",
+			Name:  "==",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
-
-					if !ok {
+					return toBooleanObject(receiver.(*IntegerObject).equalTo(args[0]))
+				}
+			},
+		},
+		{
+			// Returns if self is not equal to another Numeric.
+			//
+			// ```Ruby
+			// 1 != 3   # => true
+			// 1 != 1   # => false
+			// 1 != 1.0 # => false
+			// ```
+			// @return [Boolean]
+			Name:  "!=",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver.(*IntegerObject).equalTo(args[0]) {
 						return FALSE
 					}
 
-					rightValue := right.value
-
-					if leftValue == rightValue {
-						return TRUE
-					}
-
-					return FALSE
+					return TRUE
 				}
 			},
 		},
 		{
-			// Returns if self is not equal to another Integer.
+			// Returns the absolute value of self.
 			//
 			// ```Ruby
-			// 1 != 3 # => true
-			// 1 != 1 # => false
+			// 5.abs    # => 5
+			// (-5).abs # => 5
 			// ```
-			// @return [Boolean]
-			Name: "!=",
+			// @return [Integer]
+			Name: "abs",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
 
-					leftValue := receiver.(*IntegerObject).value
-					right, ok := args[0].(*IntegerObject)
+					integer := receiver.(*IntegerObject)
 
-					if !ok {
-						return TRUE
+					if integer.value >= 0 {
+						return integer
 					}
 
-					rightValue := right.value
+					if integer.value == minInt {
+						return t.vm.initIntegerFromBigInt(new(big.Int).Neg(integer.bigValue()))
+					}
 
-					if leftValue != rightValue {
-						return TRUE
+					return t.vm.initIntegerObject(-integer.value)
+				}
+			},
+		},
+		{
+			// Returns self, because an Integer has no decimal part to round up.
+			//
+			// ```Ruby
+			// 5.ceil # => 5
+			// ```
+			// @return [Integer]
+			Name: "ceil",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					return receiver
+				}
+			},
+		},
+		{
+			// Returns self, because an Integer has no decimal part to round down.
+			//
+			// ```Ruby
+			// 5.floor # => 5
+			// ```
+			// @return [Integer]
+			Name: "floor",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 argument. got: %d", len(args))
 					}
 
-					return FALSE
+					return receiver
+				}
+			},
+		},
+		{
+			// Returns the greatest common divisor of self and another Integer, which is never negative.
+			//
+			// ```Ruby
+			// 12.gcd(18)    # => 6
+			// 12.gcd(-18)   # => 6
+			// (-12).gcd(0)  # => 12
+			// 0.gcd(0)      # => 0
+			// ```
+			// @param other [Integer]
+			// @return [Integer]
+			Name:  "gcd",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					left := receiver.(*IntegerObject)
+
+					switch right := args[0].(type) {
+					case *IntegerObject:
+						if result, ok := gcdInt(left.value, right.value); ok {
+							return t.vm.initIntegerObject(result)
+						}
+
+						return t.vm.initIntegerFromBigInt(new(big.Int).GCD(nil, nil, left.bigValue(), right.bigValue()))
+					case *BigIntegerObject:
+						return t.vm.initIntegerFromBigInt(new(big.Int).GCD(nil, nil, left.bigValue(), right.value))
+					default:
+						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
+					}
 				}
 			},
 		},
@@ -459,11 +840,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 					i := receiver.(*IntegerObject)
 					even := i.value%2 == 0
 
-					if even {
-						return TRUE
-					}
-
-					return FALSE
+					return toBooleanObject(even)
 				}
 			},
 		},
@@ -482,19 +859,27 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			// Returns a `String` representation of self.
+			// Returns a `String` representation of self in the given radix, which defaults to 10.
 			//
 			// ```Ruby
-			// 100.to_s # => "100"
+			// 100.to_s     # => "100"
+			// 255.to_s(16) # => "ff"
+			// (-5).to_s(2) # => "-101"
 			// ```
+			// @param radix [Integer]
 			// @return [String]
 			Name: "to_s",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
+					radix, err := radixArgument(t, args)
+					if err != nil {
+						return err
+					}
+
 					int := receiver.(*IntegerObject)
 
-					return t.vm.initStringObject(strconv.Itoa(int.value))
+					return t.vm.initStringObject(strconv.FormatInt(int64(int.value), radix))
 				}
 			},
 		},
@@ -509,6 +894,11 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					i := receiver.(*IntegerObject)
+
+					if i.value == maxInt {
+						return t.vm.initIntegerFromBigInt(new(big.Int).Add(i.bigValue(), big.NewInt(1)))
+					}
+
 					return t.vm.initIntegerObject(i.value + 1)
 				}
 			},
@@ -527,11 +917,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 
 					i := receiver.(*IntegerObject)
 					odd := i.value%2 != 0
-					if odd {
-						return TRUE
-					}
-
-					return FALSE
+					return toBooleanObject(odd)
 				}
 			},
 		},
@@ -546,12 +932,47 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					i := receiver.(*IntegerObject)
+
+					if i.value == minInt {
+						return t.vm.initIntegerFromBigInt(new(big.Int).Sub(i.bigValue(), big.NewInt(1)))
+					}
+
 					return t.vm.initIntegerObject(i.value - 1)
 				}
 			},
 		},
 		{
-			// Yields a block a number of times equals to self.
+			// Rounds self to the given decimal digits, which defaults to 0.
+			// Only negative digits change an Integer, and halves are rounded away from zero.
+			//
+			// ```Ruby
+			// 5.round       # => 5
+			// 15.round(-1)  # => 20
+			// -15.round(-1) # => -20
+			// 1234.round(-2) # => 1200
+			// ```
+			// @param digits [Integer]
+			// @return [Integer]
+			Name: "round",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					digits, err := roundDigits(t, args)
+
+					if err != nil {
+						return err
+					}
+
+					if digits >= 0 {
+						return receiver
+					}
+
+					return roundInteger(t, receiver.(*IntegerObject).bigValue(), digits)
+				}
+			},
+		},
+		{
+			// Yields a block a number of times equals to self, passing the index from 0 to self - 1.
+			// Returns self. Without a block it returns an Enumerator of the indexes.
 			//
 			// ```Ruby
 			// a = 0
@@ -559,6 +980,9 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			//    a += 1
 			// end
 			// a # => 3
+			//
+			// 3.times { |i| puts(i) } # prints 0, 1, 2 and returns 3
+			// 3.times.map { |i| i * 2 } # => [0, 2, 4]
 			// ```
 			Name: "times",
 			Fn: func(receiver Object) builtinMethodBody {
@@ -570,7 +994,9 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 					}
 
 					if blockFrame == nil {
-						return t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
+						return t.vm.initEnumeratorObject(n, "times", n.value, func(i int) Object {
+							return t.vm.initIntegerObject(i)
+						})
 					}
 
 					for i := 0; i < n.value; i++ {
@@ -586,7 +1012,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*IntegerObject)
-					newInt := t.vm.initIntegerObject(r.value)
+					newInt := t.vm.newIntegerObject(r.value)
 					newInt.flag = i
 					return newInt
 				}
@@ -597,7 +1023,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*IntegerObject)
-					newInt := t.vm.initIntegerObject(r.value)
+					newInt := t.vm.newIntegerObject(r.value)
 					newInt.flag = i8
 					return newInt
 				}
@@ -608,7 +1034,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*IntegerObject)
-					newInt := t.vm.initIntegerObject(r.value)
+					newInt := t.vm.newIntegerObject(r.value)
 					newInt.flag = i16
 					return newInt
 				}
@@ -619,7 +1045,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*IntegerObject)
-					newInt := t.vm.initIntegerObject(r.value)
+					newInt := t.vm.newIntegerObject(r.value)
 					newInt.flag = i32
 					return newInt
 				}
@@ -630,7 +1056,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*IntegerObject)
-					newInt := t.vm.initIntegerObject(r.value)
+					newInt := t.vm.newIntegerObject(r.value)
 					newInt.flag = i64
 					return newInt
 				}
@@ -641,7 +1067,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*IntegerObject)
-					newInt := t.vm.initIntegerObject(r.value)
+					newInt := t.vm.newIntegerObject(r.value)
 					newInt.flag = ui
 					return newInt
 				}
@@ -652,7 +1078,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*IntegerObject)
-					newInt := t.vm.initIntegerObject(r.value)
+					newInt := t.vm.newIntegerObject(r.value)
 					newInt.flag = ui8
 					return newInt
 				}
@@ -663,7 +1089,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*IntegerObject)
-					newInt := t.vm.initIntegerObject(r.value)
+					newInt := t.vm.newIntegerObject(r.value)
 					newInt.flag = ui16
 					return newInt
 				}
@@ -674,7 +1100,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*IntegerObject)
-					newInt := t.vm.initIntegerObject(r.value)
+					newInt := t.vm.newIntegerObject(r.value)
 					newInt.flag = ui32
 					return newInt
 				}
@@ -685,7 +1111,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*IntegerObject)
-					newInt := t.vm.initIntegerObject(r.value)
+					newInt := t.vm.newIntegerObject(r.value)
 					newInt.flag = ui64
 					return newInt
 				}
@@ -696,7 +1122,7 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*IntegerObject)
-					newInt := t.vm.initIntegerObject(r.value)
+					newInt := t.vm.newIntegerObject(r.value)
 					newInt.flag = f32
 					return newInt
 				}
@@ -707,11 +1133,27 @@ func builtinIntegerInstanceMethods() []*BuiltInMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*IntegerObject)
-					newInt := t.vm.initIntegerObject(r.value)
+					newInt := t.vm.newIntegerObject(r.value)
 					newInt.flag = f64
 					return newInt
 				}
 			},
 		},
+		{
+			// Returns if self is zero.
+			//
+			// ```Ruby
+			// 0.zero? # => true
+			// 1.zero? # => false
+			// ```
+			// @return [Boolean]
+			Name: "zero?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					i := receiver.(*IntegerObject)
+					return toBooleanObject(i.value == 0)
+				}
+			},
+		},
 	}
 }
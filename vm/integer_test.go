@@ -22,6 +22,76 @@ func TestIntegerClassSuperclass(t *testing.T) {
 	}
 }
 
+func TestIntegerObjectCache(t *testing.T) {
+	v := initTestVM()
+
+	for _, value := range []int{minCachedInteger, -1, 0, 1, maxCachedInteger} {
+		if v.initIntegerObject(value) != v.initIntegerObject(value) {
+			t.Fatalf("Expect integer %d to be cached", value)
+		}
+	}
+
+	for _, value := range []int{minCachedInteger - 1, maxCachedInteger + 1} {
+		if v.initIntegerObject(value) == v.initIntegerObject(value) {
+			t.Fatalf("Expect integer %d not to be cached", value)
+		}
+	}
+
+	// Converting integer's type shouldn't change the cached object
+	evaluated := v.testEval(t, `1.to_int64`, getFilename())
+
+	if evaluated == v.initIntegerObject(1) {
+		t.Fatal("Expect converted integer not to be the cached object")
+	}
+
+	if v.initIntegerObject(1).flag != i {
+		t.Fatalf("Expect cached integer's flag to be %d. got: %d", i, v.initIntegerObject(1).flag)
+	}
+}
+
+func TestIntegerFrozen(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`5.frozen?`, true},
+		{`100000.frozen?`, true},
+		{`1.to_int64.frozen?`, true},
+		// Cached integers don't share state
+		{`
+		a = 5
+		begin
+		  a.instance_variable_set("@foo", 42)
+		rescue
+		end
+		(10 - 5).instance_variable_get("@foo")
+		`, nil},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestIntegerFrozenFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`5.instance_variable_set("@foo", 42)`, "FrozenError: Can't modify frozen Integer: 5", 1},
+		{`100000.instance_variable_set("@foo", 42)`, "FrozenError: Can't modify frozen Integer: 100000", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestIntegerArithmeticOperation(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -34,6 +104,8 @@ func TestIntegerArithmeticOperation(t *testing.T) {
 		{`5 * 20`, 100},
 		{`4 % 2`, 0},
 		{`10 % 3`, 1},
+		{`0 % 3`, 0},
+		{`0 / 3`, 0},
 		{`6 % 4`, 2},
 		{`25 / 5`, 5},
 		{`25 > 5`, true},
@@ -41,6 +113,13 @@ func TestIntegerArithmeticOperation(t *testing.T) {
 		{`-5 < -4`, true},
 		{`100 < 81`, false},
 		{`5 ** 4`, 625},
+		{`2 ** 0`, 1},
+		{`2 ** 1`, 2},
+		{`2 ** 10`, 1024},
+		{`2 ** 2 ** 3`, 256},
+		{`2 * 3 ** 2`, 18},
+		{`2 ** -1`, 0.5},
+		{`4 ** -2`, 0.0625},
 		{`25 / 5`, 5},
 		{`1 / 1 + 1`, 2},
 		{`0 / (1 + 1000)`, 0},
@@ -48,6 +127,11 @@ func TestIntegerArithmeticOperation(t *testing.T) {
 		{`(3 - 1) ** 4 / 2`, 8},
 		{`(25 / 5 + 5) * 3`, 30},
 		{`(25 / 5 + 5) * 2`, 20},
+		// Bases 0, 1 and -1 can have any exponent
+		{`1 ** 100000000000`, 1},
+		{`0 ** 100000000000`, 0},
+		{`(-1) ** 100000000001`, -1},
+		{`1 ** (10 ** 30)`, 1},
 	}
 
 	for i, tt := range tests {
@@ -61,10 +145,15 @@ func TestIntegerArithmeticOperation(t *testing.T) {
 
 func TestIntegerArithmeticOperationFail(t *testing.T) {
 	testsFail := []errorTestCase{
-		{`1 + "p"`, "TypeError: Expect argument to be Integer. got: String", 1},
-		{`1 - "m"`, "TypeError: Expect argument to be Integer. got: String", 1},
-		{`1 ** "p"`, "TypeError: Expect argument to be Integer. got: String", 1},
-		{`1 / "t"`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`1 + "p"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1 - "m"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1 ** "p"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1 / "t"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1 / 0`, "ZeroDivisionError: Divided by 0", 1},
+		{`10 % 0`, "ZeroDivisionError: Divided by 0", 1},
+		{`(5 - 5) / (3 - 3)`, "ZeroDivisionError: Divided by 0", 1},
+		{`2 ** 100000000000`, "ArgumentError: Exponent is too large. got: 100000000000", 1},
+		{`2 ** (10 ** 30)`, "ArgumentError: Exponent is too large. got: 1000000000000000000000000000000", 1},
 	}
 
 	for i, tt := range testsFail {
@@ -123,11 +212,11 @@ func TestIntegerComparison(t *testing.T) {
 
 func TestIntegerComparisonFail(t *testing.T) {
 	testsFail := []errorTestCase{
-		{`1 > "m"`, "TypeError: Expect argument to be Integer. got: String", 1},
-		{`1 >= "m"`, "TypeError: Expect argument to be Integer. got: String", 1},
-		{`1 < "m"`, "TypeError: Expect argument to be Integer. got: String", 1},
-		{`1 <= "m"`, "TypeError: Expect argument to be Integer. got: String", 1},
-		{`1 <=> "m"`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`1 > "m"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1 >= "m"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1 < "m"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1 <= "m"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`1 <=> "m"`, "TypeError: Expect argument to be Numeric. got: String", 1},
 	}
 
 	for i, tt := range testsFail {
@@ -146,6 +235,11 @@ func TestIntegerConversion(t *testing.T) {
 	}{
 		{`100.to_i`, 100},
 		{`100.to_s`, "100"},
+		{`255.to_s(16)`, "ff"},
+		{`5.to_s(2)`, "101"},
+		{`(-5).to_s(2)`, "-101"},
+		{`35.to_s(36)`, "z"},
+		{`255.to_s(16).to_i(16)`, 255},
 	}
 
 	for i, tt := range tests {
@@ -157,6 +251,62 @@ func TestIntegerConversion(t *testing.T) {
 	}
 }
 
+func TestIntegerConversionFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`255.to_s(1)`, "ArgumentError: Expect radix to be between 2 and 36. got: 1", 1},
+		{`255.to_s(37)`, "ArgumentError: Expect radix to be between 2 and 36. got: 37", 1},
+		{`255.to_s("16")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`255.to_s(16, 2)`, "ArgumentError: Expect 0 or 1 argument. got: 2", 1},
+		{`"ff".to_i(0)`, "ArgumentError: Expect radix to be between 2 and 36. got: 0", 1},
+		{`"ff".to_i(:a)`, "TypeError: Expect argument to be Integer. got: Symbol", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestIntegerAbsMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`5.abs`, 5},
+		{`(-5).abs`, 5},
+		{`0.abs`, 0},
+		{`
+		a = -9223372036854775807 - 1
+		a.abs.to_s
+		`, "9223372036854775808"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestIntegerAbsMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`5.abs(1)`, "ArgumentError: Expect 0 argument. got: 1", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestIntegerEvenMethod(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -164,6 +314,9 @@ func TestIntegerEvenMethod(t *testing.T) {
 	}{
 		{`1.even?`, false},
 		{`2.even?`, true},
+		{`0.even?`, true},
+		{`(-3).even?`, false},
+		{`(-4).even?`, true},
 	}
 
 	for i, tt := range tests {
@@ -175,6 +328,57 @@ func TestIntegerEvenMethod(t *testing.T) {
 	}
 }
 
+func TestIntegerGcdMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`12.gcd(18)`, 6},
+		{`18.gcd(12)`, 6},
+		{`12.gcd(-12)`, 12},
+		{`(-12).gcd(18)`, 6},
+		{`(-12).gcd(-18)`, 6},
+		{`7.gcd(5)`, 1},
+		{`7.gcd(0)`, 7},
+		{`0.gcd(-7)`, 7},
+		{`0.gcd(0)`, 0},
+		{`6.gcd(10 ** 30)`, 2},
+		{`
+		a = -9223372036854775807 - 1
+		a.gcd(0).to_s
+		`, "9223372036854775808"},
+		{`
+		a = -9223372036854775807 - 1
+		a.gcd(6)
+		`, 2},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestIntegerGcdMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`12.gcd`, "ArgumentError: Expect at least 1 args for method 'gcd'. got: 0", 1},
+		{`12.gcd(1, 2)`, "ArgumentError: Expect at most 1 args for method 'gcd'. got: 2", 1},
+		{`12.gcd(1.5)`, "TypeError: Expect argument to be Integer. got: Float", 1},
+		{`12.gcd("3")`, "TypeError: Expect argument to be Integer. got: String", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestIntegerNextMethod(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -200,6 +404,9 @@ func TestIntegerOddMethod(t *testing.T) {
 	}{
 		{`1.odd?`, true},
 		{`2.odd?`, false},
+		{`0.odd?`, false},
+		{`(-3).odd?`, true},
+		{`(-4).odd?`, false},
 	}
 
 	for i, tt := range tests {
@@ -229,6 +436,54 @@ func TestIntegerPredMethod(t *testing.T) {
 	}
 }
 
+func TestIntegerRounding(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`5.round`, 5},
+		{`5.floor`, 5},
+		{`5.ceil`, 5},
+		{`(-5).floor`, -5},
+		{`5.round(2)`, 5},
+		{`15.round(-1)`, 20},
+		{`14.round(-1)`, 10},
+		{`(-15).round(-1)`, -20},
+		{`(-14).round(-1)`, -10},
+		{`1250.round(-2)`, 1300},
+		{`5.round(-30)`, 0},
+		{`(9223372036854775807.round(-1)).to_s`, "9223372036854775810"},
+		{`(9223372036854775807.round(-19)).to_s`, "10000000000000000000"},
+		{`9223372036854775807.round(-20)`, 0},
+		{`(-9223372036854775807).round(-20)`, 0},
+		{`5.round(-1000000000000)`, 0},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestIntegerRoundingFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`5.round(1.5)`, "TypeError: Expect argument to be Integer. got: Float", 1},
+		{`5.floor(1)`, "ArgumentError: Expect 0 argument. got: 1", 1},
+		{`5.ceil(1)`, "ArgumentError: Expect 0 argument. got: 1", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestIntegerTimesMethod(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -240,6 +495,23 @@ func TestIntegerTimesMethod(t *testing.T) {
 			end
 			a
 			`, 3},
+		{`	a = []
+			4.times { |i| a.push(i) }
+			a.to_s
+			`, "[0, 1, 2, 3]"},
+		{`	sum = 0
+			5.times do |i|
+			  sum += i
+			end
+			sum
+			`, 10},
+		{`	3.times { |i| i * 10 }`, 3},
+		{`	a = 0
+			0.times { |i| a += 1 }
+			a
+			`, 0},
+		// Returns an Enumerator without a block
+		{`2.times.class.name`, "Enumerator"},
 	}
 
 	for i, tt := range tests {
@@ -254,7 +526,6 @@ func TestIntegerTimesMethod(t *testing.T) {
 func TestIntegerTimesMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`(-2).times`, "InternalError: Expect integer greater than or equal 0. got: -2", 1},
-		{`2.times`, "InternalError: Can't yield without a block", 1},
 	}
 
 	for i, tt := range testsFail {
@@ -265,3 +536,23 @@ func TestIntegerTimesMethodFail(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestIntegerZeroMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`0.zero?`, true},
+		{`1.zero?`, false},
+		{`(1 - 1).zero?`, true},
+		{`(-1).zero?`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
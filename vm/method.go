@@ -17,6 +17,11 @@ type MethodObject struct {
 	Name           string
 	instructionSet *instructionSet
 	argc           int
+	// owner is the class (or singleton class) which the method is defined in, it's used for looking up `super` method
+	owner *RClass
+	// ep is the frame where the method's block is created when it's defined by `define_method`,
+	// so the method can access local variables around the block
+	ep *callFrame
 }
 
 // Polymorphic helper functions -----------------------------------------
@@ -38,6 +43,14 @@ type BuiltInMethodObject struct {
 	*baseObj
 	Name string
 	Fn   func(receiver Object) builtinMethodBody
+	// Arity is checked before calling Fn, methods without it should check their arguments by themselves
+	Arity *Arity
+}
+
+// Arity declares the number of arguments a built-in method accepts. A negative Max means there's no upper limit.
+type Arity struct {
+	Min int
+	Max int
 }
 
 // Polymorphic helper functions -----------------------------------------
@@ -48,3 +61,23 @@ func (bim *BuiltInMethodObject) toString() string {
 func (bim *BuiltInMethodObject) toJSON() string {
 	return bim.toString()
 }
+
+// Other helper functions -----------------------------------------------
+
+// checkArity returns an ArgumentError if the method doesn't accept given number of arguments.
+// Its messages are the same as the ones of methods defined using goby.
+func (bim *BuiltInMethodObject) checkArity(t *thread, argCount int) *Error {
+	if bim.Arity == nil {
+		return nil
+	}
+
+	if argCount < bim.Arity.Min {
+		return t.vm.initErrorObject(ArgumentError, "Expect at least %d args for method '%s'. got: %d", bim.Arity.Min, bim.Name, argCount)
+	}
+
+	if bim.Arity.Max >= 0 && argCount > bim.Arity.Max {
+		return t.vm.initErrorObject(ArgumentError, "Expect at most %d args for method '%s'. got: %d", bim.Arity.Max, bim.Name, argCount)
+	}
+
+	return nil
+}
@@ -9,12 +9,13 @@ func (vm *VM) initNullClass() *RClass {
 	nc := vm.initializeClass(nullClass, false)
 	nc.setBuiltInMethods(builtInNullInstanceMethods(), false)
 	nc.setBuiltInMethods(builtInNullClassMethods(), true)
-	NULL = &NullObject{baseObj: &baseObj{class: nc}}
+	NULL = &NullObject{baseObj: &baseObj{class: nc, frozen: true}}
 	return nc
 }
 
 // NullObject (`nil`) represents the null value in Goby.
 // `nil` is convert into `null` when exported to JSON format.
+// There's only one `nil` in the program, so it's frozen and setting its instance variables raises a FrozenError.
 // - `Null.new` is not supported.
 type NullObject struct {
 	*baseObj
@@ -102,6 +103,11 @@ func builtInNullInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
+			// Returns 0.
+			//
+			// ```ruby
+			// nil.to_i # => 0
+			// ```
 			Name: "to_i",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
@@ -110,6 +116,11 @@ func builtInNullInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
+			// Returns an empty string.
+			//
+			// ```ruby
+			// nil.to_s # => ""
+			// ```
 			Name: "to_s",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
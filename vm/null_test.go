@@ -148,3 +148,69 @@ func TestNullIsNilMethodFail(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestNullFrozenFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`nil.instance_variable_set("@x", 1)`, "FrozenError: Can't modify frozen Null: nil", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestNullAsImplicitValue(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		def foo
+		end
+
+		foo
+		`, nil},
+		{`
+		def foo
+		end
+
+		foo.nil?
+		`, true},
+		{`
+		x = if false
+		  10
+		end
+		x
+		`, nil},
+		{`
+		x = if false
+		  10
+		end
+
+		if x.nil?
+		  "nil"
+		else
+		  "not nil"
+		end
+		`, "nil"},
+		{`
+		if nil
+		  10
+		else
+		  20
+		end
+		`, 20},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
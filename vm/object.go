@@ -2,6 +2,7 @@ package vm
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 )
 
@@ -16,6 +17,9 @@ type Object interface {
 	id() int
 	instanceVariableGet(string) (Object, bool)
 	instanceVariableSet(string, Object) Object
+	instanceVariableNames() []string
+	isFrozen() bool
+	freeze()
 }
 
 // Pointer is used to point to an object. Variables should hold pointer instead of holding a object directly.
@@ -32,6 +36,8 @@ type baseObj struct {
 	class             *RClass
 	singletonClass    *RClass
 	InstanceVariables *environment
+	// frozen objects can't be modified by mutating methods or instance variable assignments
+	frozen bool
 }
 
 // Class will return object's class
@@ -53,6 +59,11 @@ func (b *baseObj) SetSingletonClass(c *RClass) {
 }
 
 func (b *baseObj) instanceVariableGet(name string) (Object, bool) {
+	// Objects like arrays and strings don't have an environment until they get any instance variable
+	if b.InstanceVariables == nil {
+		return NULL, false
+	}
+
 	v, ok := b.InstanceVariables.get(name)
 
 	if !ok {
@@ -63,11 +74,40 @@ func (b *baseObj) instanceVariableGet(name string) (Object, bool) {
 }
 
 func (b *baseObj) instanceVariableSet(name string, value Object) Object {
+	if b.InstanceVariables == nil {
+		b.InstanceVariables = newEnvironment()
+	}
+
 	b.InstanceVariables.set(name, value)
 
 	return value
 }
 
+// instanceVariableNames returns the names of instance variables in alphabetical order,
+// because the variables are stored in a Go map which doesn't keep any order
+func (b *baseObj) instanceVariableNames() []string {
+	names := []string{}
+
+	if b.InstanceVariables == nil {
+		return names
+	}
+
+	for name := range b.InstanceVariables.store {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func (b *baseObj) isFrozen() bool {
+	return b.frozen
+}
+
+func (b *baseObj) freeze() {
+	b.frozen = true
+}
+
 func (b *baseObj) findMethod(methodName string) (method Object) {
 	if b.SingletonClass() != nil {
 		method = b.SingletonClass().lookupMethod(methodName)
@@ -88,6 +128,18 @@ func (b *baseObj) id() int {
 	return int(r)
 }
 
+// isTruthy returns false if the object is `false` or `nil`, otherwise it returns true
+func isTruthy(obj Object) bool {
+	switch obj := obj.(type) {
+	case *BooleanObject:
+		return obj.value
+	case *NullObject:
+		return false
+	default:
+		return true
+	}
+}
+
 // RObject represents any non built-in class's instance.
 type RObject struct {
 	*baseObj
@@ -137,7 +137,8 @@ func builtinPluginClassMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			Name: "use",
+			Name:  "use",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					pkgPath := args[0].(*StringObject).value
@@ -214,7 +215,8 @@ func builtinPluginInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			Name: "go_func",
+			Name:  "go_func",
+			Arity: &Arity{1, -1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					s, ok := args[0].(*StringObject)
@@ -2,11 +2,12 @@ package vm
 
 import "fmt"
 
-func (vm *VM) initRangeObject(start, end int) *RangeObject {
+func (vm *VM) initRangeObject(start, end int, exclusive bool) *RangeObject {
 	return &RangeObject{
-		baseObj: &baseObj{class: vm.topLevelClass(rangeClass)},
-		Start:   start,
-		End:     end,
+		baseObj:   &baseObj{class: vm.topLevelClass(rangeClass)},
+		Start:     start,
+		End:       end,
+		Exclusive: exclusive,
 	}
 }
 
@@ -21,10 +22,15 @@ func (vm *VM) initRangeClass() *RClass {
 // Range represents an interval: a set of values from the beginning to the end specified.
 // Currently, only Integer objects or integer literal are supported.
 //
+// A range created with `..` includes its end value, and a range created with `...` excludes it.
+// A range whose start is greater than its end is empty, so iterating it yields nothing.
+//
 // ```ruby
 // r = 0
 // (1..(1+4)).each do |i|
-//   puts(r = r + i)
+//
+//	puts(r = r + i)
+//
 // end
 // ```
 //
@@ -33,18 +39,24 @@ func (vm *VM) initRangeClass() *RClass {
 // a = 1
 // b = 5
 // (a..b).each do |i|
-//   r = r + i
+//
+//	r = r + i
+//
 // end
 // ```
-//
 type RangeObject struct {
 	*baseObj
-	Start int
-	End   int
+	Start     int
+	End       int
+	Exclusive bool
 }
 
 // Polymorphic helper functions -----------------------------------------
 func (ro *RangeObject) toString() string {
+	if ro.Exclusive {
+		return fmt.Sprintf("(%d...%d)", ro.Start, ro.End)
+	}
+
 	return fmt.Sprintf("(%d..%d)", ro.Start, ro.End)
 }
 
@@ -52,6 +64,28 @@ func (ro *RangeObject) toJSON() string {
 	return ro.toString()
 }
 
+// lastValue returns the last value included in the range
+func (ro *RangeObject) lastValue() int {
+	if ro.Exclusive {
+		return ro.End - 1
+	}
+
+	return ro.End
+}
+
+// length returns the count of values in the range, which is 0 if the range is empty
+func (ro *RangeObject) length() int {
+	if ro.Start > ro.lastValue() {
+		return 0
+	}
+
+	return ro.lastValue() - ro.Start + 1
+}
+
+func (ro *RangeObject) equal(e *RangeObject) bool {
+	return ro.Start == e.Start && ro.End == e.End && ro.Exclusive == e.Exclusive
+}
+
 func builtInRangeClassMethods() []*BuiltInMethodObject {
 	return []*BuiltInMethodObject{
 		{
@@ -71,12 +105,14 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 			// Returns a Boolean of compared two ranges
 			//
 			// ```ruby
-			// (1..5) == (1..5) # => true
-			// (1..5) == (1..6) # => false
+			// (1..5) == (1..5)  # => true
+			// (1..5) == (1..6)  # => false
+			// (1..5) == (1...5) # => false
 			// ```
 			//
 			// @return [Boolean]
-			Name: "==",
+			Name:  "==",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
@@ -88,11 +124,7 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 						return FALSE
 					}
 
-					if left.Start == right.Start && left.End == right.End {
-						return TRUE
-					}
-
-					return FALSE
+					return toBooleanObject(left.equal(right))
 				}
 			},
 		},
@@ -105,7 +137,8 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 			// ```
 			//
 			// @return [Boolean]
-			Name: "!=",
+			Name:  "!=",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
@@ -117,11 +150,7 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 						return TRUE
 					}
 
-					if left.Start == right.Start && left.End == right.End {
-						return FALSE
-					}
-
-					return TRUE
+					return toBooleanObject(!left.equal(right))
 				}
 			},
 		},
@@ -166,19 +195,24 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 			// ```
 			//
 			// @return [Integer]
-			Name: "bsearch",
+			Name:  "bsearch",
+			Arity: &Arity{0, 0},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					ran := receiver.(*RangeObject)
 
-					if ran.Start > ran.End || ran.Start < 0 {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
+					}
+
+					if ran.length() == 0 || ran.Start < 0 {
 						// if block is not used, it should be popped
 						t.callFrameStack.pop()
 						return NULL
 					}
 
 					start := ran.Start
-					end := ran.End
+					end := ran.lastValue()
 					var mid int
 					pivot := -1
 
@@ -205,7 +239,7 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 
 							if r.value {
 								end = mid - 1
-							} else if mid+1 > ran.End {
+							} else if mid+1 > ran.lastValue() {
 								return NULL
 							} else {
 								start = mid + 1
@@ -233,7 +267,7 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 		},
 		{
 			// Iterates over the elements of range, passing each in turn to the block.
-			// Returns `nil`.
+			// Returns self. The block is never yielded if the range is empty.
 			//
 			// ```ruby
 			// sum = 0
@@ -243,15 +277,20 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 			// sum # => 15
 			//
 			// sum = 0
+			// (1...5).each do |i|
+			//   sum = sum + i
+			// end
+			// sum # => 10
+			//
+			// sum = 0
 			// (-1..-5).each do |i|
 			//   sum = sum + i
 			// end
-			// sum # => -15
+			// sum # => 0
 			// ```
 			//
 			// **Note:**
 			// - Only `do`-`end` block is supported for now: `{ }` block is unavailable.
-			// - Three-dot range `...` is not supported yet.
 			//
 			// @return [Range]
 			Name: "each",
@@ -263,17 +302,11 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 						return t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
 					}
 
-					if ran.Start <= ran.End {
-						for i := ran.Start; i <= ran.End; i++ {
-							obj := t.vm.initIntegerObject(i)
-							t.builtInMethodYield(blockFrame, obj)
-						}
-					} else {
-						for i := ran.End; i <= ran.Start; i++ {
-							obj := t.vm.initIntegerObject(i)
-							t.builtInMethodYield(blockFrame, obj)
-						}
+					for i := 0; i < ran.length(); i++ {
+						obj := t.vm.initIntegerObject(ran.Start + i)
+						t.builtInMethodYield(blockFrame, obj)
 					}
+
 					return ran
 				}
 			},
@@ -301,32 +334,39 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 			// The include method will check whether the integer object is in the range
 			//
 			// ```ruby
-			// (5..10).include?(10)  # => true
-			// (5..10).include?(11)  # => false
-			// (5..10).include?(7)   # => true
-			// (5..10).include?(5)   # => true
-			// (5..10).include?(4)   # => false
-			// (-5..1).include?(-2)  # => true
-			// (-5..-2).include?(-2) # => true
-			// (-5..-3).include?(-2) # => false
-			// (1..-5).include?(-2)  # => true
-			// (-2..-5).include?(-2) # => true
-			// (-3..-5).include?(-2) # => false
+			// (5..10).include?(10)   # => true
+			// (5..10).include?(11)   # => false
+			// (5..10).include?(7)    # => true
+			// (5..10).include?(5)    # => true
+			// (5..10).include?(4)    # => false
+			// (-5..1).include?(-2)   # => true
+			// (-5..-2).include?(-2)  # => true
+			// (-5..-3).include?(-2)  # => false
+			// (1..-5).include?(-2)   # => false
+			// (5...10).include?(10)  # => false
+			// (5...10).include?(9.5) # => true
+			// (5..10).include?("5")  # => false
 			// ```
 			// @return [Boolean]
-			Name: "include?",
+			Name:  "include?",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					ran := receiver.(*RangeObject)
 
-					value := args[0].(*IntegerObject).value
-					ascendRangeBool := ran.Start <= ran.End && value >= ran.Start && value <= ran.End
-					descendRangeBool := ran.End <= ran.Start && value <= ran.Start && value >= ran.End
+					switch value := args[0].(type) {
+					case *IntegerObject:
+						return toBooleanObject(value.value >= ran.Start && value.value <= ran.lastValue())
+					case *FloatObject:
+						if ran.Exclusive {
+							return toBooleanObject(value.value >= float64(ran.Start) && value.value < float64(ran.End))
+						}
 
-					if ascendRangeBool || descendRangeBool {
-						return TRUE
+						return toBooleanObject(value.value >= float64(ran.Start) && value.value <= float64(ran.End))
+					default:
+						// A range only has Integers, other objects are never included
+						return FALSE
 					}
-					return FALSE
 				}
 			},
 		},
@@ -350,12 +390,13 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			// Returns the size of the range
+			// Returns the size of the range, which is 0 if the range is empty
 			//
 			// ```ruby
 			// (1..5).size   # => 5
+			// (1...5).size  # => 4
 			// (3..9).size   # => 7
-			// (-1..-5).size # => 5
+			// (-1..-5).size # => 0
 			// (-1..7).size  # => 9
 			// ```
 			// @return [Integer]
@@ -364,10 +405,7 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					ran := receiver.(*RangeObject)
 
-					if ran.Start <= ran.End {
-						return t.vm.initIntegerObject(ran.End - ran.Start + 1)
-					}
-					return t.vm.initIntegerObject(ran.Start - ran.End + 1)
+					return t.vm.initIntegerObject(ran.length())
 				}
 			},
 		},
@@ -402,7 +440,8 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 			// ```
 			//
 			// @return [Range]
-			Name: "step",
+			Name:  "step",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					ran := receiver.(*RangeObject)
@@ -411,7 +450,13 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 						return t.vm.initErrorObject(InternalError, CantYieldWithoutBlockFormat)
 					}
 
-					stepValue := args[0].(*IntegerObject).value
+					step, ok := args[0].(*IntegerObject)
+
+					if !ok {
+						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, args[0].Class().Name)
+					}
+
+					stepValue := step.value
 					if stepValue == 0 {
 						return newError("Step can't be 0")
 					} else if stepValue < 0 {
@@ -419,8 +464,8 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 					}
 
 					// range end must greater or equal than range start to execute the block
-					if ran.End >= ran.Start {
-						for i := ran.Start; i <= ran.End; i += stepValue {
+					if ran.length() > 0 {
+						for i := ran.Start; i <= ran.lastValue(); i += stepValue {
 							obj := t.vm.initIntegerObject(i)
 							t.builtInMethodYield(blockFrame, obj)
 						}
@@ -440,8 +485,9 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 			//
 			// ```ruby
 			// (1..5).to_a     # => [1, 2, 3, 4, 5]
+			// (1...5).to_a    # => [1, 2, 3, 4]
 			// (1..5).to_a[2]  # => 3
-			// (-1..-5).to_a   # => [-1, -2, -3, -4, -5]
+			// (-1..-5).to_a   # => []
 			// (-1..3).to_a    # => [-1, 0, 1, 2, 3]
 			// ```
 			//
@@ -453,14 +499,8 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 
 					elems := []Object{}
 
-					if ro.Start <= ro.End {
-						for i := ro.Start; i <= ro.End; i++ {
-							elems = append(elems, t.vm.initIntegerObject(i))
-						}
-					} else {
-						for i := ro.End; i <= ro.Start; i++ {
-							elems = append(elems, t.vm.initIntegerObject(i))
-						}
+					for i := 0; i < ro.length(); i++ {
+						elems = append(elems, t.vm.initIntegerObject(ro.Start+i))
 					}
 
 					return t.vm.initArrayObject(elems)
@@ -472,6 +512,7 @@ func builtInRangeInstanceMethods() []*BuiltInMethodObject {
 			//
 			// ```ruby
 			// (1..5).to_s   # "(1..5)"
+			// (1...5).to_s  # "(1...5)"
 			// (-1..-3).to_s # "(-1..-3)"
 			// ```
 			// @return [String]
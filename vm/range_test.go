@@ -35,6 +35,9 @@ func TestRangeComparisonOperation(t *testing.T) {
 		{`(1..3) == { a: 1, b: 2 }`, false},
 		{`(1..3) == [1, "String", true, 2..5]`, false},
 		{`(1..3) == Integer`, false},
+		{`(1...3) == (1...3)`, true},
+		{`(1...3) == (1..3)`, false},
+		{`(1...3) != (1..3)`, true},
 		{`(1..3) != (1..3)`, false},
 		{`(1..3) != (1..4)`, true},
 		{`(1..3) != 123`, true},
@@ -54,6 +57,24 @@ func TestRangeComparisonOperation(t *testing.T) {
 	}
 }
 
+func TestRangeLiteralFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`(1..2 ** 70)`, "TypeError: Expect argument to be Integer. got: BigInteger", 1},
+		{`(2 ** 70..1)`, "TypeError: Expect argument to be Integer. got: BigInteger", 1},
+		{`(1..2.5)`, "TypeError: Expect argument to be Integer. got: Float", 1},
+		{`(1.5...3)`, "TypeError: Expect argument to be Integer. got: Float", 1},
+		{`("a".."b")`, "TypeError: Expect argument to be Integer. got: String", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestRangeBsearchMethod(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -197,16 +218,19 @@ func TestRangeBsearchMethod(t *testing.T) {
 }
 
 func TestRangeBsearchMethodFail(t *testing.T) {
-	v := initTestVM()
 	testsFail := []errorTestCase{
 		{`ary = [0, 4, 7, 10, 12]
 		(0..4).bsearch do |i|
 			"Binary Search"
 		end
 		`, "TypeError: Expect Integer or Boolean type. got=String", 2},
+		{`(1..3).bsearch`, "InternalError: Can't yield without a block", 1},
+		{`(-1..3).bsearch`, "InternalError: Can't yield without a block", 1},
+		{`(1..3).bsearch(1) do |i| true end`, "ArgumentError: Expect at most 0 args for method 'bsearch'. got: 1", 1},
 	}
 
 	for i, tt := range testsFail {
+		v := initTestVM()
 		evaluated := v.testEval(t, tt.input, getFilename())
 		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
 		v.checkCFP(t, i, 1)
@@ -243,7 +267,7 @@ func TestRangeEachMethod(t *testing.T) {
 		  r = r + i
 		end
 		r
-		`, 15},
+		`, 0},
 		{`
 		r = 0
 		a = -1
@@ -252,7 +276,26 @@ func TestRangeEachMethod(t *testing.T) {
 		  r = r + i
 		end
 		r
-		`, -15},
+		`, 0},
+		{`
+		r = 0
+		(1...5).each do |i|
+		  r = r + i
+		end
+		r
+		`, 10},
+		{`
+		r = 0
+		(1...1).each do |i|
+		  r = r + i
+		end
+		r
+		`, 0},
+		{`
+		(5..1).each do |i|
+		  # Empty Block
+		end.to_s
+		`, "(5..1)"},
 		{`
 		r = 0
 		a = -5
@@ -332,13 +375,26 @@ func TestRangeIncludeMethod(t *testing.T) {
 		`, false},
 		{`
 		(1..-5).include?(-2)
-		`, true},
+		`, false},
 		{`
 		(-2..-5).include?(-2)
+		`, false},
+		{`
+		(5...10).include?(10)
+		`, false},
+		{`
+		(5...10).include?(9)
 		`, true},
 		{`
 		(-3..-5).include?(-2)
 		`, false},
+		{`(5..10).include?(10.0)`, true},
+		{`(5...10).include?(9.5)`, true},
+		{`(5...10).include?(10.0)`, false},
+		{`(5..10).include?(4.5)`, false},
+		{`(1..3).include?("x")`, false},
+		{`(1..3).include?(nil)`, false},
+		{`(1..3).include?(2 ** 64)`, false},
 	}
 
 	for i, tt := range tests {
@@ -391,7 +447,13 @@ func TestRangeSizeMethod(t *testing.T) {
 		`, 7},
 		{`
 		(-1..-5).size
-		`, 5},
+		`, 0},
+		{`
+		(1...5).size
+		`, 4},
+		{`
+		(1...1).size
+		`, 0},
 		{`
 		(-1..7).size
 		`, 9},
@@ -466,6 +528,21 @@ func TestRangeStepMethod(t *testing.T) {
 	}
 }
 
+func TestRangeStepMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`(1..3).step("1") do |i| end`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`(1..3).step(0.5) do |i| end`, "TypeError: Expect argument to be Integer. got: Float", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestRangeToStringMethod(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -483,6 +560,9 @@ func TestRangeToStringMethod(t *testing.T) {
 		{`
 		(1..-5).to_s
 		`, "(1..-5)"},
+		{`
+		(1...5).to_s
+		`, "(1...5)"},
 	}
 
 	for i, tt := range tests {
@@ -507,10 +587,16 @@ func TestRangeToArrayMethod(t *testing.T) {
 		`, 3},
 		{`
 		(-1..-5).to_a.length
-		`, 5},
+		`, 0},
+		{`
+		(5..1).to_a.length
+		`, 0},
 		{`
-		(-1..-5).to_a[2]
-		`, -3},
+		(1...5).to_a.length
+		`, 4},
+		{`
+		(1...5).to_a[3]
+		`, 4},
 		{`
 		(-1..3).to_a.length
 		`, 5},
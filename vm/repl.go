@@ -40,7 +40,21 @@ func (vm *VM) REPLExec(sets []*bytecode.InstructionSet) {
 	cf.self = oldFrame.self
 	cf.lPr = oldFrame.lPr
 	vm.mainThread.callFrameStack.push(cf)
+
+	t := vm.mainThread
+	sp := t.sp
+	vm.replError = nil
 	vm.startFromTopFrame()
+
+	// An uncaught error aborts the evaluation and leaves its frames and values behind,
+	// so we restore the base frame to keep the REPL's scope usable for next input.
+	if vm.replError != nil {
+		for t.cfp > 1 {
+			t.callFrameStack.pop()
+		}
+
+		t.sp = sp
+	}
 }
 
 // GetExecResult returns stack's top most value. Normally it's used in tests.
@@ -54,6 +68,10 @@ func (vm *VM) GetExecResult() Object {
 
 // GetREPLResult returns strings that should be showed after each evaluation.
 func (vm *VM) GetREPLResult() string {
+	if vm.replError != nil {
+		return vm.replError.toString()
+	}
+
 	top := vm.mainThread.stack.pop()
 
 	if top != nil {
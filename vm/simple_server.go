@@ -46,7 +46,8 @@ func builtinSimpleServerInstanceMethods() []*BuiltInMethodObject {
 
 	return []*BuiltInMethodObject{
 		{
-			Name: "mount",
+			Name:  "mount",
+			Arity: &Arity{2, 2},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					path := args[0].(*StringObject).value
@@ -59,7 +60,8 @@ func builtinSimpleServerInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			Name: "static",
+			Name:  "static",
+			Arity: &Arity{2, 2},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					prefix := args[0].(*StringObject).value
@@ -134,7 +136,7 @@ func newHandler(t *thread, blockFrame *callFrame) func(http.ResponseWriter, *htt
 		res := httpResponseClass.initializeInstance()
 
 		req := initRequest(t, w, r)
-		result := thread.builtInMethodYield(blockFrame, req, res)
+		result := thread.yieldBlock(blockFrame, req, res)
 
 		if err, ok := result.Target.(*Error); ok {
 			log.Printf("Error: %s", err.Message)
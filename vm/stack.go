@@ -1,6 +1,7 @@
 package vm
 
 import (
+	"fmt"
 	"os"
 	"sync"
 )
@@ -15,17 +16,8 @@ type stack struct {
 }
 
 func (s *stack) set(index int, pointer *Pointer) {
-	t := s.thread
-
-	if _, ok := pointer.Target.(*Error); ok {
-		cf := t.callFrameStack.top()
-		cf.pc = len(cf.instructionSet.instructions)
-
-		if t.vm.mode == NormalMode {
-			if t.isMainThread() {
-				os.Exit(1)
-			}
-		}
+	if err, ok := pointer.Target.(*Error); ok && !err.rescued {
+		s.raiseError(err)
 	}
 
 	s.Lock()
@@ -45,21 +37,35 @@ func (s *stack) push(v *Pointer) {
 		s.Data[s.thread.sp] = v
 	}
 
-	if _, ok := v.Target.(*Error); ok {
-		t := s.thread
-		cf := t.callFrameStack.top()
-		cf.pc = len(cf.instructionSet.instructions)
-
-		if t.vm.mode == NormalMode {
-			if t.isMainThread() {
-				os.Exit(1)
-			}
-		}
+	if err, ok := v.Target.(*Error); ok && !err.rescued {
+		s.raiseError(err)
 	}
 
 	s.thread.sp++
 }
 
+// raiseError stops current call frame. If the error won't be rescued in main thread,
+// it prints the error message (which contains the error's source line) to stderr and exits the program.
+// In REPL mode the error is kept as the evaluation's result instead.
+func (s *stack) raiseError(err *Error) {
+	t := s.thread
+	cf := t.callFrameStack.top()
+	cf.pc = len(cf.instructionSet.instructions)
+
+	if !t.isMainThread() || t.hasRescueHandler() {
+		return
+	}
+
+	switch t.vm.mode {
+	case NormalMode:
+		fmt.Fprintln(os.Stderr, err.Message)
+		os.Exit(1)
+	case REPLMode:
+		// REPL shouldn't exit, so we keep the error and let REPLExec clean up the frames it left
+		t.vm.replError = err
+	}
+}
+
 func (s *stack) pop() *Pointer {
 	s.Lock()
 	defer s.Unlock()
@@ -127,22 +127,39 @@ func TestDefStatement(t *testing.T) {
 
 		f2.ten + f1.ten
 		`, 30},
-		{`
-		a = 1
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDefStatementFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		// Integers are frozen like in Ruby, because small integers are shared
+		{`a = 1
 
 		def a.foo
 		  10
 		end
+		`, "TypeError: can't define singleton method 'foo' for 1", 3},
+		{`a = 100000
 
-		a.foo
-		`, 10},
+		def a.foo
+		  10
+		end
+		`, "TypeError: can't define singleton method 'foo' for 100000", 3},
 	}
 
-	for i, tt := range tests {
+	for i, tt := range testsFail {
 		v := initTestVM()
 		evaluated := v.testEval(t, tt.input, getFilename())
-		checkExpected(t, i, evaluated, tt.expected)
-		v.checkCFP(t, i, 0)
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
 		v.checkSP(t, i, 1)
 	}
 }
@@ -346,6 +363,49 @@ func TestWhileStatement(t *testing.T) {
 		end
 		a[4]
 		`, 6},
+		{
+			`
+		a = [1, 2, 3]
+		i = 0
+		while a.pop do
+		  i += 1
+		end
+		i
+		`, 3},
+		{
+			`
+		i = 0
+		while nil do
+		  i += 1
+		end
+		i
+		`, 0},
+		// `do` is optional
+		{
+			`
+		i = 0
+		while i < 2
+		  i = i + 1
+		end
+		i
+		`, 2},
+		{
+			`
+		a = [1, 2, 3]
+		sum = 0
+		while a.length > 0
+		  sum += a.pop
+		end
+		sum
+		`, 6},
+		{
+			`
+		i = 10
+		while i < 0
+		  i = i + 1
+		end
+		i
+		`, 10},
 	}
 
 	for i, tt := range tests {
@@ -357,6 +417,127 @@ func TestWhileStatement(t *testing.T) {
 	}
 }
 
+func TestWhileStatementFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`i = 0
+		while i < 10 do
+		  i += "1"
+		end
+		`, "TypeError: Expect argument to be Numeric. got: String", 3},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestForExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		sum = 0
+		for x in [1, 2, 3]
+		  sum = sum + x
+		end
+		sum
+		`, 6},
+		{`
+		sum = 0
+		for x in 1..4 do
+		  sum = sum + x
+		end
+		sum
+		`, 10},
+		{`
+		sum = 0
+		for x in 1...4; sum = sum + x; end
+		sum
+		`, 6},
+		{`
+		for x in [1, 2, 3]
+		end
+		x
+		`, 3},
+		{`
+		for x in []
+		end
+		x
+		`, nil},
+		{`
+		for x in [1, 2]
+		  y = x * 10
+		end
+		y
+		`, 20},
+		{`
+		(for x in 1..3
+		end).to_s
+		`, "(1..3)"},
+		{`
+		sum = 0
+		for x in 1..10
+		  next if x == 2
+		  break if x > 4
+		  sum = sum + x
+		end
+		sum
+		`, 8},
+		{`
+		result = []
+		for i in [1, 2]
+		  for j in [3, 4]
+		    break if j == 4
+		    result.push(i * j)
+		  end
+		  result.push(i)
+		end
+		result.join(",")
+		`, "3,1,6,2"},
+		{`
+		def foo
+		  for x in [1, 2, 3]
+		    return x * 2 if x == 2
+		  end
+		end
+		foo
+		`, 4},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestForExpressionFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`for x in 1
+		end
+		`, "UndefinedMethodError: Undefined Method 'to_a' for 1", 1},
+		{`for x in [1, 2]
+		  x += "1"
+		end
+		`, "TypeError: Expect argument to be Numeric. got: String", 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestNextStatement(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -396,6 +577,47 @@ end
 
 i
 		`, 12},
+		{`
+x = 0
+
+[1, 2, 3].each do |i|
+  j = 0
+  while j < 3 do
+    j += 1
+    next if j == 2
+    x = x + j
+  end
+  next if i == 2
+  x = x + 100
+end
+
+x
+		`, 212},
+		{`
+r = [1, 2, 3, 4].map do |i|
+  next 0 if i.even?
+  i * 10
+end
+
+r.to_s
+		`, "[10, 0, 30, 0]"},
+		{`
+def foo
+  yield(1) + yield(2)
+end
+
+foo do |i|
+  next i * 100
+  i
+end
+		`, 300},
+		{`
+r = for i in [1, 2, 3] do
+  next i * 10
+end
+
+r.to_s
+		`, "[1, 2, 3]"},
 	}
 
 	for i, tt := range tests {
@@ -447,6 +669,82 @@ end
 a = i * 10
 a + 100
 		`, 310},
+		{`
+r = [1, 2, 3].each do |i|
+  break i * 10 if i == 2
+end
+
+r
+		`, 20},
+		{`
+[1, 2, 3].each do |i|
+  break
+end
+		`, nil},
+		{`
+def foo
+  yield(1)
+  yield(2)
+  100
+end
+
+a = foo do |i|
+  break i + 40 if i == 2
+end
+
+b = foo do |i|
+  i
+end
+
+a + b
+		`, 142},
+		{`
+x = 0
+
+[1, 2, 3].each do |i|
+  [4, 5, 6].each do |j|
+    break if j == 5
+    x = x + j
+  end
+  x = x + i
+end
+
+x
+		`, 18},
+		{`
+i = 0
+while i < 10 do
+  i += 1
+  [1, 2].each do |j|
+    break
+  end
+end
+
+i
+		`, 10},
+		{`
+r = for i in [1, 2, 3] do
+  break i * 100 if i == 2
+end
+
+r
+		`, 200},
+		{`
+def foo
+  yield
+end
+
+r = foo do
+  while true do
+    break 1
+  end
+
+  break 2
+  3
+end
+
+r
+		`, 2},
 	}
 
 	for i, tt := range tests {
@@ -457,3 +755,24 @@ a + 100
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestBreakStatementFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`class Foo
+		  define_method(:bar) do
+		    break 1
+		  end
+		end
+
+		Foo.new.bar
+		`, "InternalError: Can't break outside of a block", 3},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 2)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -2,6 +2,8 @@ package vm
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"regexp"
 	"strconv"
 	"strings"
@@ -116,7 +118,8 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			// ```
 			//
 			// @return [String]
-			Name: "+",
+			Name:  "+",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
@@ -141,7 +144,8 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			// ```
 			//
 			// @return [String]
-			Name: "*",
+			Name:  "*",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
@@ -171,11 +175,12 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			// Returns a Boolean if first string greater than second string
 			//
 			// ```ruby
-			// "a" < "b" # => true
+			// "b" > "a" # => true
 			// ```
 			//
 			// @return [Boolean]
-			Name: ">",
+			Name:  ">",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
@@ -189,11 +194,7 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 
 					rightValue := right.value
 
-					if leftValue > rightValue {
-						return TRUE
-					}
-
-					return FALSE
+					return toBooleanObject(leftValue > rightValue)
 				}
 			},
 		},
@@ -205,7 +206,8 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			// ```
 			//
 			// @return [Boolean]
-			Name: "<",
+			Name:  "<",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
@@ -219,11 +221,63 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 
 					rightValue := right.value
 
-					if leftValue < rightValue {
-						return TRUE
+					return toBooleanObject(leftValue < rightValue)
+				}
+			},
+		},
+		{
+			// Returns a Boolean if first string greater than or equal to second string
+			//
+			// ```ruby
+			// "b" >= "a" # => true
+			// "a" >= "a" # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name:  ">=",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+
+					leftValue := receiver.(*StringObject).value
+					r := args[0]
+					right, ok := r.(*StringObject)
+
+					if !ok {
+						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, r.Class().Name)
 					}
 
-					return FALSE
+					rightValue := right.value
+
+					return toBooleanObject(leftValue >= rightValue)
+				}
+			},
+		},
+		{
+			// Returns a Boolean if first string less than or equal to second string
+			//
+			// ```ruby
+			// "a" <= "b" # => true
+			// "a" <= "a" # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name:  "<=",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+
+					leftValue := receiver.(*StringObject).value
+					r := args[0]
+					right, ok := r.(*StringObject)
+
+					if !ok {
+						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, r.Class().Name)
+					}
+
+					rightValue := right.value
+
+					return toBooleanObject(leftValue <= rightValue)
 				}
 			},
 		},
@@ -236,7 +290,8 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			// ```
 			//
 			// @return [Boolean]
-			Name: "==",
+			Name:  "==",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
@@ -250,11 +305,7 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 
 					rightValue := right.value
 
-					if leftValue == rightValue {
-						return TRUE
-					}
-
-					return FALSE
+					return toBooleanObject(leftValue == rightValue)
 				}
 			},
 		},
@@ -269,7 +320,8 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			// ```
 			//
 			// @return [Integer]
-			Name: "<=>",
+			Name:  "<=>",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
@@ -303,7 +355,8 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			// ```
 			//
 			// @return [Boolean]
-			Name: "!=",
+			Name:  "!=",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
@@ -316,11 +369,7 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 
 					rightValue := right.value
 
-					if leftValue != rightValue {
-						return TRUE
-					}
-
-					return FALSE
+					return toBooleanObject(leftValue != rightValue)
 				}
 			},
 		},
@@ -596,10 +645,7 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 
 					str := receiver.(*StringObject).value
 
-					if str == "" {
-						return TRUE
-					}
-					return FALSE
+					return toBooleanObject(str == "")
 				}
 			},
 		},
@@ -637,10 +683,7 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 						return FALSE
 					}
 
-					if compareStrValue == string([]rune(str)[strLength-compareStrLength:]) {
-						return TRUE
-					}
-					return FALSE
+					return toBooleanObject(compareStrValue == string([]rune(str)[strLength-compareStrLength:]))
 				}
 			},
 		},
@@ -689,31 +732,7 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			//
 			// @return [String]
 			Name: "gsub",
-			Fn: func(receiver Object) builtinMethodBody {
-				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					if len(args) != 2 {
-						return t.vm.initErrorObject(ArgumentError, "Expect 2 arguments. got=%v", len(args))
-					}
-
-					str := receiver.(*StringObject).value
-
-					p := args[0]
-					pattern, ok := p.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(TypeError, "Expect pattern to be String. got: %s", p.Class().Name)
-					}
-
-					r := args[1]
-					replacement, ok := r.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(TypeError, "Expect replacement to be String. got: %s", r.Class().Name)
-					}
-
-					return t.vm.initStringObject(strings.Replace(str, pattern.value, replacement.value, -1))
-				}
-			},
+			Fn:   substitute(-1),
 		},
 		{
 			// Checks if the specified string is included in the receiver
@@ -739,11 +758,7 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, i.Class().Name)
 					}
 
-					if strings.Contains(str, includeStr.value) {
-						return TRUE
-					}
-
-					return FALSE
+					return toBooleanObject(strings.Contains(str, includeStr.value))
 				}
 			},
 		},
@@ -808,8 +823,7 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			// Returns the character length of self
-			// **Note:** the length is currently byte-based, instead of charcode-based.
+			// Returns the character length of self, multibyte characters are counted as one character
 			//
 			// ```ruby
 			// "zero".length # => 4
@@ -1014,8 +1028,7 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			// Returns the character length of self
-			// **Note:** the length is currently byte-based, instead of charcode-based.
+			// Returns the character length of self, multibyte characters are counted as one character
 			//
 			// ```ruby
 			// "zero".size  # => 4
@@ -1054,6 +1067,8 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			// "1234567890".slice(-5..-10)  # => ""
 			// "1234567890".slice(-11..-12) # => nil
 			// "1234567890".slice(-10..-12) # => ""
+			// "1234567890".slice(1...4)    # => "234"
+			// "1234567890".slice(1...-1)   # => "23456789"
 			// "Hello 😊🐟 World".slice(1..6)    # => "ello 😊"
 			// "Hello 😊🐟 World".slice(-10..7)  # => "o 😊🐟"
 			// "Hello 😊🐟 World".slice(1..-1)   # => "ello 😊🐟 World"
@@ -1087,41 +1102,30 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 					switch args[0].(type) {
 					case *RangeObject:
 						ran := args[0].(*RangeObject)
-						switch {
-						case ran.Start >= 0 && ran.End >= 0:
-							if ran.Start > strLength {
-								return NULL
-							} else if ran.Start > ran.End {
-								return t.vm.initStringObject("")
-							}
-							return t.vm.initStringObject(string([]rune(str)[ran.Start : ran.End+1]))
-						case ran.Start < 0 && ran.End >= 0:
-							positiveStart := strLength + ran.Start
-							if -ran.Start > strLength {
-								return NULL
-							} else if positiveStart > ran.End {
-								return t.vm.initStringObject("")
-							}
-							return t.vm.initStringObject(string([]rune(str)[positiveStart : ran.End+1]))
-						case ran.Start >= 0 && ran.End < 0:
-							positiveEnd := strLength + ran.End
-							if ran.Start > strLength {
-								return NULL
-							} else if positiveEnd < 0 || ran.Start > positiveEnd {
-								return t.vm.initStringObject("")
-							}
-							return t.vm.initStringObject(string([]rune(str)[ran.Start : positiveEnd+1]))
-						default:
-							positiveStart := strLength + ran.Start
-							positiveEnd := strLength + ran.End
-							if positiveStart < 0 {
-								return NULL
-							} else if positiveStart > positiveEnd {
-								return t.vm.initStringObject("")
-							}
-							return t.vm.initStringObject(string([]rune(str)[positiveStart : positiveEnd+1]))
+						start, end := ran.Start, ran.End
+
+						if start < 0 {
+							start += strLength
+						}
+						if end < 0 {
+							end += strLength
+						}
+						// Makes end exclusive so both kinds of ranges can be sliced the same way
+						if !ran.Exclusive {
+							end++
 						}
 
+						if start < 0 || start > strLength {
+							return NULL
+						}
+						if end > strLength {
+							end = strLength
+						}
+						if start >= end {
+							return t.vm.initStringObject("")
+						}
+						return t.vm.initStringObject(string([]rune(str)[start:end]))
+
 					case *IntegerObject:
 						intValue := args[0].(*IntegerObject).value
 						if intValue < 0 {
@@ -1142,34 +1146,49 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			// Returns an array of strings separated by the given separator
+			// Returns an array of strings separated by the given separator.
+			// Without a separator, it splits on whitespaces and runs of whitespaces count as one separator.
+			// An empty separator splits the string into characters.
+			// Like Ruby, trailing empty strings are dropped from the result.
 			//
 			// ```ruby
 			// "Hello World".split("o") # => ["Hell", " W", "rld"]
 			// "Goby".split("")         # => ["G", "o", "b", "y"]
-			// "Hello\nWorld\nGoby".split("o") # => ["Hello", "World", "Goby"]
+			// "Hello\nWorld\nGoby".split("\n") # => ["Hello", "World", "Goby"]
 			// "Hello🐟World🐟Goby".split("🐟") # => ["Hello", "World", "Goby"]
+			// " Hello  World ".split   # => ["Hello", "World"]
+			// "a,,b,,".split(",")      # => ["a", "", "b"]
 			// ```
 			//
+			// @param separator [String]
 			// @return [Array]
 			Name: "split",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					if len(args) != 1 {
-						return t.vm.initErrorObject(ArgumentError, "Expect 1 argument. got=%v", strconv.Itoa(len(args)))
-					}
+					str := receiver.(*StringObject).value
+					var arr []string
 
-					s := args[0]
-					seperator, ok := s.(*StringObject)
+					switch len(args) {
+					case 0:
+						arr = strings.Fields(str)
+					case 1:
+						s := args[0]
+						seperator, ok := s.(*StringObject)
 
-					if !ok {
-						return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, s.Class().Name)
+						if !ok {
+							return t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, stringClass, s.Class().Name)
+						}
+
+						arr = strings.Split(str, seperator.value)
+					default:
+						return t.vm.initErrorObject(ArgumentError, "Expect 0 or 1 argument. got=%v", strconv.Itoa(len(args)))
 					}
 
-					str := receiver.(*StringObject).value
-					arr := strings.Split(str, seperator.value)
+					for len(arr) > 0 && arr[len(arr)-1] == "" {
+						arr = arr[:len(arr)-1]
+					}
 
-					var elements []Object
+					elements := []Object{}
 					for i := 0; i < len(arr); i++ {
 						elements = append(elements, t.vm.initStringObject(arr[i]))
 					}
@@ -1212,10 +1231,7 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 						return FALSE
 					}
 
-					if compareStrValue == string([]rune(str)[:compareStrLength]) {
-						return TRUE
-					}
-					return FALSE
+					return toBooleanObject(compareStrValue == string([]rune(str)[:compareStrLength]))
 				}
 			},
 		},
@@ -1253,6 +1269,21 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 				}
 			},
 		},
+		{
+			// Returns a copy of str with the first occurrence of pattern substituted for the second argument.
+			// Like `gsub`, only a String pattern is supported for now and it is matched literally.
+			// The copy is identical to str if the pattern doesn't occur.
+			//
+			// ```ruby
+			// "Hello".sub("l", "L")   # => "HeLlo"
+			// "Hello".sub("l", "")    # => "Helo"
+			// "Hello".sub("x", "y")   # => "Hello"
+			// ```
+			//
+			// @return [String]
+			Name: "sub",
+			Fn:   substitute(1),
+		},
 		{
 			// Returns an array of characters converted from a string
 			//
@@ -1279,41 +1310,33 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 			},
 		},
 		{
-			// Returns the result of converting self to Integer
+			// Returns the result of converting self to Integer in the given radix, which defaults to 10.
+			// Leading whitespaces and a sign are allowed, and parsing stops at the first character
+			// which isn't a valid digit. It returns 0 if there's no valid digit at all.
 			//
 			// ```ruby
 			// "123".to_i # => 123
 			// "3d print".to_i # => 3
 			// "some text".to_i # => 0
+			// "ff".to_i(16) # => 255
+			// "-101".to_i(2) # => -5
+			// "18446744073709551616".to_i # => 18446744073709551616, which is a BigInteger
 			// ```
 			//
+			// @param radix [Integer]
 			// @return [Integer]
 			Name: "to_i",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 
-					str := receiver.(*StringObject).value
-					parsedStr, err := strconv.ParseInt(str, 10, 0)
-
-					if err == nil {
-						return t.vm.initIntegerObject(int(parsedStr))
+					radix, err := radixArgument(t, args)
+					if err != nil {
+						return err
 					}
 
-					var digits string
-					for _, char := range str {
-						if unicode.IsDigit(char) {
-							digits += string(char)
-						} else {
-							break
-						}
-					}
-
-					if len(digits) > 0 {
-						parsedStr, _ = strconv.ParseInt(digits, 10, 0)
-						return t.vm.initIntegerObject(int(parsedStr))
-					}
+					str := receiver.(*StringObject).value
 
-					return t.vm.initIntegerObject(0)
+					return t.vm.initIntegerFromBigInt(parseIntegerPrefix(str, radix))
 				}
 			},
 		},
@@ -1335,6 +1358,24 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the Symbol with self value as its name
+			//
+			// ```ruby
+			// "string".to_sym # => :string
+			// ```
+			//
+			// @return [Symbol]
+			Name: "to_sym",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+
+					str := receiver.(*StringObject).value
+
+					return t.vm.initSymbolObject(str)
+				}
+			},
+		},
 		{
 			// Returns a new String with all characters is upcase
 			//
@@ -1353,6 +1394,39 @@ func builtinStringInstanceMethods() []*BuiltInMethodObject {
 				}
 			},
 		},
+		{
+			// Formats the arguments with self as the format string like `sprintf`.
+			// The argument can be an Array of values or a single value.
+			//
+			// ```ruby
+			// "%d items" % 3                    # => "3 items"
+			// "%s is %d years old" % ["Goby", 5] # => "Goby is 5 years old"
+			// "%.2f" % 3.14159                  # => "3.14"
+			// "%05x" % 255                      # => "000ff"
+			// ```
+			//
+			// @param arguments [Object]
+			// @return [String]
+			Name:  "%",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					arguments := []Object{args[0]}
+
+					if arr, ok := args[0].(*ArrayObject); ok {
+						arguments = arr.Elements
+					}
+
+					str, err := formatString(t, receiver.(*StringObject).value, arguments)
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initStringObject(str)
+				}
+			},
+		},
 		{
 			Name: "to_bytes",
 			Fn: func(receiver Object) builtinMethodBody {
@@ -1377,6 +1451,182 @@ func (s *StringObject) toJSON() string {
 	return strconv.Quote(s.value)
 }
 
+func (s *StringObject) hashKey() string {
+	return s.value
+}
+
 func (s *StringObject) equal(e *StringObject) bool {
 	return s.value == e.value
 }
+
+// Other helper functions ----------------------------------------------
+
+// substitute is the implementation of `gsub` and `sub`, which replaces the first n occurrences
+// of the pattern. It replaces all of them if n < 0.
+func substitute(n int) func(receiver Object) builtinMethodBody {
+	return func(receiver Object) builtinMethodBody {
+		return func(t *thread, args []Object, blockFrame *callFrame) Object {
+			if len(args) != 2 {
+				return t.vm.initErrorObject(ArgumentError, "Expect 2 arguments. got=%v", len(args))
+			}
+
+			str := receiver.(*StringObject).value
+
+			p := args[0]
+			pattern, ok := p.(*StringObject)
+
+			if !ok {
+				return t.vm.initErrorObject(TypeError, "Expect pattern to be String. got: %s", p.Class().Name)
+			}
+
+			r := args[1]
+			replacement, ok := r.(*StringObject)
+
+			if !ok {
+				return t.vm.initErrorObject(TypeError, "Expect replacement to be String. got: %s", r.Class().Name)
+			}
+
+			return t.vm.initStringObject(strings.Replace(str, pattern.value, replacement.value, n))
+		}
+	}
+}
+
+// parseIntegerPrefix parses the leading integer of str in the given radix like Ruby's `String#to_i`.
+// It skips leading whitespaces, accepts an optional sign, and stops at the first invalid digit.
+func parseIntegerPrefix(str string, radix int) *big.Int {
+	str = strings.TrimLeftFunc(str, unicode.IsSpace)
+
+	var sign string
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		sign, str = str[:1], str[1:]
+	}
+
+	end := 0
+	for _, char := range str {
+		var digit int
+
+		switch {
+		case '0' <= char && char <= '9':
+			digit = int(char - '0')
+		case 'a' <= char && char <= 'z':
+			digit = int(char-'a') + 10
+		case 'A' <= char && char <= 'Z':
+			digit = int(char-'A') + 10
+		default:
+			digit = radix
+		}
+
+		if digit >= radix {
+			break
+		}
+
+		end++
+	}
+
+	if end == 0 {
+		return new(big.Int)
+	}
+
+	// The digits are always valid, and values out of Integer's range become BigIntegers like arithmetic results
+	value, _ := new(big.Int).SetString(sign+str[:end], radix)
+	return value
+}
+
+// formatDirective matches a directive of format strings, the first group is its flags, width and precision
+var formatDirective = regexp.MustCompile(`%([-+ 0#]*[0-9]*(?:\.[0-9]*)?)(.?)`)
+
+// formatString is the implementation of `String#%` and `sprintf`. It supports `%d` (or `%i`), `%s`, `%f`, `%e`, `%g`,
+// `%x`, `%o` and `%b` with flags, width and precision the same as Go's fmt, and `%%` for a percent sign.
+// The number of arguments must match the number of directives.
+func formatString(t *thread, format string, args []Object) (string, *Error) {
+	matches := formatDirective.FindAllStringSubmatchIndex(format, -1)
+	count := 0
+
+	for _, m := range matches {
+		verb := format[m[4]:m[5]]
+
+		switch verb {
+		case "%":
+		case "d", "i", "s", "f", "e", "E", "g", "G", "x", "X", "o", "b":
+			count++
+		default:
+			return "", t.vm.initErrorObject(ArgumentError, "Malformed format string - %s", format[m[0]:m[1]])
+		}
+	}
+
+	if count != len(args) {
+		return "", t.vm.initErrorObject(ArgumentError, WrongNumberOfArgumentFormat, count, len(args))
+	}
+
+	var result strings.Builder
+	last := 0
+	i := 0
+
+	for _, m := range matches {
+		result.WriteString(format[last:m[0]])
+		last = m[1]
+
+		spec, verb := format[m[2]:m[3]], format[m[4]:m[5]]
+
+		if verb == "%" {
+			result.WriteString("%")
+			continue
+		}
+
+		value, err := formatArgument(t, verb, args[i])
+
+		if err != nil {
+			return "", err
+		}
+
+		if verb == "i" {
+			verb = "d"
+		}
+
+		result.WriteString(fmt.Sprintf("%"+spec+verb, value))
+		i++
+	}
+
+	result.WriteString(format[last:])
+	return result.String(), nil
+}
+
+// formatArgument converts an argument of the format string into the Go value of given directive
+func formatArgument(t *thread, verb string, arg Object) (interface{}, *Error) {
+	switch verb {
+	case "s":
+		str := t.sendMethod(arg, "to_s")
+
+		if err, ok := str.(*Error); ok {
+			return nil, err
+		}
+
+		return str.toString(), nil
+	case "f", "e", "E", "g", "G":
+		switch arg := arg.(type) {
+		case *IntegerObject:
+			return float64(arg.value), nil
+		case *BigIntegerObject:
+			return arg.floatValue(), nil
+		case *FloatObject:
+			return arg.value, nil
+		}
+
+		return nil, t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, numericName, arg.Class().Name)
+	default:
+		if f, ok := arg.(*FloatObject); ok && (verb == "d" || verb == "i") {
+			arg = f.toInteger(t, math.Trunc(f.value))
+		}
+
+		switch arg := arg.(type) {
+		case *IntegerObject:
+			return arg.value, nil
+		case *BigIntegerObject:
+			return arg.value, nil
+		case *Error:
+			return nil, arg
+		}
+
+		return nil, t.vm.initErrorObject(TypeError, WrongArgumentTypeFormat, integerClass, arg.Class().Name)
+	}
+}
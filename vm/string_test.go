@@ -35,6 +35,9 @@ func TestEvalStringExpression(t *testing.T) {
 		{`'\'Alexius\''`, "'Alexius'"},
 		{`"Maxwell\nAlexius"`, "Maxwell\nAlexius"},
 		{`'Maxwell\nAlexius'`, "Maxwell\\nAlexius"},
+		{`"Maxwell\tAlexius\n"`, "Maxwell\tAlexius\n"},
+		{`"back\\slash \0"`, "back\\slash \x00"},
+		{`'back\\slash'`, "back\\slash"},
 	}
 
 	for i, tt := range tests {
@@ -62,6 +65,24 @@ func TestStringConversion(t *testing.T) {
 		{`"string".to_i`, 0},
 		{`"123string123".to_i`, 123},
 		{`"string123".to_i`, 0},
+		{`"-42".to_i`, -42},
+		{`"  +42abc".to_i`, 42},
+		{`"ff".to_i(16)`, 255},
+		{`"FF".to_i(16)`, 255},
+		{`"fg".to_i(16)`, 15},
+		{`"-101".to_i(2)`, -5},
+		{`"z".to_i(36)`, 35},
+		{`"9".to_i(8)`, 0},
+		{`"".to_i`, 0},
+		// Values out of Integer's range are promoted to BigInteger
+		{`"9223372036854775807".to_i`, 9223372036854775807},
+		{`"9223372036854775807".to_i.class.name`, "Integer"},
+		{`"9223372036854775808".to_i.to_s`, "9223372036854775808"},
+		{`"9223372036854775808".to_i.class.name`, "BigInteger"},
+		{`"-9223372036854775808".to_i.class.name`, "Integer"},
+		{`"-9223372036854775809abc".to_i.to_s`, "-9223372036854775809"},
+		{`"ffffffffffffffffff".to_i(16).to_s`, "4722366482869645213695"},
+		{`("1" + "0" * 30).to_i == 10 ** 30`, true},
 		{`
 		  arr = "Goby".to_a
 		  arr[0]
@@ -149,6 +170,134 @@ func TestStringConversion(t *testing.T) {
 	}
 }
 
+func TestStringInterpolation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`name = "Goby"; "Hello #{name}!"`, "Hello Goby!"},
+		{`"#{1 + 2} is #{[1, 2].length + 1}"`, "3 is 3"},
+		{`"#{1.5}, #{nil}, #{true}, #{[1, "a"]}"`, `1.5, , true, [1, "a"]`},
+		{`h = { "foo" => "bar" }; "#{h["foo"]}"`, "bar"},
+		{`a = "b"; "#{a}#{a}"`, "bb"},
+		{`"#{"nested #{"string"}"}"`, "nested string"},
+		{`"#{}"`, ""},
+		{`a = 1; "\#{a}"`, "#{a}"},
+		{`a = 1; '#{a}'`, "#{a}"},
+		{`
+		def greet(name)
+		  "Hi, #{name.upcase}"
+		end
+
+		greet("goby")
+		`, "Hi, GOBY"},
+		{`
+		class Foo
+		  def initialize
+		    @bar = 10
+		  end
+
+		  def to_s
+		    "Foo(#{@bar})"
+		  end
+		end
+
+		"#{Foo.new}"
+		`, "Foo(10)"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestHeredoc(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		<<~EOS
+		  Hello
+		    World
+		EOS
+		`, "Hello\n  World\n"},
+		{`
+		<<~EOS
+
+		    indented
+
+
+		  less
+		EOS
+		`, "\n  indented\n\n\nless\n"},
+		{`
+		<<-EOS
+		  Hello
+		  EOS
+		`, "\t\t  Hello\n"},
+		{`
+		name = "Goby"
+		<<~EOS
+		  Hi, #{name.upcase}
+		  \tBye
+		EOS
+		`, "Hi, GOBY\n\tBye\n"},
+		{`
+		name = "Goby"
+		<<~'EOS'
+		  Hi, #{name}\n
+		EOS
+		`, "Hi, #{name}\\n\n"},
+		{`
+		a = <<~A + <<~B
+		  a
+		A
+		  b
+		B
+		a
+		`, "a\nb\n"},
+		{`
+		<<~EOS.length
+		  four
+		EOS
+		`, 5},
+		{`
+		<<~EOS
+		EOS
+		`, ""},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestHeredocFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`<<~EOS
+		  #{foo}
+		EOS
+		bar`, "UndefinedMethodError: Undefined Method 'bar' for <Instance of: Object>", 4},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestStringComparison(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -174,6 +323,13 @@ func TestStringComparison(t *testing.T) {
 		{`"123" > "1235"`, false},
 		{`"1234" < "123"`, false},
 		{`"1234" < "12jdkfj3"`, true},
+		{`"abc" < "abd"`, true},
+		{`"abd" >= "abc"`, true},
+		{`"abc" >= "abc"`, true},
+		{`"abc" >= "abd"`, false},
+		{`"abc" <= "abd"`, true},
+		{`"abc" <= "abc"`, true},
+		{`"abd" <= "abc"`, false},
 		{`"1234" <=> "1234"`, 0},
 		{`"1234" <=> "4"`, -1},
 		{`"abcdef" <=> "abcde"`, 1},
@@ -200,6 +356,8 @@ func TestStringComparisonFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`"a" < 1`, "TypeError: Expect argument to be String. got: Integer", 1},
 		{`"a" > 1`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`"a" >= 1`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`"a" <= 1`, "TypeError: Expect argument to be String. got: Integer", 1},
 		{`"a" <=> 1`, "TypeError: Expect argument to be String. got: Integer", 1},
 	}
 	for i, tt := range testsFail {
@@ -420,6 +578,8 @@ func TestStringDowncaseMethod(t *testing.T) {
 		{`"MORE wOrds".downcase`, "more words"},
 		{`"HeLlO\tWorLD".downcase`, "hello\tworld"},
 		{`"🍣HeLlO🍺".downcase`, "🍣hello🍺"},
+		{`"HÉLLO".downcase`, "héllo"},
+		{`a = "Hi"; a.downcase; a`, "Hi"},
 	}
 
 	for i, tt := range tests {
@@ -537,6 +697,15 @@ func TestStringGlobalSubstituteMethod(t *testing.T) {
 		{`"Hello World".gsub(" ", "\n")`, "Hello\nWorld"},
 		{`"Hello World".gsub("Hello", "Goby")`, "Goby World"},
 		{`"Hello 🍣 Hello 🍣 Hello".gsub("🍣", "🍺")`, "Hello 🍺 Hello 🍺 Hello"},
+		{`"hello".gsub("l", "L")`, "heLLo"},
+		{`"hello".gsub("l", "")`, "heo"},
+		{`"hello".gsub("x", "L")`, "hello"},
+		{`"".gsub("l", "L")`, ""},
+		{`
+		a = "hello"
+		a.gsub("l", "L")
+		a
+		`, "hello"},
 	}
 
 	for i, tt := range tests {
@@ -700,6 +869,10 @@ func TestStringLengthMethod(t *testing.T) {
 		{`"New method".length`, 10},
 		{`" ".length`, 1},
 		{`"🍣🍣🍣".length`, 3},
+		{`"".length`, 0},
+		{`"héllo wörld".length`, 11},
+		{`"日本語".length`, 3},
+		{`"hello".length.class.name`, "Integer"},
 	}
 
 	for i, tt := range tests {
@@ -849,6 +1022,11 @@ func TestStringSliceMethod(t *testing.T) {
 		{`"1234567890".slice(-10..-12)`, ""},
 		{`"1234567890".slice(-11..-12)`, nil},
 		{`"1234567890".slice(-11..-5)`, nil},
+		{`"1234567890".slice(1...4)`, "234"},
+		{`"1234567890".slice(1...-1)`, "23456789"},
+		{`"1234567890".slice(1...1)`, ""},
+		{`"1234567890".slice(0...0)`, ""},
+		{`"1234567890".slice(5..20)`, "67890"},
 		{`"Hello 🍣🍺 World".slice(1..6)`, "ello 🍣"},
 		{`"Hello 🍣🍺 World".slice(-10..7)`, "o 🍣🍺"},
 		{`"Hello 🍣🍺 World".slice(1..-1)`, "ello 🍣🍺 World"},
@@ -969,11 +1147,33 @@ func TestStringSplitMethod(t *testing.T) {
 		v.checkCFP(t, i, 0)
 		v.checkSP(t, i, 1)
 	}
+
+	testsArray := []struct {
+		input    string
+		expected []interface{}
+	}{
+		{`"a,b,c".split(",")`, []interface{}{"a", "b", "c"}},
+		{`" Hello  World\n Goby ".split`, []interface{}{"Hello", "World", "Goby"}},
+		{`"   ".split`, []interface{}{}},
+		{`"a,,b".split(",")`, []interface{}{"a", "", "b"}},
+		{`",a,b,,".split(",")`, []interface{}{"", "a", "b"}},
+		{`",,,".split(",")`, []interface{}{}},
+		{`"".split(",")`, []interface{}{}},
+		{`"".split("")`, []interface{}{}},
+	}
+
+	for i, tt := range testsArray {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		testArrayObject(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
 }
 
 func TestStringSplitMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
-		{`"Hello World".split`, "ArgumentError: Expect 1 argument. got=0", 1},
+		{`"Hello World".split(",", 1)`, "ArgumentError: Expect 0 or 1 argument. got=2", 1},
 		{`"Hello World".split(true)`, "TypeError: Expect argument to be String. got: Boolean", 1},
 		{`"Hello World".split(123)`, "TypeError: Expect argument to be String. got: Integer", 1},
 		{`"Hello World".split(1..2)`, "TypeError: Expect argument to be String. got: Range", 1},
@@ -1048,6 +1248,48 @@ func TestStringStripMethod(t *testing.T) {
 	}
 }
 
+func TestStringSubstituteMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"hello".sub("l", "L")`, "heLlo"},
+		{`"hello".sub("l", "")`, "helo"},
+		{`"hello".sub("x", "L")`, "hello"},
+		{`"hello".sub("hello", "")`, ""},
+		{`"Hello 🍣 Hello 🍣".sub("🍣", "🍺")`, "Hello 🍺 Hello 🍣"},
+		{`
+		a = "hello"
+		a.sub("l", "L")
+		a
+		`, "hello"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringSubstituteMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`"Ruby".sub("Ru")`, "ArgumentError: Expect 2 arguments. got=1", 1},
+		{`"Ruby".sub(123, "Go")`, "TypeError: Expect pattern to be String. got: Integer", 1},
+		{`"Ruby".sub("Ru", nil)`, "TypeError: Expect replacement to be String. got: Null", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestStringUpcaseMethod(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -1057,6 +1299,8 @@ func TestStringUpcaseMethod(t *testing.T) {
 		{`"MORE wOrds".upcase`, "MORE WORDS"},
 		{`"Hello\nWorld".upcase`, "HELLO\nWORLD"},
 		{`"🍣Hello🍺".upcase`, "🍣HELLO🍺"},
+		{`"héllo".upcase`, "HÉLLO"},
+		{`a = "Hi"; a.upcase; a`, "Hi"},
 	}
 
 	for i, tt := range tests {
@@ -1103,3 +1347,98 @@ func TestFormattedString(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestStringFormatOperator(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"%d items" % 3`, "3 items"},
+		{`"%s is %d years old" % ["Goby", 5]`, "Goby is 5 years old"},
+		{`"%i" % 3`, "3"},
+		{`"%+d" % 3`, "+3"},
+		{`"%d" % -3.7`, "-3"},
+		{`"%d" % (10 ** 30)`, "1000000000000000000000000000000"},
+		{`"%.2f" % 3.14159`, "3.14"},
+		{`"%f" % 1`, "1.000000"},
+		{`"%e" % 12345.678`, "1.234568e+04"},
+		{`"%05x|%X|%o|%b" % [255, 255, 8, 5]`, "000ff|FF|10|101"},
+		{`"%-5s|%5s|" % ["ab", "cd"]`, "ab   |   cd|"},
+		{`"%s" % [[1, 2]]`, "[1, 2]"},
+		{`"%s" % nil`, ""},
+		{`"%s" % :foo`, "foo"},
+		{`"100%%" % []`, "100%"},
+		{`
+		class Foo
+		  def to_s
+		    "foo"
+		  end
+		end
+
+		"%s!" % Foo.new
+		`, "foo!"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringFormatOperatorFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`"%d %d" % 1`, "ArgumentError: Expect 2 arguments. got: 1", 1},
+		{`"%d" % [1, 2]`, "ArgumentError: Expect 1 arguments. got: 2", 1},
+		{`"%y" % 1`, "ArgumentError: Malformed format string - %y", 1},
+		{`"%" % 1`, "ArgumentError: Malformed format string - %", 1},
+		{`"%d" % "a"`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`"%f" % nil`, "TypeError: Expect argument to be Numeric. got: Null", 1},
+		{`"%d" % (1 / 0.0)`, "ArgumentError: Can't convert Infinity into Integer", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSprintfMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`sprintf("%d items for $%d", 3, 30)`, "3 items for $30"},
+		{`sprintf("%.1f%%", 12.34)`, "12.3%"},
+		{`sprintf("no directives")`, "no directives"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSprintfMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`sprintf`, "ArgumentError: Expect at least 1 args for method 'sprintf'. got: 0", 1},
+		{`sprintf(1)`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`sprintf("%s and %s", 1)`, "ArgumentError: Expect 2 arguments. got: 1", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -0,0 +1,172 @@
+package vm
+
+import (
+	"strconv"
+	"sync"
+)
+
+// symbolTable stores every symbol created in the vm, so symbols with the same name are always the same object
+type symbolTable struct {
+	store map[string]*SymbolObject
+	sync.RWMutex
+}
+
+func (vm *VM) initSymbolObject(value string) *SymbolObject {
+	st := vm.symbolTable
+	st.RLock()
+	s, ok := st.store[value]
+	st.RUnlock()
+
+	if ok {
+		return s
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	// Another thread may create the same symbol before we acquire the lock
+	if s, ok := st.store[value]; ok {
+		return s
+	}
+
+	s = &SymbolObject{
+		baseObj: &baseObj{class: vm.topLevelClass(symbolClass), frozen: true},
+		value:   value,
+	}
+	st.store[value] = s
+
+	return s
+}
+
+func (vm *VM) initSymbolClass() *RClass {
+	sc := vm.initializeClass(symbolClass, false)
+	sc.setBuiltInMethods(builtinSymbolInstanceMethods(), false)
+	sc.setBuiltInMethods(builtInSymbolClassMethods(), true)
+	return sc
+}
+
+// SymbolObject represents a name, which is written as `:name` in Goby.
+// Symbols with the same name are always the same object, and a symbol never equals to a string.
+// As a hash key, a symbol references the same key as the string of its name, see `Hash` for details.
+// Since symbols are shared, they're frozen like integers: setting their instance variables raises a FrozenError
+// and defining their singleton methods raises a TypeError.
+//
+// ```ruby
+// :foo == :foo  # => true
+// :foo == "foo" # => false
+// :foo.to_s     # => "foo"
+// ```
+//
+// - `Symbol.new` is not supported.
+type SymbolObject struct {
+	*baseObj
+	value string
+}
+
+func (s *SymbolObject) Value() interface{} {
+	return s.value
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// toString returns symbol's inspected form, like `:foo`
+func (s *SymbolObject) toString() string {
+	return ":" + s.value
+}
+
+// toJSON converts the receiver into JSON string.
+func (s *SymbolObject) toJSON() string {
+	return strconv.Quote(s.value)
+}
+
+// hashKey returns the same key as a String with the symbol's name, since hash literal keys are Strings
+func (s *SymbolObject) hashKey() string {
+	return s.value
+}
+
+func builtInSymbolClassMethods() []*BuiltInMethodObject {
+	return []*BuiltInMethodObject{
+		{
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.unsupportedMethodError("#new", receiver)
+				}
+			},
+		},
+	}
+}
+
+func builtinSymbolInstanceMethods() []*BuiltInMethodObject {
+	return []*BuiltInMethodObject{
+		{
+			// Returns true if the given object is the same symbol.
+			//
+			// ```ruby
+			// :foo == :foo  # => true
+			// :foo == :bar  # => false
+			// :foo == "foo" # => false
+			// ```
+			//
+			// @return [Boolean]
+			Name:  "==",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver == args[0])
+				}
+			},
+		},
+		{
+			// Returns true if the given object is not the same symbol.
+			//
+			// ```ruby
+			// :foo != :foo  # => false
+			// :foo != "foo" # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name:  "!=",
+			Arity: &Arity{1, 1},
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if receiver == args[0] {
+						return FALSE
+					}
+
+					return TRUE
+				}
+			},
+		},
+		{
+			// Returns the symbol's name as a string.
+			//
+			// ```ruby
+			// :foo.to_s # => "foo"
+			// ```
+			//
+			// @return [String]
+			Name: "to_s",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.(*SymbolObject).value)
+				}
+			},
+		},
+		{
+			// Returns the symbol itself.
+			//
+			// ```ruby
+			// :foo.to_sym # => :foo
+			// ```
+			//
+			// @return [Symbol]
+			Name: "to_sym",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver
+				}
+			},
+		},
+	}
+}
@@ -0,0 +1,163 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestSymbolClassSuperclass(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`Symbol.class.name`, "Class"},
+		{`Symbol.superclass.name`, "Object"},
+		{`:foo.class.name`, "Symbol"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolEquality(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`:foo == :foo`, true},
+		{`:foo == :bar`, false},
+		{`:foo == "foo"`, false},
+		{`"foo" == :foo`, false},
+		{`:foo != :foo`, false},
+		{`:foo != :bar`, true},
+		{`:foo != "foo"`, true},
+		{`"foo".to_sym == :foo`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolInterning(t *testing.T) {
+	v := initTestVM()
+
+	if v.initSymbolObject("foo") != v.initSymbolObject("foo") {
+		t.Fatal("Expect symbols with the same name to be the same object")
+	}
+
+	if v.initSymbolObject("foo") == v.initSymbolObject("bar") {
+		t.Fatal("Expect symbols with different names to be different objects")
+	}
+}
+
+func TestSymbolFrozen(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`:foo.frozen?`, true},
+		{`"foo".to_sym.frozen?`, true},
+		// Interned symbols don't share state
+		{`
+		begin
+		  :foo.instance_variable_set("@x", 1)
+		rescue
+		end
+		"foo".to_sym.instance_variable_get("@x")
+		`, nil},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolFrozenFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`:foo.instance_variable_set("@x", 1)`, "FrozenError: Can't modify frozen Symbol: :foo", 1},
+		{`a = :foo
+		def a.bar
+		end`, "TypeError: can't define singleton method 'bar' for :foo", 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolConversion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`:foo.to_s`, "foo"},
+		{`:foo.to_s.class.name`, "String"},
+		{`:foo.to_sym == :foo`, true},
+		{`"#{:foo}"`, "foo"},
+		{`[:foo, "bar"].to_s`, `[:foo, "bar"]`},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolAsHashKey(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`{ foo: 1 }[:foo]`, 1},
+		{`
+		h = {}
+		h[:foo] = 10
+		h["foo"]
+		`, 10},
+		{`{ foo: 1 }.has_key?(:foo)`, true},
+		{`{ foo: 1 }.has_key?(:bar)`, false},
+		{`{ foo: 1, bar: 2 }.delete(:foo).to_s`, `{ bar: 2 }`},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolNewFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Symbol.new`, "UnsupportedMethodError: Unsupported Method #new for Symbol", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -14,6 +14,10 @@ type thread struct {
 	stack *stack
 	// stack pointer
 	sp int
+	// tailCallFrame is the frame of a tail call, which replaces the frame being evaluated
+	tailCallFrame *callFrame
+	// breakableBlocks are the blocks given to the method calls in progress, `break` can only stop these calls
+	breakableBlocks []*callFrame
 
 	vm *VM
 }
@@ -43,17 +47,61 @@ func (t *thread) evalCallFrame(cf *callFrame) {
 	for cf.pc < len(cf.instructionSet.instructions) {
 		i := cf.instructionSet.instructions[cf.pc]
 		t.execInstruction(cf, i)
+
+		if t.tailCallFrame != nil {
+			cf = t.tailCallFrame
+			t.tailCallFrame = nil
+		}
+
 		if _, yes := t.hasError(); yes {
+			if t.rescueError(cf) {
+				continue
+			}
+
 			return
 		}
 	}
 }
 
+// rescueError moves the raised error to the latest rescue handler of given frame, it returns false if there's no handler
+func (t *thread) rescueError(cf *callFrame) bool {
+	if len(cf.rescueHandlers) == 0 {
+		return false
+	}
+
+	err := t.stack.top().Target.(*Error)
+	handler := cf.rescueHandlers[len(cf.rescueHandlers)-1]
+	cf.rescueHandlers = cf.rescueHandlers[:len(cf.rescueHandlers)-1]
+
+	// Frames of methods or blocks the error is raised from are not popped
+	for t.callFrameStack.top() != cf {
+		t.callFrameStack.pop()
+	}
+
+	err.rescued = true
+	t.sp = handler.sp
+	t.stack.push(&Pointer{Target: err})
+	cf.pc = handler.pc
+
+	return true
+}
+
+// hasRescueHandler returns true if any frame of the thread can rescue the raised error
+func (t *thread) hasRescueHandler() bool {
+	for _, cf := range t.callFrameStack.callFrames[:t.cfp] {
+		if len(cf.rescueHandlers) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (t *thread) hasError() (string, bool) {
 	var hasError bool
 	var msg string
 	if t.stack.top() != nil {
-		if err, ok := t.stack.top().Target.(*Error); ok {
+		if err, ok := t.stack.top().Target.(*Error); ok && !err.rescued {
 			hasError = true
 			msg = err.Message
 		}
@@ -67,7 +115,22 @@ func (t *thread) execInstruction(cf *callFrame, i *instruction) {
 	i.action.operation(t, cf, i.Params...)
 }
 
+// builtInMethodYield yields the block from a builtin method and returns the block's result.
+// If the block raises an error, it unwinds the builtin method with a blockError, so the method doesn't keep running
+// and its result doesn't replace the error. The error becomes the method call's result in evalBuiltInMethod.
 func (t *thread) builtInMethodYield(blockFrame *callFrame, args ...Object) *Pointer {
+	result := t.yieldBlock(blockFrame, args...)
+
+	if _, raised := t.hasError(); raised {
+		panic(&blockError{err: result.Target.(*Error)})
+	}
+
+	return result
+}
+
+// yieldBlock yields the block and returns the block's result, which is the raised error if the block raises one.
+// It's used by the threads that start from a block, which have no builtin method call to unwind.
+func (t *thread) yieldBlock(blockFrame *callFrame, args ...Object) *Pointer {
 	c := newCallFrame(blockFrame.instructionSet)
 	c.blockFrame = blockFrame
 	c.ep = blockFrame.ep
@@ -83,6 +146,72 @@ func (t *thread) builtInMethodYield(blockFrame *callFrame, args ...Object) *Poin
 	return t.stack.top()
 }
 
+// blockBreak is raised by `break` in a block, it unwinds to the method call the block is given to
+type blockBreak struct {
+	blockFrame *callFrame
+	value      Object
+}
+
+// rescueBlockBreak is deferred by method calls with a block. If the block is stopped by `break`,
+// it removes the frames above the caller and makes break's value the method call's result.
+func (t *thread) rescueBlockBreak(blockFrame *callFrame, receiverPr int) {
+	t.breakableBlocks = t.breakableBlocks[:len(t.breakableBlocks)-1]
+	r := recover()
+
+	if r == nil {
+		return
+	}
+
+	b, ok := r.(*blockBreak)
+
+	if !ok || b.blockFrame != blockFrame {
+		panic(r)
+	}
+
+	for t.callFrameStack.top() != blockFrame.ep {
+		t.callFrameStack.pop()
+	}
+
+	t.stack.set(receiverPr, &Pointer{Target: b.value})
+	t.sp = receiverPr + 1
+}
+
+// isBreakable returns true if the method call the block is given to is still in progress on the thread.
+// It's false for blocks called after the method returns and blocks yielded by another thread, like `thread`'s.
+func (t *thread) isBreakable(blockFrame *callFrame) bool {
+	for _, b := range t.breakableBlocks {
+		if b == blockFrame {
+			return true
+		}
+	}
+
+	return false
+}
+
+// blockError is raised by builtInMethodYield when the block raises an error, it unwinds the builtin method yielding the block
+type blockError struct {
+	err *Error
+}
+
+// rescueBlockError is deferred by builtin method calls. If a block yielded by the method raised an error,
+// it makes the error the method call's result. The frames the error is raised from are left for rescueError.
+func (t *thread) rescueBlockError(receiverPr int) {
+	r := recover()
+
+	if r == nil {
+		return
+	}
+
+	e, ok := r.(*blockError)
+
+	if !ok {
+		panic(r)
+	}
+
+	t.stack.set(receiverPr, &Pointer{Target: e.err})
+	t.sp = receiverPr + 1
+}
+
 func (t *thread) retrieveBlock(cf *callFrame, args []interface{}) (blockFrame *callFrame) {
 	var blockName string
 	var hasBlock bool
@@ -112,6 +241,10 @@ func (t *thread) retrieveBlock(cf *callFrame, args []interface{}) (blockFrame *c
 }
 
 func (t *thread) evalBuiltInMethod(receiver Object, method *BuiltInMethodObject, receiverPr, argCount int, blockFrame *callFrame) {
+	if blockFrame != nil {
+		defer t.rescueBlockError(receiverPr)
+	}
+
 	methodBody := method.Fn(receiver)
 	args := []Object{}
 	argPr := receiverPr + 1
@@ -120,7 +253,13 @@ func (t *thread) evalBuiltInMethod(receiver Object, method *BuiltInMethodObject,
 		args = append(args, t.stack.Data[argPr+i].Target)
 	}
 
-	evaluated := methodBody(t, args, blockFrame)
+	var evaluated Object
+
+	if err := method.checkArity(t, argCount); err != nil {
+		evaluated = err
+	} else {
+		evaluated = methodBody(t, args, blockFrame)
+	}
 
 	_, ok := receiver.(*RClass)
 	if method.Name == "new" && ok {
@@ -129,41 +268,175 @@ func (t *thread) evalBuiltInMethod(receiver Object, method *BuiltInMethodObject,
 			t.evalMethodObject(instance, instance.InitializeMethod, receiverPr, argCount, blockFrame)
 		}
 	}
+
+	// Block frame is popped after the block is yielded, so we need to pop it if the method never yields it.
+	// For example: `[].each do ... end`
+	if blockFrame != nil && t.callFrameStack.top() == blockFrame {
+		t.callFrameStack.pop()
+	}
+
 	t.stack.set(receiverPr, &Pointer{Target: evaluated})
 	t.sp = argPr
 }
 
 func (t *thread) evalMethodObject(receiver Object, method *MethodObject, receiverPr, argC int, blockFrame *callFrame) {
+	c := t.newMethodFrame(receiver, method, receiverPr, argC, blockFrame)
+
+	if c == nil {
+		return
+	}
+
+	t.callFrameStack.push(c)
+	t.startFromTopFrame()
+
+	t.stack.set(receiverPr, t.stack.top())
+	t.sp = receiverPr + 1
+}
+
+// evalTailCall replaces the current method frame with the frame of the method it calls in tail position,
+// so a tail-recursive method runs in a loop instead of growing the frames or the Go stack.
+// The current frame isn't reused because blocks created in it may still refer to its locals.
+func (t *thread) evalTailCall(cf *callFrame, method *MethodObject, receiverPr, argC int) {
+	c := t.newMethodFrame(cf.self, method, receiverPr, argC, nil)
+
+	if c == nil {
+		return
+	}
+
+	t.callFrameStack.pop()
+	t.callFrameStack.push(c)
+	t.sp = receiverPr
+	t.tailCallFrame = c
+}
+
+// newMethodFrame returns a frame of the method with the arguments on the stack assigned.
+// When the arguments don't match the parameters, it places an ArgumentError at the receiver's position and returns nil.
+func (t *thread) newMethodFrame(receiver Object, method *MethodObject, receiverPr, argC int, blockFrame *callFrame) *callFrame {
 	c := newCallFrame(method.instructionSet)
 	c.self = receiver
+	c.ep = method.ep
 	argPr := receiverPr + 1
+	argTypes := method.instructionSet.argTypes
 	minimumArgNumber := 0
+	splatIndex := -1
+	keywordIndex := len(argTypes)
 
-	for _, at := range method.instructionSet.argTypes {
-		if at == bytecode.NormalArg {
+	for i, at := range argTypes {
+		switch at {
+		case bytecode.NormalArg:
 			minimumArgNumber++
+		case bytecode.SplatArg:
+			splatIndex = i
+		case bytecode.RequiredKeywordArg, bytecode.OptionalKeywordArg:
+			if keywordIndex == len(argTypes) {
+				keywordIndex = i
+			}
 		}
 	}
 
-	if argC > method.argc {
-		e := t.vm.initErrorObject(ArgumentError, "Expect at most %d args for method '%s'. got: %d", method.argc, method.Name, argC)
+	c.args = make([]Object, argC)
+
+	for i := 0; i < argC; i++ {
+		c.args[i] = t.stack.Data[argPr+i].Target
+	}
+
+	// Keyword arguments are passed as a hash in the last argument
+	args := c.args
+	var keywords *HashObject
+
+	if keywordIndex < len(argTypes) {
+		keywords = t.vm.initHashObject(map[string]Object{})
+
+		if h, ok := lastArg(args).(*HashObject); ok && len(args)-1 >= minimumArgNumber {
+			keywords = h
+			args = args[:len(args)-1]
+		}
+	}
+
+	if len(args) > keywordIndex && splatIndex == -1 {
+		e := t.vm.initErrorObject(ArgumentError, "Expect at most %d args for method '%s'. got: %d", keywordIndex, method.Name, len(args))
 		t.stack.set(receiverPr, &Pointer{Target: e})
 		t.sp = argPr
-		return
+		return nil
 	}
 
-	if minimumArgNumber > argC {
-		e := t.vm.initErrorObject(ArgumentError, "Expect at least %d args for method '%s'. got: %d", minimumArgNumber, method.Name, argC)
+	if minimumArgNumber > len(args) {
+		e := t.vm.initErrorObject(ArgumentError, "Expect at least %d args for method '%s'. got: %d", minimumArgNumber, method.Name, len(args))
 		t.stack.set(receiverPr, &Pointer{Target: e})
 		t.sp = argPr
-		return
+		return nil
 	}
 
+	if keywords != nil {
+		err := t.assignKeywordArgs(c, method, keywordIndex, keywords)
+
+		if err != nil {
+			t.stack.set(receiverPr, &Pointer{Target: err})
+			t.sp = argPr
+			return nil
+		}
+	}
+
+	if splatIndex == -1 {
+		assignNormalArgs(c, argTypes[:keywordIndex], args, minimumArgNumber)
+	} else {
+		t.assignSplatArgs(c, argTypes[:keywordIndex], args, splatIndex, minimumArgNumber)
+	}
+
+	c.blockFrame = blockFrame
+	c.method = method
+
+	return c
+}
+
+// assignKeywordArgs assigns keyword arguments by their names. It returns an ArgumentError if a required keyword is missing
+// or an unknown keyword is given.
+func (t *thread) assignKeywordArgs(c *callFrame, method *MethodObject, keywordIndex int, keywords *HashObject) *Error {
+	argTypes := method.instructionSet.argTypes
+	argNames := method.instructionSet.argNames
+	keywordNames := map[string]bool{}
+
+	for i := keywordIndex; i < len(argTypes); i++ {
+		name := argNames[i]
+		keywordNames[name] = true
+		value, ok := keywords.Pairs[name]
+
+		if !ok {
+			if argTypes[i] == bytecode.RequiredKeywordArg {
+				return t.vm.initErrorObject(ArgumentError, "Missing keyword argument '%s' for method '%s'", name, method.Name)
+			}
+
+			continue
+		}
+
+		c.insertLCL(i, 0, value)
+	}
+
+	for _, key := range keywords.sortedKeys() {
+		if !keywordNames[key] {
+			return t.vm.initErrorObject(ArgumentError, "Unknown keyword argument '%s' for method '%s'", key, method.Name)
+		}
+	}
+
+	return nil
+}
+
+func lastArg(args []Object) Object {
+	if len(args) == 0 {
+		return nil
+	}
+
+	return args[len(args)-1]
+}
+
+// assignNormalArgs assigns arguments to a method that doesn't have a splat parameter
+func assignNormalArgs(c *callFrame, argTypes []int, args []Object, minimumArgNumber int) {
+	argC := len(args)
 	argIndex := 0
 
-	for i, argType := range method.instructionSet.argTypes {
+	for i, argType := range argTypes {
 		if argType == bytecode.NormalArg {
-			c.insertLCL(i, 0, t.stack.Data[argPr+argIndex].Target)
+			c.insertLCL(i, 0, args[argIndex])
 			argIndex++
 		}
 	}
@@ -188,9 +461,9 @@ func (t *thread) evalMethodObject(receiver Object, method *MethodObject, receive
 
 	if minimumArgNumber < argC {
 		// Fill arguments with default value from beginning
-		for i, argType := range method.instructionSet.argTypes {
-			if argType != bytecode.NormalArg {
-				c.insertLCL(i, 0, t.stack.Data[argPr+argIndex].Target)
+		for i, argType := range argTypes {
+			if argType == bytecode.OptionedArg {
+				c.insertLCL(i, 0, args[argIndex])
 				argIndex++
 			}
 
@@ -200,13 +473,104 @@ func (t *thread) evalMethodObject(receiver Object, method *MethodObject, receive
 			}
 		}
 	}
+}
 
-	c.blockFrame = blockFrame
-	t.callFrameStack.push(c)
-	t.startFromTopFrame()
+// assignSplatArgs assigns arguments to a method that has a splat parameter. For example:
+//
+//	def foo(a, b = 10, *c, d); end
+//	foo(1, 2, 3, 4, 5)
+//
+// Parameters after the splat parameter take the last arguments, so `d` would be 5.
+// Parameters before it are assigned from the beginning, but an optioned one only takes an argument
+// when there are more arguments than required parameters, so `a` would be 1 and `b` would be 2.
+// The rest of the arguments are collected into an array, so `c` would be [3, 4].
+func (t *thread) assignSplatArgs(c *callFrame, argTypes []int, args []Object, splatIndex, minimumArgNumber int) {
+	argC := len(args)
+	extraArgC := argC - minimumArgNumber
+	afterSplatArgC := len(argTypes) - splatIndex - 1
+	argIndex := 0
 
-	t.stack.set(receiverPr, t.stack.top())
-	t.sp = argPr
+	for i, argType := range argTypes[:splatIndex] {
+		switch argType {
+		case bytecode.NormalArg:
+			c.insertLCL(i, 0, args[argIndex])
+			argIndex++
+		case bytecode.OptionedArg:
+			if extraArgC > 0 {
+				c.insertLCL(i, 0, args[argIndex])
+				argIndex++
+				extraArgC--
+			}
+		}
+	}
+
+	restArgs := []Object{}
+
+	for ; argIndex < argC-afterSplatArgC; argIndex++ {
+		restArgs = append(restArgs, args[argIndex])
+	}
+
+	c.insertLCL(splatIndex, 0, t.vm.initArrayObject(restArgs))
+
+	for i := splatIndex + 1; i < len(argTypes); i++ {
+		c.insertLCL(i, 0, args[argIndex])
+		argIndex++
+	}
+}
+
+// sendMethod calls receiver's method with given arguments and returns the result, it's for calling Goby methods in Go
+func (t *thread) sendMethod(receiver Object, methodName string, args ...Object) Object {
+	return t.sendMethodWithBlock(receiver, methodName, nil, args...)
+}
+
+// sendMethodWithBlock is like sendMethod but also passes the given block frame to the method
+func (t *thread) sendMethodWithBlock(receiver Object, methodName string, blockFrame *callFrame, args ...Object) Object {
+	method := receiver.findMethod(methodName)
+
+	if method == nil {
+		method = t.findMethodMissing(receiver)
+
+		if method == nil {
+			return t.vm.initErrorObject(UndefinedMethodError, "Undefined Method '%+v' for %+v", methodName, receiver.toString())
+		}
+
+		args = append([]Object{t.vm.initSymbolObject(methodName)}, args...)
+	}
+
+	receiverPr := t.sp
+	t.stack.push(&Pointer{Target: receiver})
+
+	for _, arg := range args {
+		t.stack.push(&Pointer{Target: arg})
+	}
+
+	switch m := method.(type) {
+	case *MethodObject:
+		t.evalMethodObject(receiver, m, receiverPr, len(args), blockFrame)
+	case *BuiltInMethodObject:
+		t.evalBuiltInMethod(receiver, m, receiverPr, len(args), blockFrame)
+	}
+
+	result := t.stack.Data[receiverPr].Target
+	t.sp = receiverPr
+
+	return result
+}
+
+// methodMissing is the name of the method which is called when the receiver doesn't have the called method
+const methodMissing = "method_missing"
+
+// findMethodMissing returns receiver's `method_missing` method. It returns nil if the receiver's `method_missing` is
+// already being called, so calling a missing method inside `method_missing` raises an error instead of infinite recursion.
+// It checks the whole call stack, so a missing method called by a helper of `method_missing` is also caught.
+func (t *thread) findMethodMissing(receiver Object) Object {
+	for _, cf := range t.callFrameStack.callFrames[:t.cfp] {
+		if cf.method != nil && cf.method.Name == methodMissing && cf.self == receiver {
+			return nil
+		}
+	}
+
+	return receiver.findMethod(methodMissing)
 }
 
 func (t *thread) returnError(errorType, format string, args ...interface{}) {
@@ -217,3 +581,7 @@ func (t *thread) returnError(errorType, format string, args ...interface{}) {
 func (t *thread) unsupportedMethodError(methodName string, receiver Object) *Error {
 	return t.vm.initErrorObject(UnsupportedMethodError, "Unsupported Method %s for %+v", methodName, receiver.toString())
 }
+
+func (t *thread) frozenError(receiver Object) *Error {
+	return t.vm.initErrorObject(FrozenError, CantModifyFrozenFormat, receiver.Class().Name, receiver.toString())
+}
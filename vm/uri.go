@@ -15,15 +15,7 @@ func initURIClass(vm *VM) {
 	uri.setClassConstant(https)
 	uri.setBuiltInMethods(builtinURIClassMethods(), true)
 
-	attrs := []Object{
-		vm.initStringObject("host"),
-		vm.initStringObject("path"),
-		vm.initStringObject("port"),
-		vm.initStringObject("query"),
-		vm.initStringObject("scheme"),
-		vm.initStringObject("user"),
-		vm.initStringObject("password"),
-	}
+	attrs := []string{"host", "path", "port", "query", "scheme", "user", "password"}
 
 	http.setAttrReader(attrs)
 	http.setAttrWriter(attrs)
@@ -43,7 +35,8 @@ func builtinURIClassMethods() []*BuiltInMethodObject {
 			// u.port # => 80
 			// u.path # => "/"
 			// ```
-			Name: "parse",
+			Name:  "parse",
+			Arity: &Arity{1, 1},
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					uri := args[0].(*StringObject).value
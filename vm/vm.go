@@ -5,6 +5,7 @@ import (
 	"github.com/goby-lang/goby/compiler"
 	"github.com/goby-lang/goby/compiler/bytecode"
 	"github.com/goby-lang/goby/compiler/parser"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -70,17 +71,33 @@ type VM struct {
 
 	channelObjectMap *objectMap
 
+	symbolTable *symbolTable
+
+	globalVariables *globalVariableTable
+
+	// integerTable holds preallocated small integer objects
+	integerTable []*IntegerObject
+
 	sync.Mutex
 
 	mode int
+
+	// replError holds the uncaught error that aborted current REPL evaluation
+	replError *Error
+
+	// warningOutput is where warnings like reassigning a constant are printed, which is stderr by default
+	warningOutput io.Writer
 }
 
 // New initializes a vm to initialize state and returns it.
 func New(fileDir string, args []string) (vm *VM, e error) {
-	vm = &VM{args: args}
+	vm = &VM{args: args, warningOutput: os.Stderr}
+	vm.symbolTable = &symbolTable{store: map[string]*SymbolObject{}}
+	vm.globalVariables = &globalVariableTable{store: map[string]Object{}}
 	vm.mainThread = vm.newThread()
 
 	vm.initConstants()
+	vm.initIntegerTable()
 	vm.methodISIndexTables = map[filename]*isIndexTable{
 		filename(fileDir): newISIndexTable(),
 	}
@@ -170,7 +187,7 @@ func (vm *VM) SetMethodISIndexTable(fn filename) {
 func (vm *VM) initMainObj() *RObject {
 	obj := vm.objectClass.initializeInstance()
 	singletonClass := vm.initializeClass(fmt.Sprintf("#<Class:%s>", obj.toString()), false)
-	singletonClass.Methods.set("include", vm.topLevelClass(classClass).lookupMethod("include"))
+	singletonClass.setMethod("include", vm.topLevelClass(classClass).lookupMethod("include"))
 	obj.singletonClass = singletonClass
 
 	return obj
@@ -181,14 +198,20 @@ func (vm *VM) initConstants() {
 	vm.objectClass = initObjectClass(cClass)
 	vm.topLevelClass(objectClass).setClassConstant(cClass)
 
+	ic := vm.initIntegerClass()
+
 	builtInClasses := []*RClass{
-		vm.initIntegerClass(),
+		ic,
+		vm.initBigIntegerClass(ic),
+		vm.initFloatClass(),
 		vm.initStringClass(),
+		vm.initSymbolClass(),
 		vm.initBoolClass(),
 		vm.initNullClass(),
 		vm.initArrayClass(),
 		vm.initHashClass(),
 		vm.initRangeClass(),
+		vm.initEnumeratorClass(),
 		vm.initMethodClass(),
 		vm.initChannelClass(),
 		vm.initGoClass(),
@@ -218,6 +241,13 @@ func (vm *VM) initConstants() {
 	vm.objectClass.constants["ENV"] = &Pointer{Target: vm.initHashObject(envs)}
 }
 
+// warn prints a warning with the source line of the instruction being executed in given call frame
+func (vm *VM) warn(cf *callFrame, format string, args ...interface{}) {
+	i := cf.instructionSet.instructions[cf.pc-1]
+	// Add 1 to source line because it's zero indexed
+	fmt.Fprintf(vm.warningOutput, "%s:%d: warning: %s\n", cf.instructionSet.filename, i.sourceLine+1, fmt.Sprintf(format, args...))
+}
+
 func (vm *VM) topLevelClass(cn string) *RClass {
 	objClass := vm.objectClass
 
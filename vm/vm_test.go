@@ -135,7 +135,11 @@ foo
 			p.Mode = parser.REPLMode
 
 			program, _ := p.ParseProgram()
-			sets := g.GenerateInstructions(program.Statements)
+			sets, err := g.GenerateInstructions(program.Statements)
+
+			if err != nil {
+				t.Fatal(err.Error())
+			}
 
 			v.REPLExec(sets)
 		}
@@ -147,6 +151,70 @@ foo
 	}
 }
 
+func TestVM_REPLExecWithError(t *testing.T) {
+	tests := []struct {
+		inputs   []string
+		expected []string
+	}{
+		{
+			[]string{
+				`a = 10`,
+				`a.foo`,
+				`a + 1`,
+			}, []string{"10", "ERROR: UndefinedMethodError: Undefined Method 'foo' for 10. At :1", "11"}},
+		{
+			[]string{
+				`a = 10`,
+				`
+def foo
+  bar
+end
+`,
+				`
+[1, 2].each do |i|
+  foo
+end
+`,
+				`a + 1`,
+			}, []string{"10", "", "ERROR: UndefinedMethodError: Undefined Method 'bar' for <Instance of: Object>. At :3", "11"}},
+	}
+
+	for i, test := range tests {
+		v := initTestVM()
+		v.InitForREPL()
+
+		p := parser.New(lexer.New(""))
+		p.Mode = parser.REPLMode
+
+		program, _ := p.ParseProgram()
+
+		g := bytecode.NewGenerator()
+		g.REPL = true
+		g.InitTopLevelScope(program)
+
+		for j, input := range test.inputs {
+			p := parser.New(lexer.New(input))
+			p.Mode = parser.REPLMode
+
+			program, _ := p.ParseProgram()
+			sets, err := g.GenerateInstructions(program.Statements)
+
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			v.REPLExec(sets)
+
+			if r := v.GetREPLResult(); r != test.expected[j] {
+				t.Fatalf("At case %d input %d expect result to be %q. got: %q", i, j, test.expected[j], r)
+			}
+
+			// An error shouldn't leave any frame behind except REPL's base frame
+			v.checkCFP(t, i, 1)
+		}
+	}
+}
+
 func initTestVM() *VM {
 	fn, err := os.Getwd()
 
@@ -209,6 +277,24 @@ func testIntegerObject(t *testing.T, i int, obj Object, expected int) bool {
 	}
 }
 
+func testFloatObject(t *testing.T, i int, obj Object, expected float64) bool {
+	switch result := obj.(type) {
+	case *FloatObject:
+		if result.value != expected {
+			t.Fatalf("At test case %d: object has wrong value. expect=%f, got=%f", i, expected, result.value)
+			return false
+		}
+
+		return true
+	case *Error:
+		t.Fatalf("At test case %d: %s", i, result.Message)
+		return false
+	default:
+		t.Fatalf("At test case %d: object is not Float. got=%T (%+v).", i, obj, obj)
+		return false
+	}
+}
+
 func testNullObject(t *testing.T, i int, obj Object) bool {
 	switch result := obj.(type) {
 	case *NullObject:
@@ -285,6 +371,8 @@ func checkExpected(t *testing.T, i int, evaluated Object, expected interface{})
 	switch expected := expected.(type) {
 	case int:
 		testIntegerObject(t, i, evaluated, expected)
+	case float64:
+		testFloatObject(t, i, evaluated, expected)
 	case string:
 		testStringObject(t, i, evaluated, expected)
 	case bool: